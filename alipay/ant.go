@@ -1,17 +1,20 @@
 package alipay
 
 import (
+	"context"
 	"encoding/json"
-	"fmt"
 
 	"github.com/yuanqinguo/gopay"
 )
 
 // ant.merchant.expand.shop.modify(修改蚂蚁店铺)
 //	文档地址：https://opendocs.alipay.com/apis/014tmb
-func (a *Client) AntMerchantShopModify(bm gopay.BodyMap) (aliRsp *AntMerchantShopModifyRsp, err error) {
-	var bs []byte
-	if bs, err = a.doAliPay(bm, "ant.merchant.expand.shop.modify"); err != nil {
+func (a *Client) AntMerchantShopModify(ctx context.Context, bm gopay.BodyMap) (aliRsp *AntMerchantShopModifyRsp, err error) {
+	var (
+		bs          []byte
+		encSignData string
+	)
+	if bs, encSignData, err = a.doAliPay(ctx, bm, "ant.merchant.expand.shop.modify"); err != nil {
 		return nil, err
 	}
 	aliRsp = new(AntMerchantShopModifyRsp)
@@ -20,22 +23,25 @@ func (a *Client) AntMerchantShopModify(bm gopay.BodyMap) (aliRsp *AntMerchantSho
 	}
 	if aliRsp.Response != nil && aliRsp.Response.Code != "10000" {
 		info := aliRsp.Response
-		return aliRsp, fmt.Errorf(`{"code":"%s","msg":"%s","sub_code":"%s","sub_msg":"%s"}`, info.Code, info.Msg, info.SubCode, info.SubMsg)
+		return aliRsp, bizError(info.Code, info.Msg, info.SubCode, info.SubMsg, bs)
 	}
-	signData, signDataErr := a.getSignData(bs, aliRsp.AlipayCertSn)
+	signData, signDataErr := a.getSignData(bs, encSignData, aliRsp.AlipayCertSn)
 	aliRsp.SignData = signData
 	return aliRsp, a.autoVerifySignByCert(aliRsp.Sign, signData, signDataErr)
 }
 
 // ant.merchant.expand.shop.create(蚂蚁店铺创建)
 //	文档地址：https://opendocs.alipay.com/apis/api_1/ant.merchant.expand.shop.create
-func (a *Client) AntMerchantShopCreate(bm gopay.BodyMap) (aliRsp *AntMerchantShopCreateRsp, err error) {
+func (a *Client) AntMerchantShopCreate(ctx context.Context, bm gopay.BodyMap) (aliRsp *AntMerchantShopCreateRsp, err error) {
 	err = bm.CheckEmptyError("business_address", "shop_category", "store_id", "shop_type", "ip_role_id", "shop_name")
 	if err != nil {
 		return nil, err
 	}
-	var bs []byte
-	if bs, err = a.doAliPay(bm, "ant.merchant.expand.shop.create"); err != nil {
+	var (
+		bs          []byte
+		encSignData string
+	)
+	if bs, encSignData, err = a.doAliPay(ctx, bm, "ant.merchant.expand.shop.create"); err != nil {
 		return nil, err
 	}
 	aliRsp = new(AntMerchantShopCreateRsp)
@@ -44,22 +50,25 @@ func (a *Client) AntMerchantShopCreate(bm gopay.BodyMap) (aliRsp *AntMerchantSho
 	}
 	if aliRsp.Response != nil && aliRsp.Response.Code != "10000" {
 		info := aliRsp.Response
-		return aliRsp, fmt.Errorf(`{"code":"%s","msg":"%s","sub_code":"%s","sub_msg":"%s"}`, info.Code, info.Msg, info.SubCode, info.SubMsg)
+		return aliRsp, bizError(info.Code, info.Msg, info.SubCode, info.SubMsg, bs)
 	}
-	signData, signDataErr := a.getSignData(bs, aliRsp.AlipayCertSn)
+	signData, signDataErr := a.getSignData(bs, encSignData, aliRsp.AlipayCertSn)
 	aliRsp.SignData = signData
 	return aliRsp, a.autoVerifySignByCert(aliRsp.Sign, signData, signDataErr)
 }
 
 // ant.merchant.expand.shop.consult(蚂蚁店铺创建咨询)
 //	文档地址：https://opendocs.alipay.com/apis/014yig
-func (a *Client) AntMerchantShopConsult(bm gopay.BodyMap) (aliRsp *AntMerchantShopConsultRsp, err error) {
+func (a *Client) AntMerchantShopConsult(ctx context.Context, bm gopay.BodyMap) (aliRsp *AntMerchantShopConsultRsp, err error) {
 	err = bm.CheckEmptyError("business_address", "shop_category", "store_id", "shop_type", "ip_role_id", "shop_name")
 	if err != nil {
 		return nil, err
 	}
-	var bs []byte
-	if bs, err = a.doAliPay(bm, "ant.merchant.expand.shop.consult"); err != nil {
+	var (
+		bs          []byte
+		encSignData string
+	)
+	if bs, encSignData, err = a.doAliPay(ctx, bm, "ant.merchant.expand.shop.consult"); err != nil {
 		return nil, err
 	}
 	aliRsp = new(AntMerchantShopConsultRsp)
@@ -68,22 +77,25 @@ func (a *Client) AntMerchantShopConsult(bm gopay.BodyMap) (aliRsp *AntMerchantSh
 	}
 	if aliRsp.Response != nil && aliRsp.Response.Code != "10000" {
 		info := aliRsp.Response
-		return aliRsp, fmt.Errorf(`{"code":"%s","msg":"%s","sub_code":"%s","sub_msg":"%s"}`, info.Code, info.Msg, info.SubCode, info.SubMsg)
+		return aliRsp, bizError(info.Code, info.Msg, info.SubCode, info.SubMsg, bs)
 	}
-	signData, signDataErr := a.getSignData(bs, aliRsp.AlipayCertSn)
+	signData, signDataErr := a.getSignData(bs, encSignData, aliRsp.AlipayCertSn)
 	aliRsp.SignData = signData
 	return aliRsp, a.autoVerifySignByCert(aliRsp.Sign, signData, signDataErr)
 }
 
 // ant.merchant.expand.order.query(商户申请单查询)
 //	文档地址：https://opendocs.alipay.com/apis/api_1/ant.merchant.expand.order.query
-func (a *Client) AntMerchantOrderQuery(bm gopay.BodyMap) (aliRsp *AntMerchantOrderQueryRsp, err error) {
+func (a *Client) AntMerchantOrderQuery(ctx context.Context, bm gopay.BodyMap) (aliRsp *AntMerchantOrderQueryRsp, err error) {
 	err = bm.CheckEmptyError("order_id")
 	if err != nil {
 		return nil, err
 	}
-	var bs []byte
-	if bs, err = a.doAliPay(bm, "ant.merchant.expand.order.query"); err != nil {
+	var (
+		bs          []byte
+		encSignData string
+	)
+	if bs, encSignData, err = a.doAliPay(ctx, bm, "ant.merchant.expand.order.query"); err != nil {
 		return nil, err
 	}
 	aliRsp = new(AntMerchantOrderQueryRsp)
@@ -92,18 +104,21 @@ func (a *Client) AntMerchantOrderQuery(bm gopay.BodyMap) (aliRsp *AntMerchantOrd
 	}
 	if aliRsp.Response != nil && aliRsp.Response.Code != "10000" {
 		info := aliRsp.Response
-		return aliRsp, fmt.Errorf(`{"code":"%s","msg":"%s","sub_code":"%s","sub_msg":"%s"}`, info.Code, info.Msg, info.SubCode, info.SubMsg)
+		return aliRsp, bizError(info.Code, info.Msg, info.SubCode, info.SubMsg, bs)
 	}
-	signData, signDataErr := a.getSignData(bs, aliRsp.AlipayCertSn)
+	signData, signDataErr := a.getSignData(bs, encSignData, aliRsp.AlipayCertSn)
 	aliRsp.SignData = signData
 	return aliRsp, a.autoVerifySignByCert(aliRsp.Sign, signData, signDataErr)
 }
 
 // ant.merchant.expand.shop.query(店铺查询接口)
 //	文档地址：https://opendocs.alipay.com/apis/api_1/ant.merchant.expand.shop.query
-func (a *Client) AntMerchantShopQuery(bm gopay.BodyMap) (aliRsp *AntMerchantShopQueryRsp, err error) {
-	var bs []byte
-	if bs, err = a.doAliPay(bm, "ant.merchant.expand.shop.query"); err != nil {
+func (a *Client) AntMerchantShopQuery(ctx context.Context, bm gopay.BodyMap) (aliRsp *AntMerchantShopQueryRsp, err error) {
+	var (
+		bs          []byte
+		encSignData string
+	)
+	if bs, encSignData, err = a.doAliPay(ctx, bm, "ant.merchant.expand.shop.query"); err != nil {
 		return nil, err
 	}
 	aliRsp = new(AntMerchantShopQueryRsp)
@@ -112,18 +127,21 @@ func (a *Client) AntMerchantShopQuery(bm gopay.BodyMap) (aliRsp *AntMerchantShop
 	}
 	if aliRsp.Response != nil && aliRsp.Response.Code != "10000" {
 		info := aliRsp.Response
-		return aliRsp, fmt.Errorf(`{"code":"%s","msg":"%s","sub_code":"%s","sub_msg":"%s"}`, info.Code, info.Msg, info.SubCode, info.SubMsg)
+		return aliRsp, bizError(info.Code, info.Msg, info.SubCode, info.SubMsg, bs)
 	}
-	signData, signDataErr := a.getSignData(bs, aliRsp.AlipayCertSn)
+	signData, signDataErr := a.getSignData(bs, encSignData, aliRsp.AlipayCertSn)
 	aliRsp.SignData = signData
 	return aliRsp, a.autoVerifySignByCert(aliRsp.Sign, signData, signDataErr)
 }
 
 // ant.merchant.expand.shop.close(蚂蚁店铺关闭)
 //	文档地址：https://opendocs.alipay.com/apis/api_1/ant.merchant.expand.shop.close
-func (a *Client) AntMerchantShopClose(bm gopay.BodyMap) (aliRsp *AntMerchantShopCloseRsp, err error) {
-	var bs []byte
-	if bs, err = a.doAliPay(bm, "ant.merchant.expand.shop.close"); err != nil {
+func (a *Client) AntMerchantShopClose(ctx context.Context, bm gopay.BodyMap) (aliRsp *AntMerchantShopCloseRsp, err error) {
+	var (
+		bs          []byte
+		encSignData string
+	)
+	if bs, encSignData, err = a.doAliPay(ctx, bm, "ant.merchant.expand.shop.close"); err != nil {
 		return nil, err
 	}
 	aliRsp = new(AntMerchantShopCloseRsp)
@@ -132,9 +150,9 @@ func (a *Client) AntMerchantShopClose(bm gopay.BodyMap) (aliRsp *AntMerchantShop
 	}
 	if aliRsp.Response != nil && aliRsp.Response.Code != "10000" {
 		info := aliRsp.Response
-		return aliRsp, fmt.Errorf(`{"code":"%s","msg":"%s","sub_code":"%s","sub_msg":"%s"}`, info.Code, info.Msg, info.SubCode, info.SubMsg)
+		return aliRsp, bizError(info.Code, info.Msg, info.SubCode, info.SubMsg, bs)
 	}
-	signData, signDataErr := a.getSignData(bs, aliRsp.AlipayCertSn)
+	signData, signDataErr := a.getSignData(bs, encSignData, aliRsp.AlipayCertSn)
 	aliRsp.SignData = signData
 	return aliRsp, a.autoVerifySignByCert(aliRsp.Sign, signData, signDataErr)
 }
@@ -0,0 +1,375 @@
+package alipay
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/yuanqinguo/gopay"
+	"github.com/yuanqinguo/gopay/pkg/util"
+	"github.com/yuanqinguo/gopay/pkg/xbill"
+	"github.com/yuanqinguo/gopay/pkg/xlog"
+	"golang.org/x/text/encoding/simplifiedchinese"
+)
+
+const (
+	BillTypeTrade        = "trade"        // 商户基于支付宝交易收单的业务账单
+	BillTypeSignCustomer = "signcustomer" // 基于商户支付宝余额收入及支出等资金变动的账务账单
+)
+
+// Bill 支付宝账单解析结果
+//	bill_type 为 trade 时，TradeRows、TradeSummary 有值；为 signcustomer 时，SignCustomerRows、SignCustomerSummary 有值
+type Bill struct {
+	BillType            string
+	TradeRows           []*TradeBillRow
+	TradeSummary        *TradeBillSummary
+	SignCustomerRows    []*SignCustomerBillRow
+	SignCustomerSummary *SignCustomerBillSummary
+}
+
+// TradeBillRow 业务明细，金额单位为分
+type TradeBillRow struct {
+	TradeNo           string // 支付宝交易号
+	OutTradeNo        string // 商户订单号
+	BizType           string // 业务类型：交易、退款
+	Subject           string // 商品名称
+	CreateTime        string // 创建时间
+	FinishTime        string // 完成时间
+	StoreId           string // 门店编号
+	StoreName         string // 门店名称
+	Operator          string // 操作员
+	TerminalId        string // 终端号
+	BuyerAccount      string // 对方账户
+	TotalAmount       int64  // 订单金额
+	ReceiptAmount     int64  // 商家实收
+	AlipayRedPacket   int64  // 支付宝红包
+	PointAmount       int64  // 集分宝
+	AlipayDiscount    int64  // 支付宝优惠
+	MerchantDiscount  int64  // 商家优惠
+	CouponAmount      int64  // 券核销金额
+	CouponName        string // 券名称
+	MerchantRedPacket int64  // 商家红包消费金额
+	CardAmount        int64  // 卡消费金额
+	OutRequestNo      string // 退款批次号/请求号
+	ServiceFee        int64  // 服务费
+	RoyaltyAmount     int64  // 分润
+	Remark            string // 备注
+}
+
+// TradeBillSummary 业务汇总（合计），金额单位为分
+type TradeBillSummary struct {
+	TradeCount       int64 // 交易订单总笔数
+	RefundCount      int64 // 退款订单总笔数
+	TotalAmount      int64 // 订单金额
+	ReceiptAmount    int64 // 商家实收
+	AlipayDiscount   int64 // 支付宝优惠
+	MerchantDiscount int64 // 商家优惠
+	CardAmount       int64 // 卡消费金额
+	ServiceFee       int64 // 服务费
+	RoyaltyAmount    int64 // 分润
+	NetAmount        int64 // 实收净额
+}
+
+// SignCustomerBillRow 账务明细，金额单位为分
+type SignCustomerBillRow struct {
+	AccountLogId string // 账务流水号
+	TradeNo      string // 业务流水号
+	OutTradeNo   string // 商户订单号
+	Subject      string // 商品名称
+	TransDate    string // 发生时间
+	OtherAccount string // 对方账号
+	InAmount     int64  // 收入金额
+	OutAmount    int64  // 支出金额（负数）
+	Balance      int64  // 账户余额
+	Channel      string // 交易渠道
+	BizType      string // 业务类型
+	Remark       string // 备注
+}
+
+// SignCustomerBillSummary 账务汇总（合计），金额单位为分
+type SignCustomerBillSummary struct {
+	InCount     int64 // 收入笔数
+	InAmount    int64 // 收入金额
+	OutCount    int64 // 支出笔数
+	OutAmount   int64 // 支出金额（负数）
+	TotalCount  int64 // 合计笔数
+	TotalAmount int64 // 合计金额
+}
+
+// DataBillDownload 查询对账单下载地址、下载并解析账单
+//	bm：同 DataBillDownloadUrlQuery()，bill_type 为 trade 或 signcustomer，bill_date 为日账单 yyyy-MM-dd
+//	文档地址：https://opendocs.alipay.com/apis/api_15/alipay.data.dataservice.bill.downloadurl.query
+func (a *Client) DataBillDownload(ctx context.Context, bm gopay.BodyMap) (bill *Bill, err error) {
+	aliRsp, err := a.DataBillDownloadUrlQuery(ctx, bm)
+	if err != nil {
+		return nil, err
+	}
+	if aliRsp.Response == nil || aliRsp.Response.BillDownloadUrl == util.NULL {
+		return nil, errors.New("bill_download_url is empty")
+	}
+	bs, err := a.DataBillDownloadFile(ctx, aliRsp.Response.BillDownloadUrl)
+	if err != nil {
+		return nil, err
+	}
+	return ParseBill(bs)
+}
+
+// billDownloadTimeout 未设置 http.Client 时，下载对账单文件的超时时间，包括读取 body 的时间
+const billDownloadTimeout = 10 * time.Minute
+
+// billDownloadClient 未设置 http.Client 时，下载对账单文件使用的 http.Client
+var billDownloadClient = &http.Client{Timeout: billDownloadTimeout}
+
+// DataBillDownloadFile 下载对账单文件（ZIP 压缩包）
+//	billDownloadUrl：DataBillDownloadUrlQuery() 返回的 bill_download_url，有效期30秒
+//	不经过 xhttp（其响应 body 上限为 5MB），完整读取账单文件，避免大账单被截断
+//	ctx：控制请求及读取 body 的超时、取消；未通过 client.SetHttpClient() 设置 http.Client 时，最长 10 分钟
+func (a *Client) DataBillDownloadFile(ctx context.Context, billDownloadUrl string) (zipData []byte, err error) {
+	if a.DebugSwitch == gopay.DebugOn {
+		xlog.Debugf("Alipay_BillDownloadUrl: %s", billDownloadUrl)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, billDownloadUrl, nil)
+	if err != nil {
+		return nil, err
+	}
+	hc := a.hc
+	if hc == nil {
+		hc = billDownloadClient
+	}
+	res, err := hc.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+	bs, err := ioutil.ReadAll(res.Body)
+	if err != nil {
+		return nil, fmt.Errorf("read bill file：%w", err)
+	}
+	if res.StatusCode != http.StatusOK {
+		return nil, httpError(res, bs)
+	}
+	return bs, nil
+}
+
+// ParseBill 解析对账单文件（ZIP 压缩包内为 GBK 编码的明细、汇总 CSV 文件）
+func ParseBill(zipData []byte) (bill *Bill, err error) {
+	zr, err := zip.NewReader(bytes.NewReader(zipData), int64(len(zipData)))
+	if err != nil {
+		return nil, fmt.Errorf("zip.NewReader：%w", err)
+	}
+	bill = new(Bill)
+	for _, f := range zr.File {
+		if f.FileInfo().IsDir() {
+			continue
+		}
+		if err = bill.parseFile(f); err != nil {
+			return nil, err
+		}
+	}
+	if bill.BillType == util.NULL {
+		return nil, errors.New("no bill file found in zip")
+	}
+	return bill, nil
+}
+
+func (b *Bill) parseFile(f *zip.File) (err error) {
+	rc, err := f.Open()
+	if err != nil {
+		return fmt.Errorf("open %s：%w", f.Name, err)
+	}
+	defer rc.Close()
+	records, err := readBillCSV(rc)
+	if err != nil {
+		return fmt.Errorf("read %s：%w", f.Name, err)
+	}
+	if len(records) == 0 {
+		return nil
+	}
+	h := xbill.NewHeader(records[0])
+	switch {
+	case h.Has("支付宝交易号"):
+		b.BillType = BillTypeTrade
+		for _, v := range records[1:] {
+			row, err := parseTradeBillRow(h, v)
+			if err != nil {
+				return err
+			}
+			b.TradeRows = append(b.TradeRows, row)
+		}
+	case h.Has("交易订单总笔数"):
+		if v := findSummaryRecord(records[1:]); v != nil {
+			if b.TradeSummary, err = parseTradeBillSummary(h, v); err != nil {
+				return err
+			}
+		}
+	case h.Has("账务流水号"):
+		b.BillType = BillTypeSignCustomer
+		for _, v := range records[1:] {
+			row, err := parseSignCustomerBillRow(h, v)
+			if err != nil {
+				return err
+			}
+			b.SignCustomerRows = append(b.SignCustomerRows, row)
+		}
+	case h.Has("收入笔数"):
+		if v := findSummaryRecord(records[1:]); v != nil {
+			if b.SignCustomerSummary, err = parseSignCustomerBillSummary(h, v); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// readBillCSV GBK 解码，跳过 # 开头的说明行，去除字段首尾的空格和制表符
+func readBillCSV(r io.Reader) (records [][]string, err error) {
+	bs, err := ioutil.ReadAll(simplifiedchinese.GBK.NewDecoder().Reader(r))
+	if err != nil {
+		return nil, err
+	}
+	cr := csv.NewReader(bytes.NewReader(bs))
+	cr.Comment = '#'
+	cr.FieldsPerRecord = -1
+	cr.LazyQuotes = true
+	for {
+		record, err := cr.Read()
+		if err == io.EOF {
+			return records, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		for i, v := range record {
+			record[i] = strings.TrimSpace(v)
+		}
+		if len(record) == 1 && record[0] == util.NULL {
+			continue
+		}
+		records = append(records, record)
+	}
+}
+
+// findSummaryRecord 汇总文件中 合计 行
+func findSummaryRecord(records [][]string) []string {
+	for _, v := range records {
+		for _, field := range v {
+			if field == "合计" {
+				return v
+			}
+		}
+	}
+	return nil
+}
+
+func parseTradeBillRow(h xbill.Header, fields []string) (row *TradeBillRow, err error) {
+	row = &TradeBillRow{
+		TradeNo:      h.Get(fields, "支付宝交易号"),
+		OutTradeNo:   h.Get(fields, "商户订单号"),
+		BizType:      h.Get(fields, "业务类型"),
+		Subject:      h.Get(fields, "商品名称"),
+		CreateTime:   h.Get(fields, "创建时间"),
+		FinishTime:   h.Get(fields, "完成时间"),
+		StoreId:      h.Get(fields, "门店编号"),
+		StoreName:    h.Get(fields, "门店名称"),
+		Operator:     h.Get(fields, "操作员"),
+		TerminalId:   h.Get(fields, "终端号"),
+		BuyerAccount: h.Get(fields, "对方账户"),
+		CouponName:   h.Get(fields, "券名称"),
+		OutRequestNo: h.Get(fields, "退款批次号/请求号"),
+		Remark:       h.Get(fields, "备注"),
+	}
+	err = h.Amounts(fields, map[string]*int64{
+		"订单金额":     &row.TotalAmount,
+		"商家实收":     &row.ReceiptAmount,
+		"支付宝红包":    &row.AlipayRedPacket,
+		"集分宝":      &row.PointAmount,
+		"支付宝优惠":    &row.AlipayDiscount,
+		"商家优惠":     &row.MerchantDiscount,
+		"券核销金额":    &row.CouponAmount,
+		"商家红包消费金额": &row.MerchantRedPacket,
+		"卡消费金额":    &row.CardAmount,
+		"服务费":      &row.ServiceFee,
+		"分润":       &row.RoyaltyAmount,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("trade_no [%s]：%w", row.TradeNo, err)
+	}
+	return row, nil
+}
+
+func parseTradeBillSummary(h xbill.Header, fields []string) (summary *TradeBillSummary, err error) {
+	summary = new(TradeBillSummary)
+	err = h.Counts(fields, map[string]*int64{
+		"交易订单总笔数": &summary.TradeCount,
+		"退款订单总笔数": &summary.RefundCount,
+	})
+	if err != nil {
+		return nil, err
+	}
+	err = h.Amounts(fields, map[string]*int64{
+		"订单金额":  &summary.TotalAmount,
+		"商家实收":  &summary.ReceiptAmount,
+		"支付宝优惠": &summary.AlipayDiscount,
+		"商家优惠":  &summary.MerchantDiscount,
+		"卡消费金额": &summary.CardAmount,
+		"服务费":   &summary.ServiceFee,
+		"分润":    &summary.RoyaltyAmount,
+		"实收净额":  &summary.NetAmount,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return summary, nil
+}
+
+func parseSignCustomerBillRow(h xbill.Header, fields []string) (row *SignCustomerBillRow, err error) {
+	row = &SignCustomerBillRow{
+		AccountLogId: h.Get(fields, "账务流水号"),
+		TradeNo:      h.Get(fields, "业务流水号"),
+		OutTradeNo:   h.Get(fields, "商户订单号"),
+		Subject:      h.Get(fields, "商品名称"),
+		TransDate:    h.Get(fields, "发生时间"),
+		OtherAccount: h.Get(fields, "对方账号"),
+		Channel:      h.Get(fields, "交易渠道"),
+		BizType:      h.Get(fields, "业务类型"),
+		Remark:       h.Get(fields, "备注"),
+	}
+	err = h.Amounts(fields, map[string]*int64{
+		"收入金额": &row.InAmount,
+		"支出金额": &row.OutAmount,
+		"账户余额": &row.Balance,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("account_log_id [%s]：%w", row.AccountLogId, err)
+	}
+	return row, nil
+}
+
+func parseSignCustomerBillSummary(h xbill.Header, fields []string) (summary *SignCustomerBillSummary, err error) {
+	summary = new(SignCustomerBillSummary)
+	err = h.Counts(fields, map[string]*int64{
+		"收入笔数": &summary.InCount,
+		"支出笔数": &summary.OutCount,
+		"合计笔数": &summary.TotalCount,
+	})
+	if err != nil {
+		return nil, err
+	}
+	err = h.Amounts(fields, map[string]*int64{
+		"收入金额": &summary.InAmount,
+		"支出金额": &summary.OutAmount,
+		"合计金额": &summary.TotalAmount,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return summary, nil
+}
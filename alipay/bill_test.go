@@ -0,0 +1,117 @@
+package alipay
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"golang.org/x/text/encoding/simplifiedchinese"
+)
+
+func TestParseBill(t *testing.T) {
+	detail := "#支付宝业务明细查询\n" +
+		"#账号：[20881234567890120156]\n" +
+		"#起始日期：[2021年09月01日 00:00:00]   终止日期：[2021年09月02日 00:00:00]\n" +
+		"#-----------------------------------------业务明细列表----------------------------------------\n" +
+		"支付宝交易号,商户订单号,业务类型,商品名称,创建时间,完成时间,门店编号,门店名称,操作员,终端号,对方账户,订单金额（元）,商家实收（元）,支付宝红包（元）,集分宝（元）,支付宝优惠（元）,商家优惠（元）,券核销金额（元）,券名称,商家红包消费金额（元）,卡消费金额（元）,退款批次号/请求号,服务费（元）,分润（元）,备注\n" +
+		"2021090122001411111111111111\t,GZ202109011111\t,交易,测试商品,2021-09-01 10:00:00,2021-09-01 10:00:05,,,,,abc***@163.com,10.01,10.01,0.00,0.00,0.00,0.00,0.00,,0.00,0.00,,-0.06,0.00,\n" +
+		"2021090122001411111111111111\t,GZ202109011111\t,退款,测试商品,2021-09-01 11:00:00,2021-09-01 11:00:01,,,,,abc***@163.com,10.01,-5.00,0.00,0.00,0.00,0.00,0.00,,0.00,0.00,GZ202109011111R1\t,0.03,0.00,\n" +
+		"#-----------------------------------------业务明细列表结束------------------------------------\n" +
+		"#导出时间：[2021年09月02日 09:00:00]\n"
+	summary := "#支付宝业务汇总查询\n" +
+		"门店编号,门店名称,交易订单总笔数,退款订单总笔数,订单金额（元）,商家实收（元）,支付宝优惠（元）,商家优惠（元）,卡消费金额（元）,服务费（元）,分润（元）,实收净额（元）\n" +
+		",合计,1,1,5.01,5.01,0.00,0.00,0.00,-0.03,0.00,4.98\n"
+	bill, err := ParseBill(buildBillZip(t, map[string]string{
+		"20881234567890120156_20210901_业务明细.csv":     detail,
+		"20881234567890120156_20210901_业务明细(汇总).csv": summary,
+	}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if bill.BillType != BillTypeTrade || len(bill.TradeRows) != 2 {
+		t.Fatalf("bill: %+v", bill)
+	}
+	row := bill.TradeRows[1]
+	if row.TradeNo != "2021090122001411111111111111" || row.BizType != "退款" || row.TotalAmount != 1001 || row.ReceiptAmount != -500 ||
+		row.ServiceFee != 3 || row.OutRequestNo != "GZ202109011111R1" {
+		t.Errorf("row: %+v", row)
+	}
+	if s := bill.TradeSummary; s == nil || s.TradeCount != 1 || s.RefundCount != 1 || s.TotalAmount != 501 || s.NetAmount != 498 {
+		t.Errorf("summary: %+v", s)
+	}
+
+	account := "#支付宝账务明细查询\n" +
+		"账务流水号,业务流水号,商户订单号,商品名称,发生时间,对方账号,收入金额（+元）,支出金额（-元）,账户余额（元）,交易渠道,业务类型,备注\n" +
+		"300001\t,2021090122001411111111111111\t,GZ202109011111\t,测试商品,2021-09-01 10:00:05,abc***@163.com,10.01,,110.01,支付宝,在线支付,\n" +
+		"300002\t,2021090122001411111111111111\t,GZ202109011111\t,测试商品,2021-09-01 11:00:01,abc***@163.com,,-5.00,105.01,支付宝,交易退款,\n"
+	accountSummary := "#支付宝账务汇总查询\n" +
+		"业务类型,收入笔数,收入金额（+元）,支出笔数,支出金额（-元）,合计笔数,合计金额（元）\n" +
+		"合计,1,10.01,1,-5.00,2,5.01\n"
+	bill, err = ParseBill(buildBillZip(t, map[string]string{
+		"20881234567890120156_20210901_账务明细.csv":     account,
+		"20881234567890120156_20210901_账务明细(汇总).csv": accountSummary,
+	}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if bill.BillType != BillTypeSignCustomer || len(bill.SignCustomerRows) != 2 {
+		t.Fatalf("bill: %+v", bill)
+	}
+	if r := bill.SignCustomerRows[1]; r.AccountLogId != "300002" || r.OutAmount != -500 || r.Balance != 10501 {
+		t.Errorf("row: %+v", r)
+	}
+	if s := bill.SignCustomerSummary; s == nil || s.TotalCount != 2 || s.InAmount != 1001 || s.OutAmount != -500 {
+		t.Errorf("summary: %+v", s)
+	}
+
+	if _, err = ParseBill([]byte("not a zip")); err == nil {
+		t.Error("expected error for invalid zip")
+	}
+}
+
+func buildBillZip(t *testing.T, files map[string]string) []byte {
+	buf := new(bytes.Buffer)
+	zw := zip.NewWriter(buf)
+	for name, content := range files {
+		gbk, err := simplifiedchinese.GBK.NewEncoder().String(content)
+		if err != nil {
+			t.Fatal(err)
+		}
+		w, err := zw.Create(name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err = w.Write([]byte(gbk)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := zw.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+func TestDataBillDownloadFileLarge(t *testing.T) {
+	// 超过 xhttp 5MB 上限的账单文件需完整下载
+	data := bytes.Repeat([]byte("0123456789"), 6<<20/10)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write(data)
+	}))
+	defer srv.Close()
+	bs, err := new(Client).DataBillDownloadFile(context.Background(), srv.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(bs, data) {
+		t.Fatalf("download size: %d, want: %d", len(bs), len(data))
+	}
+
+	srv404 := httptest.NewServer(http.NotFoundHandler())
+	defer srv404.Close()
+	if _, err = new(Client).DataBillDownloadFile(context.Background(), srv404.URL); err == nil {
+		t.Fatal("expected error for status 404")
+	}
+}
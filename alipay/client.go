@@ -1,9 +1,13 @@
 package alipay
 
 import (
+	"context"
 	"crypto/rsa"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"net/http"
+	"strings"
 	"time"
 
 	"github.com/yuanqinguo/gopay"
@@ -27,9 +31,11 @@ type Client struct {
 	IsProd             bool
 	privateKey         *rsa.PrivateKey
 	aliPayPublicKey    *rsa.PublicKey // 支付宝证书公钥内容 alipayCertPublicKey_RSA2.crt
+	aesKey             []byte         // 接口内容加密密钥
 	autoSign           bool
 	DebugSwitch        gopay.DebugSwitch
 	location           *time.Location
+	hc                 *http.Client
 }
 
 // 初始化支付宝客户端
@@ -54,9 +60,10 @@ func NewClient(appId, privateKey string, isProd bool) (client *Client, err error
 	return client, nil
 }
 
-// 开启请求完自动验签功能（默认不开启，推荐开启，只支持证书模式）
-//	注意：只支持证书模式
+// 开启请求完自动验签功能（默认不开启，推荐开启，公钥证书模式）
+//	注意：公钥模式请使用 client.AutoVerifySignByPublicKey()
 //	alipayPublicKeyContent：支付宝公钥证书文件内容[]byte
+//	验签失败时，接口方法返回 *VerifySignError
 func (a *Client) AutoVerifySign(alipayPublicKeyContent []byte) {
 	pubKey, err := xpem.DecodePublicKey(alipayPublicKeyContent)
 	if err != nil {
@@ -68,18 +75,41 @@ func (a *Client) AutoVerifySign(alipayPublicKeyContent []byte) {
 	}
 }
 
+// 开启请求完自动验签功能（默认不开启，推荐开启，公钥模式）
+//	aliPayPublicKey：支付宝平台获取的支付宝公钥，支持去除头尾的公钥字符串或 PEM 格式
+//	验签失败时，接口方法返回 *VerifySignError
+func (a *Client) AutoVerifySignByPublicKey(aliPayPublicKey string) (err error) {
+	if !strings.HasPrefix(strings.TrimSpace(aliPayPublicKey), "-----BEGIN") {
+		aliPayPublicKey = xrsa.FormatAlipayPublicKey(aliPayPublicKey)
+	}
+	pubKey, err := xpem.DecodePublicKey([]byte(aliPayPublicKey))
+	if err != nil {
+		return err
+	}
+	if pubKey == nil {
+		return errors.New("aliPayPublicKey is not a rsa public key")
+	}
+	a.aliPayPublicKey = pubKey
+	a.autoSign = true
+	return nil
+}
+
 // Deprecated
 //	推荐使用 PostAliPayAPISelfV2()
 //	示例：请参考 client_test.go 的 TestClient_PostAliPayAPISelf() 方法
-func (a *Client) PostAliPayAPISelf(bm gopay.BodyMap, method string, aliRsp interface{}) (err error) {
-	var bs []byte
-	if bs, err = a.doAliPay(bm, method); err != nil {
+//	注意：开启自动验签后，对接口成功的响应进行验签，验签失败时返回 *VerifySignError
+func (a *Client) PostAliPayAPISelf(ctx context.Context, bm gopay.BodyMap, method string, aliRsp interface{}) (err error) {
+	var (
+		bs          []byte
+		encSignData string
+	)
+	if bs, encSignData, err = a.doAliPay(ctx, bm, method); err != nil {
 		return err
 	}
 	if err = json.Unmarshal(bs, aliRsp); err != nil {
 		return err
 	}
-	return nil
+	return a.autoVerifySignSelf(bs, encSignData)
 }
 
 // Deprecated
@@ -111,6 +141,9 @@ func (a *Client) RequestParam(bm gopay.BodyMap, method string) (string, error) {
 
 	// check sign
 	if bm.GetString("sign") == "" {
+		if err = a.encryptBizContent(bm); err != nil {
+			return "", err
+		}
 		sign, err = GetRsaSign(bm, bm.GetString("sign_type"), a.privateKey)
 		if err != nil {
 			return "", fmt.Errorf("GetRsaSign Error: %v", err)
@@ -127,9 +160,11 @@ func (a *Client) RequestParam(bm gopay.BodyMap, method string) (string, error) {
 // PostAliPayAPISelfV2 支付宝接口自行实现方法
 //	注意：biz_content 需要自行通过bm.SetBodyMap()设置，不设置则没有此参数
 //	示例：请参考 client_test.go 的 TestClient_PostAliPayAPISelfV2() 方法
-func (a *Client) PostAliPayAPISelfV2(bm gopay.BodyMap, method string, aliRsp interface{}) (err error) {
+//	注意：开启自动验签后，对接口成功的响应进行验签，验签失败时返回 *VerifySignError
+func (a *Client) PostAliPayAPISelfV2(ctx context.Context, bm gopay.BodyMap, method string, aliRsp interface{}) (err error) {
 	var (
-		bs, bodyBs []byte
+		bs, bodyBs  []byte
+		encSignData string
 	)
 	// check if there is biz_content
 	bz := bm.GetInterface("biz_content")
@@ -140,17 +175,51 @@ func (a *Client) PostAliPayAPISelfV2(bm gopay.BodyMap, method string, aliRsp int
 		bm.Set("biz_content", string(bodyBs))
 	}
 
-	if bs, err = a.doAliPaySelf(bm, method); err != nil {
+	if bs, encSignData, err = a.doAliPaySelf(ctx, bm, method); err != nil {
 		return err
 	}
 	if err = json.Unmarshal(bs, aliRsp); err != nil {
 		return err
 	}
-	return nil
+	return a.autoVerifySignSelf(bs, encSignData)
+}
+
+// autoVerifySignSelf 自行实现的接口，开启自动验签时对响应验签
+//	与其他接口一致，xxx_response 中 code 不为 10000 时不验签
+func (a *Client) autoVerifySignSelf(bs []byte, encSignData string) (err error) {
+	if !a.autoSign || a.aliPayPublicKey == nil {
+		return nil
+	}
+	var (
+		rsp          map[string]json.RawMessage
+		sign, certSn string
+	)
+	if err = json.Unmarshal(bs, &rsp); err != nil {
+		return fmt.Errorf("json.Unmarshal(%s)：%w", string(bs), err)
+	}
+	for k, v := range rsp {
+		switch {
+		case k == "sign":
+			_ = json.Unmarshal(v, &sign)
+		case k == "alipay_cert_sn":
+			_ = json.Unmarshal(v, &certSn)
+		case strings.HasSuffix(k, "_response"):
+			// 加密的响应为字符串，解析失败时照常验签
+			info := new(ErrorResponse)
+			if json.Unmarshal(v, info) == nil && info.Code != util.NULL && info.Code != "10000" {
+				return nil
+			}
+		}
+	}
+	if sign == util.NULL {
+		return &VerifySignError{Err: errors.New("sign is empty")}
+	}
+	signData, signDataErr := a.getSignData(bs, encSignData, certSn)
+	return a.autoVerifySignByCert(sign, signData, signDataErr)
 }
 
 // 向支付宝发送自定义请求
-func (a *Client) doAliPaySelf(bm gopay.BodyMap, method string) (bs []byte, err error) {
+func (a *Client) doAliPaySelf(ctx context.Context, bm gopay.BodyMap, method string) (bs []byte, encSignData string, err error) {
 	var (
 		url, sign string
 	)
@@ -159,9 +228,12 @@ func (a *Client) doAliPaySelf(bm gopay.BodyMap, method string) (bs []byte, err e
 	a.checkPublicParam(bm)
 	// check sign
 	if bm.GetString("sign") == "" {
+		if err = a.encryptBizContent(bm); err != nil {
+			return nil, util.NULL, err
+		}
 		sign, err = GetRsaSign(bm, bm.GetString("sign_type"), a.privateKey)
 		if err != nil {
-			return nil, fmt.Errorf("GetRsaSign Error: %v", err)
+			return nil, util.NULL, fmt.Errorf("GetRsaSign Error: %v", err)
 		}
 		bm.Set("sign", sign)
 	}
@@ -169,27 +241,28 @@ func (a *Client) doAliPaySelf(bm gopay.BodyMap, method string) (bs []byte, err e
 		xlog.Debugf("Alipay_Request: %s", bm.JsonBody())
 	}
 
-	httpClient := xhttp.NewClient()
+	httpClient := xhttp.NewClient().SetHttpClient(a.hc)
 	if a.IsProd {
 		url = baseUrlUtf8
 	} else {
 		url = sandboxBaseUrlUtf8
 	}
-	res, bs, errs := httpClient.Type(xhttp.TypeForm).Post(url).SendString(bm.EncodeURLParams()).EndBytes()
+	res, bs, errs := httpClient.Type(xhttp.TypeForm).Post(url).SendString(bm.EncodeURLParams()).EndBytesWithContext(ctx)
 	if len(errs) > 0 {
-		return nil, errs[0]
+		return nil, util.NULL, errs[0]
 	}
 	if a.DebugSwitch == gopay.DebugOn {
 		xlog.Debugf("Alipay_Response: %s%d %s%s", xlog.Red, res.StatusCode, xlog.Reset, string(bs))
 	}
 	if res.StatusCode != 200 {
-		return nil, fmt.Errorf("HTTP Request Error, StatusCode = %d", res.StatusCode)
+		return nil, util.NULL, httpError(res, bs)
 	}
-	return bs, nil
+	return a.decryptResponse(bs)
 }
 
 // 向支付宝发送请求
-func (a *Client) doAliPay(bm gopay.BodyMap, method string, authToken ...string) (bs []byte, err error) {
+//	encSignData：响应内容为密文时，为验签使用的原始密文，见 decryptResponse()
+func (a *Client) doAliPay(ctx context.Context, bm gopay.BodyMap, method string, authToken ...string) (bs []byte, encSignData string, err error) {
 	var (
 		bodyStr, url string
 		bodyBs       []byte
@@ -199,7 +272,7 @@ func (a *Client) doAliPay(bm gopay.BodyMap, method string, authToken ...string)
 		aat = bm.GetString("app_auth_token")
 		bm.Remove("app_auth_token")
 		if bodyBs, err = json.Marshal(bm); err != nil {
-			return nil, fmt.Errorf("json.Marshal：%w", err)
+			return nil, util.NULL, fmt.Errorf("json.Marshal：%w", err)
 		}
 		bodyStr = string(bodyBs)
 	}
@@ -240,9 +313,12 @@ func (a *Client) doAliPay(bm gopay.BodyMap, method string, authToken ...string)
 	if bodyStr != util.NULL {
 		pubBody.Set("biz_content", bodyStr)
 	}
+	if err = a.encryptBizContent(pubBody); err != nil {
+		return nil, util.NULL, err
+	}
 	sign, err := GetRsaSign(pubBody, pubBody.GetString("sign_type"), a.privateKey)
 	if err != nil {
-		return nil, fmt.Errorf("GetRsaSign Error: %v", err)
+		return nil, util.NULL, fmt.Errorf("GetRsaSign Error: %v", err)
 	}
 	pubBody.Set("sign", sign)
 	if a.DebugSwitch == gopay.DebugOn {
@@ -251,32 +327,54 @@ func (a *Client) doAliPay(bm gopay.BodyMap, method string, authToken ...string)
 	param := pubBody.EncodeURLParams()
 	switch method {
 	case "alipay.trade.app.pay", "alipay.fund.auth.order.app.freeze":
-		return []byte(param), nil
+		return []byte(param), util.NULL, nil
 	case "alipay.trade.wap.pay", "alipay.trade.page.pay", "alipay.user.certify.open.certify":
 		if !a.IsProd {
-			return []byte(sandboxBaseUrl + "?" + param), nil
+			return []byte(sandboxBaseUrl + "?" + param), util.NULL, nil
 		}
-		return []byte(baseUrl + "?" + param), nil
+		return []byte(baseUrl + "?" + param), util.NULL, nil
 	default:
-		httpClient := xhttp.NewClient()
+		httpClient := xhttp.NewClient().SetHttpClient(a.hc)
 		url = baseUrlUtf8
 		if !a.IsProd {
 			url = sandboxBaseUrlUtf8
 		}
-		res, bs, errs := httpClient.Type(xhttp.TypeForm).Post(url).SendString(param).EndBytes()
+		res, bs, errs := httpClient.Type(xhttp.TypeForm).Post(url).SendString(param).EndBytesWithContext(ctx)
 		if len(errs) > 0 {
-			return nil, errs[0]
+			return nil, util.NULL, errs[0]
 		}
 		if a.DebugSwitch == gopay.DebugOn {
 			xlog.Debugf("Alipay_Response: %s%d %s%s", xlog.Red, res.StatusCode, xlog.Reset, string(bs))
 		}
 		if res.StatusCode != 200 {
-			return nil, fmt.Errorf("HTTP Request Error, StatusCode = %d", res.StatusCode)
+			return nil, util.NULL, httpError(res, bs)
 		}
-		return bs, nil
+		return a.decryptResponse(bs)
 	}
 }
 
+// httpError 支付宝 http 状态码错误
+func httpError(res *http.Response, bs []byte) error {
+	e := gopay.NewError(gopay.ProviderAlipay, res.StatusCode, util.NULL, util.NULL)
+	e.Body = string(bs)
+	return e
+}
+
+// bizError 支付宝业务错误，返回 code 不为 10000 时使用
+//	有 sub_code 时，Code、Message 为 sub_code、sub_msg，否则为 code、msg
+//	code 为 20000（服务不可用）或 sub_code 为系统错误时，可重试
+//	bs：支付宝原始返回内容
+func bizError(code, msg, subCode, subMsg string, bs []byte) error {
+	e := gopay.NewError(gopay.ProviderAlipay, http.StatusOK, code, msg)
+	if subCode != util.NULL {
+		e.Code = subCode
+		e.Message = subMsg
+	}
+	e.Retryable = code == "20000" || strings.HasSuffix(subCode, "SYSTEM_ERROR") || subCode == "isp.unknow-error"
+	e.Body = string(bs)
+	return e
+}
+
 // 公共参数检查
 func (a *Client) checkPublicParam(bm gopay.BodyMap) {
 	bm.Set("format", "JSON").
@@ -1,7 +1,12 @@
 package alipay
 
 import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
 	"os"
+	"strings"
 	"testing"
 
 	"github.com/yuanqinguo/gopay"
@@ -12,6 +17,7 @@ import (
 
 var (
 	client *Client
+	ctx    = context.Background()
 	err    error
 	// 普通公钥模式时，验签使用
 	//aliPayPublicKey = "MIIBIjANBgkqhkiG9w0BAQEFAAOCAQ8AMIIBCgKCAQEA1wn1sU/8Q0rYLlZ6sq3enrPZw2ptp6FecHR2bBFLjJ+sKzepROd0bKddgj+Mr1ffr3Ej78mLdWV8IzLfpXUi945DkrQcOUWLY0MHhYVG2jSs/qzFfpzmtut2Cl2TozYpE84zom9ei06u2AXLMBkU6VpznZl+R4qIgnUfByt3Ix5b3h4Cl6gzXMAB1hJrrrCkq+WvWb3Fy0vmk/DUbJEz8i8mQPff2gsHBE1nMPvHVAMw1GMk9ImB4PxucVek4ZbUzVqxZXphaAgUXFK2FSFU+Q+q1SPvHbUsjtIyL+cLA6H/6ybFF9Ffp27Y14AHPw29+243/SpMisbGcj2KD+evBwIDAQAB"
@@ -38,7 +44,7 @@ func TestMain(m *testing.M) {
 		SetReturnUrl("https://www.fmm.ink").
 		SetNotifyUrl("https://www.fmm.ink")
 
-	// 自动同步验签（只支持证书模式）
+	// 自动同步验签（公钥证书模式），公钥模式请使用 client.AutoVerifySignByPublicKey()
 	// 传入 alipayCertPublicKey_RSA2.crt 内容
 	client.AutoVerifySign(cert.AlipayPublicContentRSA2)
 
@@ -69,7 +75,7 @@ func TestClient_PostAliPayAPISelfV2(t *testing.T) {
 	})
 
 	aliPsp := new(TradePrecreateResponse)
-	err := client.PostAliPayAPISelfV2(bm, "alipay.trade.precreate", aliPsp)
+	err := client.PostAliPayAPISelfV2(ctx, bm, "alipay.trade.precreate", aliPsp)
 	if err != nil {
 		xlog.Error(err)
 		return
@@ -84,7 +90,7 @@ func TestClient_PostAliPayAPISelf(t *testing.T) {
 	bm.Set("total_amount", "100")
 
 	aliPsp := new(TradePrecreateResponse)
-	err := client.PostAliPayAPISelf(bm, "alipay.trade.precreate", aliPsp)
+	err := client.PostAliPayAPISelf(ctx, bm, "alipay.trade.precreate", aliPsp)
 	if err != nil {
 		xlog.Error(err)
 		return
@@ -104,3 +110,25 @@ func TestDecryptOpenDataToBodyMap(t *testing.T) {
 	}
 	xlog.Info("bm:", bm)
 }
+
+func TestBizError(t *testing.T) {
+	c, err := NewClient(cert.Appid, cert.PrivateKey, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// sub_msg 含引号，Body 应为原始响应，而非拼接的 JSON
+	rspBody := `{"alipay_trade_query_response":{"code":"40004","msg":"Business Failed","sub_code":"ACQ.TRADE_NOT_EXIST","sub_msg":"交易\"6823789339978248\"不存在"},"sign":"xxx"}`
+	c.SetHttpClient(&http.Client{Transport: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		return &http.Response{StatusCode: http.StatusOK, Header: make(http.Header), Body: ioutil.NopCloser(strings.NewReader(rspBody))}, nil
+	})})
+	bm := make(gopay.BodyMap)
+	bm.Set("out_trade_no", "6823789339978248")
+	_, err = c.TradeQuery(ctx, bm)
+	e, ok := gopay.AsError(err)
+	if !ok || e.Code != "ACQ.TRADE_NOT_EXIST" || e.Message != `交易"6823789339978248"不存在` || e.Retryable {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if e.Body != rspBody || !json.Valid([]byte(e.Body)) {
+		t.Fatalf("unexpected body: %s", e.Body)
+	}
+}
@@ -0,0 +1,203 @@
+package alipay
+
+import (
+	"context"
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/yuanqinguo/gopay"
+	"github.com/yuanqinguo/gopay/pkg/util"
+	"github.com/yuanqinguo/gopay/pkg/xhttp"
+	"github.com/yuanqinguo/gopay/pkg/xlog"
+)
+
+// V3ErrorRsp OpenAPI v3 接口失败时的响应体
+type V3ErrorRsp struct {
+	Code    string    `json:"code,omitempty"`
+	Message string    `json:"message,omitempty"`
+	Links   []*V3Link `json:"links,omitempty"`
+}
+
+type V3Link struct {
+	Link string `json:"link,omitempty"`
+	Desc string `json:"desc,omitempty"`
+}
+
+// DoAliPayAPISelfV3 支付宝 OpenAPI v3 接口通用请求方法
+//	注意：请求签名使用 client 的应用私钥；公钥证书模式时，请先设置证书SN（client.SetCertSnByContent() 或 client.SetCertSnByPath()）
+//	注意：开启自动验签（client.AutoVerifySign() 或 client.AutoVerifySignByPublicKey()）后，对接口成功的响应进行验签，验签失败时返回 *VerifySignError
+//	method：请求方法，如 http.MethodPost、http.MethodGet
+//	path：接口路径，如 /v3/alipay/trade/query
+//	bm：请求参数，GET、DELETE 请求时拼接为 url 参数，其他请求作为 JSON 请求体；bm 中的 app_auth_token 作为应用授权令牌，不设置则使用 client.AppAuthToken
+//	aliRsp：接口成功时，响应体解析到的结构体指针，可为 nil
+//	接口失败时返回 *gopay.Error，Code、Message 为响应体中的 code、message，RequestId 为 alipay-trace-id
+func (a *Client) DoAliPayAPISelfV3(ctx context.Context, method, path string, bm gopay.BodyMap, aliRsp interface{}) (err error) {
+	var (
+		uri   = path
+		body  string
+		aat   = a.AppAuthToken
+		param = make(gopay.BodyMap)
+	)
+	for k, v := range bm {
+		param[k] = v
+	}
+	if v := param.GetString("app_auth_token"); v != util.NULL {
+		aat = v
+		param.Remove("app_auth_token")
+	}
+	switch method {
+	case http.MethodGet, http.MethodDelete:
+		if query := param.EncodeURLParams(); query != util.NULL {
+			uri += "?" + query
+		}
+	case http.MethodPost, http.MethodPut, http.MethodPatch:
+		if bm != nil {
+			bodyBs, err := json.Marshal(param)
+			if err != nil {
+				return fmt.Errorf("json.Marshal(%v)：%w", param, err)
+			}
+			body = string(bodyBs)
+		}
+	default:
+		return fmt.Errorf("unsupported method [%s]", method)
+	}
+	authorization, err := a.v3Authorization(method, uri, body, aat)
+	if err != nil {
+		return err
+	}
+	httpClient := xhttp.NewClient().SetHttpClient(a.hc)
+	httpClient.Header.Add("Authorization", authorization)
+	httpClient.Header.Add(HeaderV3RequestId, util.GetRandomString(32))
+	httpClient.Header.Add("Accept", "application/json")
+	if aat != util.NULL {
+		httpClient.Header.Add(HeaderV3AppAuthToken, aat)
+	}
+	if a.AliPayRootCertSN != util.NULL {
+		httpClient.Header.Add(HeaderV3RootCertSn, a.AliPayRootCertSN)
+	}
+	if a.DebugSwitch == gopay.DebugOn {
+		xlog.Debugf("Alipay_V3_Request: %s %s %s", method, uri, body)
+		xlog.Debugf("Alipay_V3_Authorization: %s", authorization)
+	}
+	url := v3BaseUrl + uri
+	if !a.IsProd {
+		url = v3SandboxBaseUrl + uri
+	}
+	httpClient.Type(xhttp.TypeJSON)
+	switch method {
+	case http.MethodGet:
+		httpClient.Get(url)
+	case http.MethodDelete:
+		httpClient.Delete(url)
+	case http.MethodPost:
+		httpClient.Post(url)
+	case http.MethodPut:
+		httpClient.Put(url)
+	case http.MethodPatch:
+		httpClient.Patch(url)
+	}
+	res, bs, errs := httpClient.SendString(body).EndBytesWithContext(ctx)
+	if len(errs) > 0 {
+		return errs[0]
+	}
+	if a.DebugSwitch == gopay.DebugOn {
+		xlog.Debugf("Alipay_V3_Response: %s%d %s%s", xlog.Red, res.StatusCode, xlog.Reset, string(bs))
+		xlog.Debugf("Alipay_V3_Headers: %#v", res.Header)
+	}
+	if res.StatusCode < http.StatusOK || res.StatusCode >= http.StatusMultipleChoices {
+		return v3Error(res, bs)
+	}
+	if err = a.autoVerifySignV3(res.Header, bs); err != nil {
+		return err
+	}
+	if aliRsp == nil || len(bs) == 0 {
+		return nil
+	}
+	if err = json.Unmarshal(bs, aliRsp); err != nil {
+		return fmt.Errorf("json.Unmarshal(%s)：%w", string(bs), err)
+	}
+	return nil
+}
+
+// v3Authorization 生成 OpenAPI v3 请求的 Authorization
+//	待签名内容：authString\nmethod\nuri\nbody\n，有应用授权令牌时再追加 appAuthToken\n
+//	authString：app_id=xxx[,app_cert_sn=xxx],nonce=xxx,timestamp=xxx（毫秒）
+func (a *Client) v3Authorization(method, uri, body, appAuthToken string) (authorization string, err error) {
+	var buf strings.Builder
+	buf.WriteString("app_id=" + a.AppId)
+	if a.AppCertSN != util.NULL {
+		buf.WriteString(",app_cert_sn=" + a.AppCertSN)
+	}
+	buf.WriteString(",nonce=" + util.GetRandomString(32))
+	buf.WriteString(",timestamp=" + strconv.FormatInt(time.Now().UnixNano()/int64(time.Millisecond), 10))
+	authString := buf.String()
+
+	signData := authString + "\n" + method + "\n" + uri + "\n" + body + "\n"
+	if appAuthToken != util.NULL {
+		signData += appAuthToken + "\n"
+	}
+	h := sha256.Sum256([]byte(signData))
+	signBytes, err := rsa.SignPKCS1v15(rand.Reader, a.privateKey, crypto.SHA256, h[:])
+	if err != nil {
+		return util.NULL, fmt.Errorf("rsa.SignPKCS1v15：%w", err)
+	}
+	return AuthorizationV3 + " " + authString + ",sign=" + base64.StdEncoding.EncodeToString(signBytes), nil
+}
+
+// autoVerifySignV3 OpenAPI v3 响应验签，开启自动验签时有效
+//	待验签内容：alipay-timestamp\nalipay-nonce\nbody\n
+//	公钥证书模式时，响应头 alipay-sn 需与 client.AliPayPublicCertSN 一致
+//	验签失败时返回 *VerifySignError
+func (a *Client) autoVerifySignV3(header http.Header, bs []byte) (err error) {
+	if !a.autoSign || a.aliPayPublicKey == nil {
+		return nil
+	}
+	var (
+		timestamp = header.Get(HeaderV3Timestamp)
+		nonce     = header.Get(HeaderV3Nonce)
+		sign      = header.Get(HeaderV3Signature)
+		sn        = header.Get(HeaderV3Sn)
+	)
+	signData := timestamp + "\n" + nonce + "\n" + string(bs) + "\n"
+	if timestamp == util.NULL || nonce == util.NULL || sign == util.NULL {
+		return &VerifySignError{SignData: signData, Sign: sign, Err: errors.New("response header alipay-timestamp, alipay-nonce, alipay-signature cannot be empty")}
+	}
+	if sn != util.NULL && a.AliPayPublicCertSN != util.NULL && sn != a.AliPayPublicCertSN {
+		return &VerifySignError{SignData: signData, Sign: sign, Err: errors.New("当前使用的支付宝公钥证书SN与网关响应报文中的SN不匹配")}
+	}
+	if a.DebugSwitch == gopay.DebugOn {
+		xlog.Debugf("Alipay_V3_SignData: %s, Sign=[%s]", signData, sign)
+	}
+	signBytes, _ := base64.StdEncoding.DecodeString(sign)
+	h := sha256.Sum256([]byte(signData))
+	if err = rsa.VerifyPKCS1v15(a.aliPayPublicKey, crypto.SHA256, h[:], signBytes); err != nil {
+		return &VerifySignError{SignData: signData, Sign: sign, Err: err}
+	}
+	return nil
+}
+
+// v3Error OpenAPI v3 http 状态码错误
+func v3Error(res *http.Response, bs []byte) error {
+	e := gopay.NewError(gopay.ProviderAlipay, res.StatusCode, util.NULL, util.NULL)
+	e.RequestId = res.Header.Get(HeaderV3TraceId)
+	e.Body = string(bs)
+	errRsp := new(V3ErrorRsp)
+	if json.Unmarshal(bs, errRsp) == nil {
+		e.Code = errRsp.Code
+		e.Message = errRsp.Message
+		if strings.HasSuffix(errRsp.Code, "SYSTEM_ERROR") {
+			e.Retryable = true
+		}
+	}
+	return e
+}
@@ -0,0 +1,142 @@
+package alipay
+
+import (
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/yuanqinguo/gopay"
+	"github.com/yuanqinguo/gopay/alipay/cert"
+	"github.com/yuanqinguo/gopay/pkg/xlog"
+)
+
+type roundTripFunc func(req *http.Request) (*http.Response, error)
+
+func (f roundTripFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+// fakeAlipayV3 模拟支付宝 OpenAPI v3 网关：校验请求签名，使用应用私钥对响应签名
+func fakeAlipayV3(t *testing.T, c *Client, status int, body string, header map[string]string) *http.Client {
+	return &http.Client{Transport: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		auth := req.Header.Get("Authorization")
+		if !strings.HasPrefix(auth, AuthorizationV3+" ") || req.Header.Get(HeaderV3RequestId) == "" {
+			t.Fatalf("invalid request header: %v", req.Header)
+		}
+		if c.AppCertSN != "" && (!strings.Contains(auth, ",app_cert_sn="+c.AppCertSN+",") || req.Header.Get(HeaderV3RootCertSn) != c.AliPayRootCertSN) {
+			t.Fatalf("cert sn not set: %v", req.Header)
+		}
+		idx := strings.LastIndex(auth, ",sign=")
+		authString, sign := auth[len(AuthorizationV3)+1:idx], auth[idx+6:]
+		var reqBody []byte
+		if req.Body != nil {
+			reqBody, _ = ioutil.ReadAll(req.Body)
+		}
+		signData := authString + "\n" + req.Method + "\n" + req.URL.RequestURI() + "\n" + string(reqBody) + "\n"
+		if aat := req.Header.Get(HeaderV3AppAuthToken); aat != "" {
+			signData += aat + "\n"
+		}
+		signBytes, _ := base64.StdEncoding.DecodeString(sign)
+		h := sha256.Sum256([]byte(signData))
+		if err := rsa.VerifyPKCS1v15(&c.privateKey.PublicKey, crypto.SHA256, h[:], signBytes); err != nil {
+			t.Fatalf("request sign verify failed: %v, signData: %s", err, signData)
+		}
+
+		rsp := &http.Response{StatusCode: status, Header: make(http.Header), Body: ioutil.NopCloser(strings.NewReader(body))}
+		rsp.Header.Set(HeaderV3Timestamp, "1700000000000")
+		rsp.Header.Set(HeaderV3Nonce, "nonce")
+		rsp.Header.Set(HeaderV3TraceId, "0b8a4f5a17000000000000001e0b37")
+		h = sha256.Sum256([]byte("1700000000000\nnonce\n" + body + "\n"))
+		rspSign, _ := rsa.SignPKCS1v15(rand.Reader, c.privateKey, crypto.SHA256, h[:])
+		rsp.Header.Set(HeaderV3Signature, base64.StdEncoding.EncodeToString(rspSign))
+		for k, v := range header {
+			rsp.Header.Set(k, v)
+		}
+		return rsp, nil
+	})}
+}
+
+func TestClient_DoAliPayAPISelfV3(t *testing.T) {
+	// 普通公钥模式
+	c, err := NewClient(cert.Appid, cert.PrivateKey, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	pubBs, _ := x509.MarshalPKIXPublicKey(&c.privateKey.PublicKey)
+	if err = c.AutoVerifySignByPublicKey(base64.StdEncoding.EncodeToString(pubBs)); err != nil {
+		t.Fatal(err)
+	}
+
+	rspBody := `{"trade_no":"2013112011001004330000121536","out_trade_no":"6823789339978248","trade_status":"TRADE_SUCCESS"}`
+	c.SetHttpClient(fakeAlipayV3(t, c, http.StatusOK, rspBody, nil))
+	bm := make(gopay.BodyMap)
+	bm.Set("out_trade_no", "6823789339978248").
+		Set("app_auth_token", "202110BB0c5e3b0d5b8b4b7ab1c2f0d7e0e2cX71")
+	aliRsp := new(struct {
+		TradeNo     string `json:"trade_no"`
+		TradeStatus string `json:"trade_status"`
+	})
+	if err = c.DoAliPayAPISelfV3(ctx, http.MethodPost, "/v3/alipay/trade/query", bm, aliRsp); err != nil {
+		t.Fatal(err)
+	}
+	if aliRsp.TradeNo != "2013112011001004330000121536" || aliRsp.TradeStatus != "TRADE_SUCCESS" {
+		t.Fatalf("unexpected rsp: %+v", aliRsp)
+	}
+	if bm.GetString("app_auth_token") == "" {
+		t.Fatal("bm should not be modified")
+	}
+	// GET 请求参数拼接在 url 中
+	if err = c.DoAliPayAPISelfV3(ctx, http.MethodGet, "/v3/alipay/user/deloauth/detail/query", bm, nil); err != nil {
+		t.Fatal(err)
+	}
+
+	// 响应签名不匹配
+	c.SetHttpClient(fakeAlipayV3(t, c, http.StatusOK, rspBody, map[string]string{HeaderV3Nonce: "other"}))
+	err = c.DoAliPayAPISelfV3(ctx, http.MethodPost, "/v3/alipay/trade/query", bm, aliRsp)
+	if verifyErr := new(VerifySignError); !errors.As(err, &verifyErr) {
+		t.Fatalf("expected sign verify error, got: %v", err)
+	}
+
+	// 接口失败
+	errBody := `{"code":"ACQ.TRADE_NOT_EXIST","message":"交易不存在"}`
+	c.SetHttpClient(fakeAlipayV3(t, c, http.StatusBadRequest, errBody, nil))
+	err = c.DoAliPayAPISelfV3(ctx, http.MethodPost, "/v3/alipay/trade/query", bm, aliRsp)
+	e, ok := gopay.AsError(err)
+	if !ok || e.Code != "ACQ.TRADE_NOT_EXIST" || e.RequestId != "0b8a4f5a17000000000000001e0b37" || e.Retryable {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	xlog.Debug(err)
+}
+
+func TestClient_DoAliPayAPISelfV3WithCert(t *testing.T) {
+	// 公钥证书模式，以应用公钥证书模拟支付宝公钥证书
+	c, err := NewClient(cert.Appid, cert.PrivateKey, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = c.SetCertSnByContent(cert.AppPublicContent, cert.AlipayRootContent, cert.AppPublicContent); err != nil {
+		t.Fatal(err)
+	}
+	c.AutoVerifySign(cert.AppPublicContent)
+
+	rspBody := `{"trade_no":"2013112011001004330000121536"}`
+	c.SetHttpClient(fakeAlipayV3(t, c, http.StatusOK, rspBody, map[string]string{HeaderV3Sn: c.AliPayPublicCertSN}))
+	if err = c.DoAliPayAPISelfV3(ctx, http.MethodPost, "/v3/alipay/trade/query", nil, nil); err != nil {
+		t.Fatal(err)
+	}
+
+	// 支付宝公钥证书SN不匹配
+	c.SetHttpClient(fakeAlipayV3(t, c, http.StatusOK, rspBody, map[string]string{HeaderV3Sn: "other_sn"}))
+	err = c.DoAliPayAPISelfV3(ctx, http.MethodPost, "/v3/alipay/trade/query", nil, nil)
+	if verifyErr := new(VerifySignError); !errors.As(err, &verifyErr) {
+		t.Fatalf("expected cert sn mismatch error, got: %v", err)
+	}
+}
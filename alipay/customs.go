@@ -1,6 +1,7 @@
 package alipay
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 
@@ -11,13 +12,16 @@ import (
 
 // alipay.trade.customs.declare(统一收单报关接口)
 //	文档地址：https://opendocs.alipay.com/apis/api_29/alipay.trade.customs.declare
-func (a *Client) TradeCustomsDeclare(bm gopay.BodyMap) (aliRsp *TradeCustomsDeclareRsp, err error) {
+func (a *Client) TradeCustomsDeclare(ctx context.Context, bm gopay.BodyMap) (aliRsp *TradeCustomsDeclareRsp, err error) {
 	err = bm.CheckEmptyError("out_request_no", "trade_no", "merchant_customs_code", "merchant_customs_name", "amount", "customs_place")
 	if err != nil {
 		return nil, err
 	}
-	var bs []byte
-	if bs, err = a.doAliPay(bm, "alipay.trade.customs.declare"); err != nil {
+	var (
+		bs          []byte
+		encSignData string
+	)
+	if bs, encSignData, err = a.doAliPay(ctx, bm, "alipay.trade.customs.declare"); err != nil {
 		return nil, err
 	}
 	aliRsp = new(TradeCustomsDeclareRsp)
@@ -26,21 +30,21 @@ func (a *Client) TradeCustomsDeclare(bm gopay.BodyMap) (aliRsp *TradeCustomsDecl
 	}
 	if aliRsp.Response != nil && aliRsp.Response.Code != "10000" {
 		info := aliRsp.Response
-		return aliRsp, fmt.Errorf(`{"code":"%s","msg":"%s","sub_code":"%s","sub_msg":"%s"}`, info.Code, info.Msg, info.SubCode, info.SubMsg)
+		return aliRsp, bizError(info.Code, info.Msg, info.SubCode, info.SubMsg, bs)
 	}
-	signData, signDataErr := a.getSignData(bs, aliRsp.AlipayCertSn)
+	signData, signDataErr := a.getSignData(bs, encSignData, aliRsp.AlipayCertSn)
 	aliRsp.SignData = signData
 	return aliRsp, a.autoVerifySignByCert(aliRsp.Sign, signData, signDataErr)
 }
 
 // alipay.acquire.customs(报关接口)
 //	文档地址：https://opendocs.alipay.com/pre-open/01x3kh
-func (a *Client) AcquireCustoms(bm gopay.BodyMap) (aliRspBs []byte, err error) {
+func (a *Client) AcquireCustoms(ctx context.Context, bm gopay.BodyMap) (aliRspBs []byte, err error) {
 	err = bm.CheckEmptyError("partner", "out_request_no", "trade_no", "merchant_customs_code", "amount", "customs_place", "merchant_customs_name")
 	if err != nil {
 		return nil, err
 	}
-	bs, err := a.doAliPayCustoms(bm, "alipay.acquire.customs")
+	bs, err := a.doAliPayCustoms(ctx, bm, "alipay.acquire.customs")
 	if err != nil {
 		return nil, err
 	}
@@ -49,12 +53,12 @@ func (a *Client) AcquireCustoms(bm gopay.BodyMap) (aliRspBs []byte, err error) {
 
 // alipay.overseas.acquire.customs.query(报关查询接口)
 //	文档地址：https://opendocs.alipay.com/pre-open/01x3ki
-func (a *Client) AcquireCustomsQuery(bm gopay.BodyMap) (aliRspBs []byte, err error) {
+func (a *Client) AcquireCustomsQuery(ctx context.Context, bm gopay.BodyMap) (aliRspBs []byte, err error) {
 	err = bm.CheckEmptyError("partner", "out_request_nos")
 	if err != nil {
 		return nil, err
 	}
-	bs, err := a.doAliPayCustoms(bm, "alipay.overseas.acquire.customs.query")
+	bs, err := a.doAliPayCustoms(ctx, bm, "alipay.overseas.acquire.customs.query")
 	if err != nil {
 		return nil, err
 	}
@@ -62,7 +66,7 @@ func (a *Client) AcquireCustomsQuery(bm gopay.BodyMap) (aliRspBs []byte, err err
 }
 
 // 向支付宝发送请求
-func (a *Client) doAliPayCustoms(bm gopay.BodyMap, service string) (bs []byte, err error) {
+func (a *Client) doAliPayCustoms(ctx context.Context, bm gopay.BodyMap, service string) (bs []byte, err error) {
 	bm.Set("service", service).
 		Set("_input_charset", "utf-8")
 	bm.Remove("sign_type")
@@ -78,8 +82,8 @@ func (a *Client) doAliPayCustoms(bm gopay.BodyMap, service string) (bs []byte, e
 		xlog.Debugf("Alipay_Request: %s", bm.JsonBody())
 	}
 	// request
-	httpClient := xhttp.NewClient()
-	res, bs, errs := httpClient.Type(xhttp.TypeForm).Post("https://mapi.alipay.com/gateway.do").SendString(bm.EncodeURLParams()).EndBytes()
+	httpClient := xhttp.NewClient().SetHttpClient(a.hc)
+	res, bs, errs := httpClient.Type(xhttp.TypeForm).Post("https://mapi.alipay.com/gateway.do").SendString(bm.EncodeURLParams()).EndBytesWithContext(ctx)
 	if len(errs) > 0 {
 		return nil, errs[0]
 	}
@@ -87,7 +91,7 @@ func (a *Client) doAliPayCustoms(bm gopay.BodyMap, service string) (bs []byte, e
 		xlog.Debugf("Alipay_Response: %s%d %s%s", xlog.Red, res.StatusCode, xlog.Reset, string(bs))
 	}
 	if res.StatusCode != 200 {
-		return nil, fmt.Errorf("HTTP Request Error, StatusCode = %d", res.StatusCode)
+		return nil, httpError(res, bs)
 	}
 	return bs, nil
 }
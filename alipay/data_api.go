@@ -1,8 +1,8 @@
 package alipay
 
 import (
+	"context"
 	"encoding/json"
-	"fmt"
 
 	"github.com/yuanqinguo/gopay"
 )
@@ -11,9 +11,12 @@ import (
 // 支付宝已不再支持
 // alipay.data.bill.balance.query(支付宝商家账户当前余额查询)
 //	文档地址：https://opendocs.alipay.com/apis/api_15/alipay.data.bill.balance.query
-func (a *Client) DataBillBalanceQuery(bm gopay.BodyMap) (aliRsp *DataBillBalanceQueryResponse, err error) {
-	var bs []byte
-	if bs, err = a.doAliPay(bm, "alipay.data.bill.balance.query"); err != nil {
+func (a *Client) DataBillBalanceQuery(ctx context.Context, bm gopay.BodyMap) (aliRsp *DataBillBalanceQueryResponse, err error) {
+	var (
+		bs          []byte
+		encSignData string
+	)
+	if bs, encSignData, err = a.doAliPay(ctx, bm, "alipay.data.bill.balance.query"); err != nil {
 		return nil, err
 	}
 	aliRsp = new(DataBillBalanceQueryResponse)
@@ -22,22 +25,25 @@ func (a *Client) DataBillBalanceQuery(bm gopay.BodyMap) (aliRsp *DataBillBalance
 	}
 	if aliRsp.Response != nil && aliRsp.Response.Code != "10000" {
 		info := aliRsp.Response
-		return aliRsp, fmt.Errorf(`{"code":"%s","msg":"%s","sub_code":"%s","sub_msg":"%s"}`, info.Code, info.Msg, info.SubCode, info.SubMsg)
+		return aliRsp, bizError(info.Code, info.Msg, info.SubCode, info.SubMsg, bs)
 	}
-	signData, signDataErr := a.getSignData(bs, aliRsp.AlipayCertSn)
+	signData, signDataErr := a.getSignData(bs, encSignData, aliRsp.AlipayCertSn)
 	aliRsp.SignData = signData
 	return aliRsp, a.autoVerifySignByCert(aliRsp.Sign, signData, signDataErr)
 }
 
 // alipay.data.dataservice.bill.downloadurl.query(查询对账单下载地址)
 //	文档地址：https://opendocs.alipay.com/apis/api_15/alipay.data.dataservice.bill.downloadurl.query
-func (a *Client) DataBillDownloadUrlQuery(bm gopay.BodyMap) (aliRsp *DataBillDownloadUrlQueryResponse, err error) {
+func (a *Client) DataBillDownloadUrlQuery(ctx context.Context, bm gopay.BodyMap) (aliRsp *DataBillDownloadUrlQueryResponse, err error) {
 	err = bm.CheckEmptyError("bill_type", "bill_date")
 	if err != nil {
 		return nil, err
 	}
-	var bs []byte
-	if bs, err = a.doAliPay(bm, "alipay.data.dataservice.bill.downloadurl.query"); err != nil {
+	var (
+		bs          []byte
+		encSignData string
+	)
+	if bs, encSignData, err = a.doAliPay(ctx, bm, "alipay.data.dataservice.bill.downloadurl.query"); err != nil {
 		return nil, err
 	}
 	aliRsp = new(DataBillDownloadUrlQueryResponse)
@@ -46,9 +52,9 @@ func (a *Client) DataBillDownloadUrlQuery(bm gopay.BodyMap) (aliRsp *DataBillDow
 	}
 	if aliRsp.Response != nil && aliRsp.Response.Code != "10000" {
 		info := aliRsp.Response
-		return aliRsp, fmt.Errorf(`{"code":"%s","msg":"%s","sub_code":"%s","sub_msg":"%s"}`, info.Code, info.Msg, info.SubCode, info.SubMsg)
+		return aliRsp, bizError(info.Code, info.Msg, info.SubCode, info.SubMsg, bs)
 	}
-	signData, signDataErr := a.getSignData(bs, aliRsp.AlipayCertSn)
+	signData, signDataErr := a.getSignData(bs, encSignData, aliRsp.AlipayCertSn)
 	aliRsp.SignData = signData
 	return aliRsp, a.autoVerifySignByCert(aliRsp.Sign, signData, signDataErr)
 }
@@ -11,7 +11,7 @@ func TestClient_DataBillDownloadUrlQuery(t *testing.T) {
 	bm := make(gopay.BodyMap)
 	bm.Set("bill_type", "trade").
 		Set("bill_date", "2016-04-05")
-	rsp, err := client.DataBillDownloadUrlQuery(bm)
+	rsp, err := client.DataBillDownloadUrlQuery(ctx, bm)
 	if err != nil {
 		xlog.Error(err)
 		return
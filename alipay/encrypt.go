@@ -0,0 +1,97 @@
+package alipay
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/yuanqinguo/gopay"
+	"github.com/yuanqinguo/gopay/pkg/aes"
+	"github.com/yuanqinguo/gopay/pkg/util"
+)
+
+const (
+	EncryptTypeAES = "AES"
+)
+
+// 接口内容加密使用的 iv，固定为 16 个 0
+var aesIv = []byte{0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0}
+
+// 设置接口内容加密密钥，设置后请求的 biz_content 使用 AES 加密，加密的响应自动解密
+//	注意：需先在支付宝开放平台开通接口内容加密，并获取AES密钥
+//	aesKey：AES密钥（Base64编码）
+//	文档：https://opendocs.alipay.com/open/common/104567
+func (a *Client) SetAESKey(aesKey string) (err error) {
+	key, err := base64.StdEncoding.DecodeString(aesKey)
+	if err != nil {
+		return fmt.Errorf("base64.StdEncoding.DecodeString(%s)：%w", aesKey, err)
+	}
+	switch len(key) {
+	case 16, 24, 32:
+	default:
+		return fmt.Errorf("invalid aes key length: %d", len(key))
+	}
+	a.aesKey = key
+	return nil
+}
+
+// encryptBizContent 设置了AES密钥时，加密 biz_content 并设置 encrypt_type
+//	已设置 encrypt_type 时，认为 biz_content 已自行加密
+func (a *Client) encryptBizContent(bm gopay.BodyMap) (err error) {
+	bizContent := bm.GetString("biz_content")
+	if a.aesKey == nil || bizContent == util.NULL || bm.GetString("encrypt_type") != util.NULL {
+		return nil
+	}
+	secretData, err := aes.CBCEncryptIvData([]byte(bizContent), a.aesKey, aesIv)
+	if err != nil {
+		return fmt.Errorf("aes.CBCEncryptIvData：%w", err)
+	}
+	bm.Set("encrypt_type", EncryptTypeAES).
+		Set("biz_content", base64.StdEncoding.EncodeToString(secretData))
+	return nil
+}
+
+// decryptResponse 设置了AES密钥且响应内容为密文时，解密响应内容
+//	plain：xxx_response 替换为明文后的响应，其余内容不变
+//	signData：原始密文（含引号），即验签内容，传给 getSignData()；响应未加密时为空
+//	接口失败时，支付宝返回明文响应，原样返回
+func (a *Client) decryptResponse(bs []byte) (plain []byte, signData string, err error) {
+	var (
+		str        = string(bs)
+		indexStart = strings.Index(str, `_response":`)
+		indexEnd   = strings.Index(str, `,"alipay_cert_sn":`)
+	)
+	if a.aesKey == nil || indexStart == -1 || !strings.HasPrefix(str[indexStart+11:], `"`) {
+		return bs, util.NULL, nil
+	}
+	if indexEnd == -1 {
+		indexEnd = strings.Index(str, `,"sign":`)
+	}
+	if indexEnd == -1 {
+		indexEnd = strings.LastIndex(str, "}")
+	}
+	if indexEnd < indexStart+11 {
+		return nil, util.NULL, errors.New("encrypted response format error")
+	}
+	var (
+		cipherContent = str[indexStart+11 : indexEnd]
+		encrypted     string
+	)
+	if err = json.Unmarshal([]byte(cipherContent), &encrypted); err != nil {
+		return nil, util.NULL, fmt.Errorf("json.Unmarshal(%s)：%w", cipherContent, err)
+	}
+	secretData, err := base64.StdEncoding.DecodeString(encrypted)
+	if err != nil {
+		return nil, util.NULL, fmt.Errorf("base64.StdEncoding.DecodeString(%s)：%w", encrypted, err)
+	}
+	if len(secretData) == 0 || len(secretData)%len(aesIv) != 0 {
+		return nil, util.NULL, errors.New("encrypted response is error")
+	}
+	originData, err := aes.CBCDecryptIvData(secretData, a.aesKey, aesIv)
+	if err != nil {
+		return nil, util.NULL, fmt.Errorf("aes.CBCDecryptIvData：%w", err)
+	}
+	return []byte(str[:indexStart+11] + string(originData) + str[indexEnd:]), cipherContent, nil
+}
@@ -0,0 +1,115 @@
+package alipay
+
+import (
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/yuanqinguo/gopay"
+	"github.com/yuanqinguo/gopay/alipay/cert"
+	"github.com/yuanqinguo/gopay/pkg/aes"
+)
+
+func TestClient_SetAESKey(t *testing.T) {
+	const aesKey = "aa4BtZ4tspm2wnXLb1ThQA=="
+	c, err := NewClient(cert.Appid, cert.PrivateKey, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = c.SetAESKey("aa4BtZ4t"); err == nil {
+		t.Fatal("expected invalid aes key error")
+	}
+	if err = c.SetAESKey(aesKey); err != nil {
+		t.Fatal(err)
+	}
+	pubBs, _ := x509.MarshalPKIXPublicKey(&c.privateKey.PublicKey)
+	if err = c.AutoVerifySignByPublicKey(base64.StdEncoding.EncodeToString(pubBs)); err != nil {
+		t.Fatal(err)
+	}
+
+	key, _ := base64.StdEncoding.DecodeString(aesKey)
+	plain := `{"code":"10000","msg":"Success","trade_no":"2013112011001004330000121536","out_trade_no":"6823789339978248","trade_status":"TRADE_SUCCESS","total_amount":"88.88"}`
+	secretData, _ := aes.CBCEncryptIvData([]byte(plain), key, aesIv)
+	cipherContent := `"` + base64.StdEncoding.EncodeToString(secretData) + `"`
+
+	var signPlain bool
+	c.SetHttpClient(&http.Client{Transport: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		body, _ := ioutil.ReadAll(req.Body)
+		form, _ := url.ParseQuery(string(body))
+		if form.Get("encrypt_type") != EncryptTypeAES {
+			t.Fatalf("encrypt_type not set: %s", body)
+		}
+		bizBs, _ := base64.StdEncoding.DecodeString(form.Get("biz_content"))
+		bizContent, err := aes.CBCDecryptIvData(bizBs, key, aesIv)
+		if err != nil || string(bizContent) != `{"out_trade_no":"6823789339978248"}` {
+			t.Fatalf("unexpected biz_content: %s, %v", bizContent, err)
+		}
+		// 以密文（含引号）签名
+		signData := cipherContent
+		if signPlain {
+			signData = plain
+		}
+		h := sha256.Sum256([]byte(signData))
+		sign, _ := rsa.SignPKCS1v15(rand.Reader, c.privateKey, crypto.SHA256, h[:])
+		rspBody := `{"alipay_trade_query_response":` + cipherContent + `,"sign":"` + base64.StdEncoding.EncodeToString(sign) + `"}`
+		return &http.Response{StatusCode: http.StatusOK, Header: make(http.Header), Body: ioutil.NopCloser(strings.NewReader(rspBody))}, nil
+	})})
+
+	bm := make(gopay.BodyMap)
+	bm.Set("out_trade_no", "6823789339978248")
+	aliRsp, err := c.TradeQuery(ctx, bm)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if aliRsp.Response.TradeNo != "2013112011001004330000121536" || aliRsp.Response.TotalAmount != "88.88" {
+		t.Fatalf("unexpected rsp: %+v", aliRsp.Response)
+	}
+	if aliRsp.SignData != cipherContent {
+		t.Fatalf("sign data should be cipher content, got: %s", aliRsp.SignData)
+	}
+
+	// 签名不是对密文的签名
+	signPlain = true
+	_, err = c.TradeQuery(ctx, bm)
+	if verifyErr := new(VerifySignError); !errors.As(err, &verifyErr) {
+		t.Fatalf("expected sign verify error, got: %v", err)
+	}
+}
+
+func TestDecryptResponse(t *testing.T) {
+	c := &Client{aesKey: []byte("1234567890abcdef")}
+	// 接口失败时，支付宝返回明文响应
+	rsp := `{"alipay_trade_query_response":{"code":"40004","msg":"Business Failed","sub_code":"ACQ.TRADE_NOT_EXIST","sub_msg":"交易不存在"},"alipay_cert_sn":"sn","sign":"xxx"}`
+	bs, signData, err := c.decryptResponse([]byte(rsp))
+	if err != nil || string(bs) != rsp || signData != "" {
+		t.Fatalf("plain response should not be changed: %s, %s, %v", bs, signData, err)
+	}
+
+	// 公钥证书模式
+	secretData, _ := aes.CBCEncryptIvData([]byte(`{"code":"10000","msg":"Success"}`), c.aesKey, aesIv)
+	cipherContent := `"` + strings.Replace(base64.StdEncoding.EncodeToString(secretData), "/", `\/`, -1) + `"`
+	rsp = `{"alipay_trade_query_response":` + cipherContent + `,"alipay_cert_sn":"sn","sign":"xxx"}`
+	if bs, signData, err = c.decryptResponse([]byte(rsp)); err != nil {
+		t.Fatal(err)
+	}
+	// 只替换 xxx_response 为明文，不添加额外字段
+	if want := `{"alipay_trade_query_response":{"code":"10000","msg":"Success"},"alipay_cert_sn":"sn","sign":"xxx"}`; string(bs) != want {
+		t.Fatalf("got: %s, want: %s", bs, want)
+	}
+	if signData != cipherContent {
+		t.Fatalf("sign data should be cipher content, got: %s", signData)
+	}
+	c.AliPayPublicCertSN = "sn"
+	if signData, err = c.getSignData(bs, signData, "sn"); err != nil || signData != cipherContent {
+		t.Fatalf("sign data should be cipher content, got: %s", signData)
+	}
+}
@@ -1,6 +1,7 @@
 package alipay
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 
@@ -9,13 +10,16 @@ import (
 
 // alipay.fund.trans.uni.transfer(单笔转账接口)
 //	文档地址：https://opendocs.alipay.com/apis/api_28/alipay.fund.trans.uni.transfer
-func (a *Client) FundTransUniTransfer(bm gopay.BodyMap) (aliRsp *FundTransUniTransferResponse, err error) {
+func (a *Client) FundTransUniTransfer(ctx context.Context, bm gopay.BodyMap) (aliRsp *FundTransUniTransferResponse, err error) {
 	err = bm.CheckEmptyError("out_biz_no", "trans_amount", "product_code", "payee_info")
 	if err != nil {
 		return nil, err
 	}
-	var bs []byte
-	if bs, err = a.doAliPay(bm, "alipay.fund.trans.uni.transfer"); err != nil {
+	var (
+		bs          []byte
+		encSignData string
+	)
+	if bs, encSignData, err = a.doAliPay(ctx, bm, "alipay.fund.trans.uni.transfer"); err != nil {
 		return nil, err
 	}
 	aliRsp = new(FundTransUniTransferResponse)
@@ -24,22 +28,25 @@ func (a *Client) FundTransUniTransfer(bm gopay.BodyMap) (aliRsp *FundTransUniTra
 	}
 	if aliRsp.Response != nil && aliRsp.Response.Code != "10000" {
 		info := aliRsp.Response
-		return aliRsp, fmt.Errorf(`{"code":"%s","msg":"%s","sub_code":"%s","sub_msg":"%s"}`, info.Code, info.Msg, info.SubCode, info.SubMsg)
+		return aliRsp, bizError(info.Code, info.Msg, info.SubCode, info.SubMsg, bs)
 	}
-	signData, signDataErr := a.getSignData(bs, aliRsp.AlipayCertSn)
+	signData, signDataErr := a.getSignData(bs, encSignData, aliRsp.AlipayCertSn)
 	aliRsp.SignData = signData
 	return aliRsp, a.autoVerifySignByCert(aliRsp.Sign, signData, signDataErr)
 }
 
 // alipay.fund.account.query(支付宝资金账户资产查询接口)
 //	文档地址：https://opendocs.alipay.com/apis/api_28/alipay.fund.account.query
-func (a *Client) FundAccountQuery(bm gopay.BodyMap) (aliRsp *FundAccountQueryResponse, err error) {
+func (a *Client) FundAccountQuery(ctx context.Context, bm gopay.BodyMap) (aliRsp *FundAccountQueryResponse, err error) {
 	err = bm.CheckEmptyError("alipay_user_id")
 	if err != nil {
 		return nil, err
 	}
-	var bs []byte
-	if bs, err = a.doAliPay(bm, "alipay.fund.account.query"); err != nil {
+	var (
+		bs          []byte
+		encSignData string
+	)
+	if bs, encSignData, err = a.doAliPay(ctx, bm, "alipay.fund.account.query"); err != nil {
 		return nil, err
 	}
 	aliRsp = new(FundAccountQueryResponse)
@@ -48,18 +55,21 @@ func (a *Client) FundAccountQuery(bm gopay.BodyMap) (aliRsp *FundAccountQueryRes
 	}
 	if aliRsp.Response != nil && aliRsp.Response.Code != "10000" {
 		info := aliRsp.Response
-		return aliRsp, fmt.Errorf(`{"code":"%s","msg":"%s","sub_code":"%s","sub_msg":"%s"}`, info.Code, info.Msg, info.SubCode, info.SubMsg)
+		return aliRsp, bizError(info.Code, info.Msg, info.SubCode, info.SubMsg, bs)
 	}
-	signData, signDataErr := a.getSignData(bs, aliRsp.AlipayCertSn)
+	signData, signDataErr := a.getSignData(bs, encSignData, aliRsp.AlipayCertSn)
 	aliRsp.SignData = signData
 	return aliRsp, a.autoVerifySignByCert(aliRsp.Sign, signData, signDataErr)
 }
 
 // alipay.fund.trans.common.query(转账业务单据查询接口)
 //	文档地址：https://opendocs.alipay.com/apis/api_28/alipay.fund.trans.common.query
-func (a *Client) FundTransCommonQuery(bm gopay.BodyMap) (aliRsp *FundTransCommonQueryResponse, err error) {
-	var bs []byte
-	if bs, err = a.doAliPay(bm, "alipay.fund.trans.common.query"); err != nil {
+func (a *Client) FundTransCommonQuery(ctx context.Context, bm gopay.BodyMap) (aliRsp *FundTransCommonQueryResponse, err error) {
+	var (
+		bs          []byte
+		encSignData string
+	)
+	if bs, encSignData, err = a.doAliPay(ctx, bm, "alipay.fund.trans.common.query"); err != nil {
 		return nil, err
 	}
 	aliRsp = new(FundTransCommonQueryResponse)
@@ -68,16 +78,16 @@ func (a *Client) FundTransCommonQuery(bm gopay.BodyMap) (aliRsp *FundTransCommon
 	}
 	if aliRsp.Response != nil && aliRsp.Response.Code != "10000" {
 		info := aliRsp.Response
-		return aliRsp, fmt.Errorf(`{"code":"%s","msg":"%s","sub_code":"%s","sub_msg":"%s"}`, info.Code, info.Msg, info.SubCode, info.SubMsg)
+		return aliRsp, bizError(info.Code, info.Msg, info.SubCode, info.SubMsg, bs)
 	}
-	signData, signDataErr := a.getSignData(bs, aliRsp.AlipayCertSn)
+	signData, signDataErr := a.getSignData(bs, encSignData, aliRsp.AlipayCertSn)
 	aliRsp.SignData = signData
 	return aliRsp, a.autoVerifySignByCert(aliRsp.Sign, signData, signDataErr)
 }
 
 // alipay.fund.trans.order.query(查询转账订单接口)
 // 文档地址: https://opendocs.alipay.com/apis/api_28/alipay.fund.trans.order.query
-func (a *Client) FundTransOrderQuery(bm gopay.BodyMap) (aliRsp *FundTransOrderQueryResponse, err error) {
+func (a *Client) FundTransOrderQuery(ctx context.Context, bm gopay.BodyMap) (aliRsp *FundTransOrderQueryResponse, err error) {
 	// 两个请求参数不能同时为空
 	err1 := bm.CheckEmptyError("out_biz_no")
 	err2 := bm.CheckEmptyError("order_id")
@@ -85,8 +95,11 @@ func (a *Client) FundTransOrderQuery(bm gopay.BodyMap) (aliRsp *FundTransOrderQu
 		return nil, fmt.Errorf("out_biz_no,order_id : Both cannot be empty at some time")
 	}
 
-	var bs []byte
-	if bs, err = a.doAliPay(bm, "alipay.fund.trans.order.query"); err != nil {
+	var (
+		bs          []byte
+		encSignData string
+	)
+	if bs, encSignData, err = a.doAliPay(ctx, bm, "alipay.fund.trans.order.query"); err != nil {
 		return nil, err
 	}
 
@@ -96,22 +109,25 @@ func (a *Client) FundTransOrderQuery(bm gopay.BodyMap) (aliRsp *FundTransOrderQu
 	}
 	if aliRsp.Response != nil && aliRsp.Response.Code != "10000" {
 		info := aliRsp.Response
-		return aliRsp, fmt.Errorf(`{"code":"%s","msg":"%s","sub_code":"%s","sub_msg":"%s"}`, info.Code, info.Msg, info.SubCode, info.SubMsg)
+		return aliRsp, bizError(info.Code, info.Msg, info.SubCode, info.SubMsg, bs)
 	}
-	signData, signDataErr := a.getSignData(bs, aliRsp.AlipayCertSn)
+	signData, signDataErr := a.getSignData(bs, encSignData, aliRsp.AlipayCertSn)
 	aliRsp.SignData = signData
 	return aliRsp, a.autoVerifySignByCert(aliRsp.Sign, signData, signDataErr)
 }
 
 // alipay.fund.trans.refund(资金退回接口)
 // 文档地址: https://opendocs.alipay.com/apis/api_28/alipay.fund.trans.refund
-func (a *Client) FundTransRefund(bm gopay.BodyMap) (aliRsp *FundTransRefundResponse, err error) {
+func (a *Client) FundTransRefund(ctx context.Context, bm gopay.BodyMap) (aliRsp *FundTransRefundResponse, err error) {
 	err = bm.CheckEmptyError("order_id", "out_request_no", "refund_amount")
 	if err != nil {
 		return nil, err
 	}
-	var bs []byte
-	if bs, err = a.doAliPay(bm, "alipay.fund.trans.refund"); err != nil {
+	var (
+		bs          []byte
+		encSignData string
+	)
+	if bs, encSignData, err = a.doAliPay(ctx, bm, "alipay.fund.trans.refund"); err != nil {
 		return nil, err
 	}
 	aliRsp = new(FundTransRefundResponse)
@@ -120,22 +136,25 @@ func (a *Client) FundTransRefund(bm gopay.BodyMap) (aliRsp *FundTransRefundRespo
 	}
 	if aliRsp.Response != nil && aliRsp.Response.Code != "10000" {
 		info := aliRsp.Response
-		return aliRsp, fmt.Errorf(`{"code":"%s","msg":"%s","sub_code":"%s","sub_msg":"%s"}`, info.Code, info.Msg, info.SubCode, info.SubMsg)
+		return aliRsp, bizError(info.Code, info.Msg, info.SubCode, info.SubMsg, bs)
 	}
-	signData, signDataErr := a.getSignData(bs, aliRsp.AlipayCertSn)
+	signData, signDataErr := a.getSignData(bs, encSignData, aliRsp.AlipayCertSn)
 	aliRsp.SignData = signData
 	return aliRsp, a.autoVerifySignByCert(aliRsp.Sign, signData, signDataErr)
 }
 
 // alipay.fund.auth.order.freeze(资金授权冻结接口)
 // 文档地址: https://opendocs.alipay.com/apis/api_28/alipay.fund.auth.order.freeze
-func (a *Client) FundAuthOrderFreeze(bm gopay.BodyMap) (aliRsp *FundAuthOrderFreezeResponse, err error) {
+func (a *Client) FundAuthOrderFreeze(ctx context.Context, bm gopay.BodyMap) (aliRsp *FundAuthOrderFreezeResponse, err error) {
 	err = bm.CheckEmptyError("auth_code", "auth_code_type", "out_order_no", "out_request_no", "order_title", "amount")
 	if err != nil {
 		return nil, err
 	}
-	var bs []byte
-	if bs, err = a.doAliPay(bm, "alipay.fund.auth.order.freeze"); err != nil {
+	var (
+		bs          []byte
+		encSignData string
+	)
+	if bs, encSignData, err = a.doAliPay(ctx, bm, "alipay.fund.auth.order.freeze"); err != nil {
 		return nil, err
 	}
 	aliRsp = new(FundAuthOrderFreezeResponse)
@@ -144,22 +163,25 @@ func (a *Client) FundAuthOrderFreeze(bm gopay.BodyMap) (aliRsp *FundAuthOrderFre
 	}
 	if aliRsp.Response != nil && aliRsp.Response.Code != "10000" {
 		info := aliRsp.Response
-		return aliRsp, fmt.Errorf(`{"code":"%s","msg":"%s","sub_code":"%s","sub_msg":"%s"}`, info.Code, info.Msg, info.SubCode, info.SubMsg)
+		return aliRsp, bizError(info.Code, info.Msg, info.SubCode, info.SubMsg, bs)
 	}
-	signData, signDataErr := a.getSignData(bs, aliRsp.AlipayCertSn)
+	signData, signDataErr := a.getSignData(bs, encSignData, aliRsp.AlipayCertSn)
 	aliRsp.SignData = signData
 	return aliRsp, a.autoVerifySignByCert(aliRsp.Sign, signData, signDataErr)
 }
 
 // alipay.fund.auth.order.voucher.create(资金授权发码接口)
 // 文档地址: https://opendocs.alipay.com/apis/api_28/alipay.fund.auth.order.voucher.create
-func (a *Client) FundAuthOrderVoucherCreate(bm gopay.BodyMap) (aliRsp *FundAuthOrderVoucherCreateResponse, err error) {
+func (a *Client) FundAuthOrderVoucherCreate(ctx context.Context, bm gopay.BodyMap) (aliRsp *FundAuthOrderVoucherCreateResponse, err error) {
 	err = bm.CheckEmptyError("out_order_no", "out_request_no", "order_title", "amount", "product_code")
 	if err != nil {
 		return nil, err
 	}
-	var bs []byte
-	if bs, err = a.doAliPay(bm, "alipay.fund.auth.order.voucher.create"); err != nil {
+	var (
+		bs          []byte
+		encSignData string
+	)
+	if bs, encSignData, err = a.doAliPay(ctx, bm, "alipay.fund.auth.order.voucher.create"); err != nil {
 		return nil, err
 	}
 	aliRsp = new(FundAuthOrderVoucherCreateResponse)
@@ -168,22 +190,22 @@ func (a *Client) FundAuthOrderVoucherCreate(bm gopay.BodyMap) (aliRsp *FundAuthO
 	}
 	if aliRsp.Response != nil && aliRsp.Response.Code != "10000" {
 		info := aliRsp.Response
-		return aliRsp, fmt.Errorf(`{"code":"%s","msg":"%s","sub_code":"%s","sub_msg":"%s"}`, info.Code, info.Msg, info.SubCode, info.SubMsg)
+		return aliRsp, bizError(info.Code, info.Msg, info.SubCode, info.SubMsg, bs)
 	}
-	signData, signDataErr := a.getSignData(bs, aliRsp.AlipayCertSn)
+	signData, signDataErr := a.getSignData(bs, encSignData, aliRsp.AlipayCertSn)
 	aliRsp.SignData = signData
 	return aliRsp, a.autoVerifySignByCert(aliRsp.Sign, signData, signDataErr)
 }
 
 // alipay.fund.auth.order.app.freeze(线上资金授权冻结接口)
 // 文档地址: https://opendocs.alipay.com/apis/api_28/alipay.fund.auth.order.app.freeze
-func (a *Client) FundAuthOrderAppFreeze(bm gopay.BodyMap) (payParam string, err error) {
+func (a *Client) FundAuthOrderAppFreeze(ctx context.Context, bm gopay.BodyMap) (payParam string, err error) {
 	err = bm.CheckEmptyError("out_order_no", "out_request_no", "order_title", "amount", "product_code")
 	if err != nil {
 		return "", err
 	}
 	var bs []byte
-	if bs, err = a.doAliPay(bm, "alipay.fund.auth.order.app.freeze"); err != nil {
+	if bs, _, err = a.doAliPay(ctx, bm, "alipay.fund.auth.order.app.freeze"); err != nil {
 		return "", err
 	}
 	payParam = string(bs)
@@ -192,13 +214,16 @@ func (a *Client) FundAuthOrderAppFreeze(bm gopay.BodyMap) (payParam string, err
 
 // alipay.fund.auth.order.unfreeze(资金授权解冻接口)
 // 文档地址: https://opendocs.alipay.com/apis/api_28/alipay.fund.auth.order.unfreeze
-func (a *Client) FundAuthOrderUnfreeze(bm gopay.BodyMap) (aliRsp *FundAuthOrderUnfreezeResponse, err error) {
+func (a *Client) FundAuthOrderUnfreeze(ctx context.Context, bm gopay.BodyMap) (aliRsp *FundAuthOrderUnfreezeResponse, err error) {
 	err = bm.CheckEmptyError("auth_no", "out_request_no", "amount", "remark")
 	if err != nil {
 		return nil, err
 	}
-	var bs []byte
-	if bs, err = a.doAliPay(bm, "alipay.fund.auth.order.unfreeze"); err != nil {
+	var (
+		bs          []byte
+		encSignData string
+	)
+	if bs, encSignData, err = a.doAliPay(ctx, bm, "alipay.fund.auth.order.unfreeze"); err != nil {
 		return nil, err
 	}
 	aliRsp = new(FundAuthOrderUnfreezeResponse)
@@ -207,18 +232,21 @@ func (a *Client) FundAuthOrderUnfreeze(bm gopay.BodyMap) (aliRsp *FundAuthOrderU
 	}
 	if aliRsp.Response != nil && aliRsp.Response.Code != "10000" {
 		info := aliRsp.Response
-		return aliRsp, fmt.Errorf(`{"code":"%s","msg":"%s","sub_code":"%s","sub_msg":"%s"}`, info.Code, info.Msg, info.SubCode, info.SubMsg)
+		return aliRsp, bizError(info.Code, info.Msg, info.SubCode, info.SubMsg, bs)
 	}
-	signData, signDataErr := a.getSignData(bs, aliRsp.AlipayCertSn)
+	signData, signDataErr := a.getSignData(bs, encSignData, aliRsp.AlipayCertSn)
 	aliRsp.SignData = signData
 	return aliRsp, a.autoVerifySignByCert(aliRsp.Sign, signData, signDataErr)
 }
 
 // alipay.fund.auth.operation.detail.query(资金授权操作查询接口)
 // 文档地址: https://opendocs.alipay.com/apis/api_28/alipay.fund.auth.operation.detail.query
-func (a *Client) FundAuthOperationDetailQuery(bm gopay.BodyMap) (aliRsp *FundAuthOperationDetailQueryResponse, err error) {
-	var bs []byte
-	if bs, err = a.doAliPay(bm, "alipay.fund.auth.operation.detail.query"); err != nil {
+func (a *Client) FundAuthOperationDetailQuery(ctx context.Context, bm gopay.BodyMap) (aliRsp *FundAuthOperationDetailQueryResponse, err error) {
+	var (
+		bs          []byte
+		encSignData string
+	)
+	if bs, encSignData, err = a.doAliPay(ctx, bm, "alipay.fund.auth.operation.detail.query"); err != nil {
 		return nil, err
 	}
 	aliRsp = new(FundAuthOperationDetailQueryResponse)
@@ -227,22 +255,25 @@ func (a *Client) FundAuthOperationDetailQuery(bm gopay.BodyMap) (aliRsp *FundAut
 	}
 	if aliRsp.Response != nil && aliRsp.Response.Code != "10000" {
 		info := aliRsp.Response
-		return aliRsp, fmt.Errorf(`{"code":"%s","msg":"%s","sub_code":"%s","sub_msg":"%s"}`, info.Code, info.Msg, info.SubCode, info.SubMsg)
+		return aliRsp, bizError(info.Code, info.Msg, info.SubCode, info.SubMsg, bs)
 	}
-	signData, signDataErr := a.getSignData(bs, aliRsp.AlipayCertSn)
+	signData, signDataErr := a.getSignData(bs, encSignData, aliRsp.AlipayCertSn)
 	aliRsp.SignData = signData
 	return aliRsp, a.autoVerifySignByCert(aliRsp.Sign, signData, signDataErr)
 }
 
 // alipay.fund.auth.operation.cancel(资金授权撤销接口)
 // 文档地址: https://opendocs.alipay.com/apis/api_28/alipay.fund.auth.operation.cancel
-func (a *Client) FundAuthOperationCancel(bm gopay.BodyMap) (aliRsp *FundAuthOperationCancelResponse, err error) {
+func (a *Client) FundAuthOperationCancel(ctx context.Context, bm gopay.BodyMap) (aliRsp *FundAuthOperationCancelResponse, err error) {
 	err = bm.CheckEmptyError("remark")
 	if err != nil {
 		return nil, err
 	}
-	var bs []byte
-	if bs, err = a.doAliPay(bm, "alipay.fund.auth.operation.cancel"); err != nil {
+	var (
+		bs          []byte
+		encSignData string
+	)
+	if bs, encSignData, err = a.doAliPay(ctx, bm, "alipay.fund.auth.operation.cancel"); err != nil {
 		return nil, err
 	}
 	aliRsp = new(FundAuthOperationCancelResponse)
@@ -251,22 +282,25 @@ func (a *Client) FundAuthOperationCancel(bm gopay.BodyMap) (aliRsp *FundAuthOper
 	}
 	if aliRsp.Response != nil && aliRsp.Response.Code != "10000" {
 		info := aliRsp.Response
-		return aliRsp, fmt.Errorf(`{"code":"%s","msg":"%s","sub_code":"%s","sub_msg":"%s"}`, info.Code, info.Msg, info.SubCode, info.SubMsg)
+		return aliRsp, bizError(info.Code, info.Msg, info.SubCode, info.SubMsg, bs)
 	}
-	signData, signDataErr := a.getSignData(bs, aliRsp.AlipayCertSn)
+	signData, signDataErr := a.getSignData(bs, encSignData, aliRsp.AlipayCertSn)
 	aliRsp.SignData = signData
 	return aliRsp, a.autoVerifySignByCert(aliRsp.Sign, signData, signDataErr)
 }
 
 // alipay.fund.batch.create(批次下单接口)
 // 文档地址: https://opendocs.alipay.com/apis/api_28/alipay.fund.batch.create
-func (a *Client) FundBatchCreate(bm gopay.BodyMap) (aliRsp *FundBatchCreateResponse, err error) {
+func (a *Client) FundBatchCreate(ctx context.Context, bm gopay.BodyMap) (aliRsp *FundBatchCreateResponse, err error) {
 	err = bm.CheckEmptyError("out_batch_no", "product_code", "biz_scene", "order_title", "total_trans_amount", "total_count", "trans_order_list")
 	if err != nil {
 		return nil, err
 	}
-	var bs []byte
-	if bs, err = a.doAliPay(bm, "alipay.fund.batch.create"); err != nil {
+	var (
+		bs          []byte
+		encSignData string
+	)
+	if bs, encSignData, err = a.doAliPay(ctx, bm, "alipay.fund.batch.create"); err != nil {
 		return nil, err
 	}
 	aliRsp = new(FundBatchCreateResponse)
@@ -275,22 +309,25 @@ func (a *Client) FundBatchCreate(bm gopay.BodyMap) (aliRsp *FundBatchCreateRespo
 	}
 	if aliRsp.Response != nil && aliRsp.Response.Code != "10000" {
 		info := aliRsp.Response
-		return aliRsp, fmt.Errorf(`{"code":"%s","msg":"%s","sub_code":"%s","sub_msg":"%s"}`, info.Code, info.Msg, info.SubCode, info.SubMsg)
+		return aliRsp, bizError(info.Code, info.Msg, info.SubCode, info.SubMsg, bs)
 	}
-	signData, signDataErr := a.getSignData(bs, aliRsp.AlipayCertSn)
+	signData, signDataErr := a.getSignData(bs, encSignData, aliRsp.AlipayCertSn)
 	aliRsp.SignData = signData
 	return aliRsp, a.autoVerifySignByCert(aliRsp.Sign, signData, signDataErr)
 }
 
 // alipay.fund.batch.close(批量转账关单接口)
 // 文档地址: https://opendocs.alipay.com/apis/api_28/alipay.fund.batch.close
-func (a *Client) FundBatchClose(bm gopay.BodyMap) (aliRsp *FundBatchCloseResponse, err error) {
+func (a *Client) FundBatchClose(ctx context.Context, bm gopay.BodyMap) (aliRsp *FundBatchCloseResponse, err error) {
 	err = bm.CheckEmptyError("biz_scene")
 	if err != nil {
 		return nil, err
 	}
-	var bs []byte
-	if bs, err = a.doAliPay(bm, "alipay.fund.batch.close"); err != nil {
+	var (
+		bs          []byte
+		encSignData string
+	)
+	if bs, encSignData, err = a.doAliPay(ctx, bm, "alipay.fund.batch.close"); err != nil {
 		return nil, err
 	}
 	aliRsp = new(FundBatchCloseResponse)
@@ -299,22 +336,25 @@ func (a *Client) FundBatchClose(bm gopay.BodyMap) (aliRsp *FundBatchCloseRespons
 	}
 	if aliRsp.Response != nil && aliRsp.Response.Code != "10000" {
 		info := aliRsp.Response
-		return aliRsp, fmt.Errorf(`{"code":"%s","msg":"%s","sub_code":"%s","sub_msg":"%s"}`, info.Code, info.Msg, info.SubCode, info.SubMsg)
+		return aliRsp, bizError(info.Code, info.Msg, info.SubCode, info.SubMsg, bs)
 	}
-	signData, signDataErr := a.getSignData(bs, aliRsp.AlipayCertSn)
+	signData, signDataErr := a.getSignData(bs, encSignData, aliRsp.AlipayCertSn)
 	aliRsp.SignData = signData
 	return aliRsp, a.autoVerifySignByCert(aliRsp.Sign, signData, signDataErr)
 }
 
 // alipay.fund.batch.detail.query(批量转账明细查询接口)
 // 文档地址: https://opendocs.alipay.com/apis/api_28/alipay.fund.batch.detail.query
-func (a *Client) FundBatchDetailQuery(bm gopay.BodyMap) (aliRsp *FundBatchDetailQueryResponse, err error) {
+func (a *Client) FundBatchDetailQuery(ctx context.Context, bm gopay.BodyMap) (aliRsp *FundBatchDetailQueryResponse, err error) {
 	err = bm.CheckEmptyError("biz_scene")
 	if err != nil {
 		return nil, err
 	}
-	var bs []byte
-	if bs, err = a.doAliPay(bm, "alipay.fund.batch.detail.query"); err != nil {
+	var (
+		bs          []byte
+		encSignData string
+	)
+	if bs, encSignData, err = a.doAliPay(ctx, bm, "alipay.fund.batch.detail.query"); err != nil {
 		return nil, err
 	}
 	aliRsp = new(FundBatchDetailQueryResponse)
@@ -323,22 +363,25 @@ func (a *Client) FundBatchDetailQuery(bm gopay.BodyMap) (aliRsp *FundBatchDetail
 	}
 	if aliRsp.Response != nil && aliRsp.Response.Code != "10000" {
 		info := aliRsp.Response
-		return aliRsp, fmt.Errorf(`{"code":"%s","msg":"%s","sub_code":"%s","sub_msg":"%s"}`, info.Code, info.Msg, info.SubCode, info.SubMsg)
+		return aliRsp, bizError(info.Code, info.Msg, info.SubCode, info.SubMsg, bs)
 	}
-	signData, signDataErr := a.getSignData(bs, aliRsp.AlipayCertSn)
+	signData, signDataErr := a.getSignData(bs, encSignData, aliRsp.AlipayCertSn)
 	aliRsp.SignData = signData
 	return aliRsp, a.autoVerifySignByCert(aliRsp.Sign, signData, signDataErr)
 }
 
 // alipay.fund.trans.app.pay(现金红包无线支付接口)
 // 文档地址: https://opendocs.alipay.com/apis/api_28/alipay.fund.trans.app.pay
-func (a *Client) FundTransAppPay(bm gopay.BodyMap) (aliRsp *FundTransAppPayResponse, err error) {
+func (a *Client) FundTransAppPay(ctx context.Context, bm gopay.BodyMap) (aliRsp *FundTransAppPayResponse, err error) {
 	err = bm.CheckEmptyError("out_biz_no", "trans_amount", "product_code", "biz_scene")
 	if err != nil {
 		return nil, err
 	}
-	var bs []byte
-	if bs, err = a.doAliPay(bm, "alipay.fund.trans.app.pay"); err != nil {
+	var (
+		bs          []byte
+		encSignData string
+	)
+	if bs, encSignData, err = a.doAliPay(ctx, bm, "alipay.fund.trans.app.pay"); err != nil {
 		return nil, err
 	}
 	aliRsp = new(FundTransAppPayResponse)
@@ -347,22 +390,25 @@ func (a *Client) FundTransAppPay(bm gopay.BodyMap) (aliRsp *FundTransAppPayRespo
 	}
 	if aliRsp.Response != nil && aliRsp.Response.Code != "10000" {
 		info := aliRsp.Response
-		return aliRsp, fmt.Errorf(`{"code":"%s","msg":"%s","sub_code":"%s","sub_msg":"%s"}`, info.Code, info.Msg, info.SubCode, info.SubMsg)
+		return aliRsp, bizError(info.Code, info.Msg, info.SubCode, info.SubMsg, bs)
 	}
-	signData, signDataErr := a.getSignData(bs, aliRsp.AlipayCertSn)
+	signData, signDataErr := a.getSignData(bs, encSignData, aliRsp.AlipayCertSn)
 	aliRsp.SignData = signData
 	return aliRsp, a.autoVerifySignByCert(aliRsp.Sign, signData, signDataErr)
 }
 
 // alipay.fund.trans.payee.bind.query(资金收款账号绑定关系查询)
 // 文档地址: https://opendocs.alipay.com/apis/020tl1
-func (a *Client) FundTransPayeeBindQuery(bm gopay.BodyMap) (aliRsp *FundTransPayeeBindQueryRsp, err error) {
+func (a *Client) FundTransPayeeBindQuery(ctx context.Context, bm gopay.BodyMap) (aliRsp *FundTransPayeeBindQueryRsp, err error) {
 	err = bm.CheckEmptyError("identity", "identity_type")
 	if err != nil {
 		return nil, err
 	}
-	var bs []byte
-	if bs, err = a.doAliPay(bm, "alipay.fund.trans.payee.bind.query"); err != nil {
+	var (
+		bs          []byte
+		encSignData string
+	)
+	if bs, encSignData, err = a.doAliPay(ctx, bm, "alipay.fund.trans.payee.bind.query"); err != nil {
 		return nil, err
 	}
 	aliRsp = new(FundTransPayeeBindQueryRsp)
@@ -371,22 +417,25 @@ func (a *Client) FundTransPayeeBindQuery(bm gopay.BodyMap) (aliRsp *FundTransPay
 	}
 	if aliRsp.Response != nil && aliRsp.Response.Code != "10000" {
 		info := aliRsp.Response
-		return aliRsp, fmt.Errorf(`{"code":"%s","msg":"%s","sub_code":"%s","sub_msg":"%s"}`, info.Code, info.Msg, info.SubCode, info.SubMsg)
+		return aliRsp, bizError(info.Code, info.Msg, info.SubCode, info.SubMsg, bs)
 	}
-	signData, signDataErr := a.getSignData(bs, aliRsp.AlipayCertSn)
+	signData, signDataErr := a.getSignData(bs, encSignData, aliRsp.AlipayCertSn)
 	aliRsp.SignData = signData
 	return aliRsp, a.autoVerifySignByCert(aliRsp.Sign, signData, signDataErr)
 }
 
 // alipay.fund.trans.page.pay(资金转账页面支付接口)
 // 文档地址: https://opendocs.alipay.com/apis/api_1/alipay.fund.trans.page.pay
-func (a *Client) FundTransPagePay(bm gopay.BodyMap) (aliRsp *FundTransPagePayRsp, err error) {
+func (a *Client) FundTransPagePay(ctx context.Context, bm gopay.BodyMap) (aliRsp *FundTransPagePayRsp, err error) {
 	err = bm.CheckEmptyError("out_biz_no", "trans_amount", "product_code", "biz_scene")
 	if err != nil {
 		return nil, err
 	}
-	var bs []byte
-	if bs, err = a.doAliPay(bm, "alipay.fund.trans.page.pay"); err != nil {
+	var (
+		bs          []byte
+		encSignData string
+	)
+	if bs, encSignData, err = a.doAliPay(ctx, bm, "alipay.fund.trans.page.pay"); err != nil {
 		return nil, err
 	}
 	aliRsp = new(FundTransPagePayRsp)
@@ -395,9 +444,9 @@ func (a *Client) FundTransPagePay(bm gopay.BodyMap) (aliRsp *FundTransPagePayRsp
 	}
 	if aliRsp.Response != nil && aliRsp.Response.Code != "10000" {
 		info := aliRsp.Response
-		return aliRsp, fmt.Errorf(`{"code":"%s","msg":"%s","sub_code":"%s","sub_msg":"%s"}`, info.Code, info.Msg, info.SubCode, info.SubMsg)
+		return aliRsp, bizError(info.Code, info.Msg, info.SubCode, info.SubMsg, bs)
 	}
-	signData, signDataErr := a.getSignData(bs, aliRsp.AlipayCertSn)
+	signData, signDataErr := a.getSignData(bs, encSignData, aliRsp.AlipayCertSn)
 	aliRsp.SignData = signData
 	return aliRsp, a.autoVerifySignByCert(aliRsp.Sign, signData, signDataErr)
 }
@@ -1,8 +1,10 @@
 package alipay
 
+import "context"
+
 // alipay.merchant.item.file.upload(商品文件上传接口)
 //	文档地址：https://opendocs.alipay.com/apis/api_4/alipay.merchant.item.file.upload
-func (a *Client) MerchantItemFileUpload() (aliRsp *MerchantItemFileUploadRsp, err error) {
+func (a *Client) MerchantItemFileUpload(ctx context.Context) (aliRsp *MerchantItemFileUploadRsp, err error) {
 	// todo: finish
 	return nil, nil
 }
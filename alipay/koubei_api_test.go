@@ -13,9 +13,9 @@ func TestKoubeiTradeOrderPrecreate(t *testing.T) {
 	bm.Set("request_id", "20181120111040030100030100002400")
 	bm.Set("biz_type", "POST_ORDER_PAY")
 
-	aliRsp, err := client.KoubeiTradeOrderPrecreate(bm)
+	aliRsp, err := client.KoubeiTradeOrderPrecreate(ctx, bm)
 	if err != nil {
-		xlog.Errorf("client.KoubeiTradeOrderPrecreate(%+v),error:%+v", bm, err)
+		xlog.Errorf("client.KoubeiTradeOrderPrecreate(ctx, %+v),error:%+v", bm, err)
 		return
 	}
 	xlog.Debug("aliRsp:", *aliRsp)
@@ -38,9 +38,9 @@ func TestKoubeiTradeItemorderBuy(t *testing.T) {
 		bm.Set("quantity", "10")
 	})
 
-	aliRsp, err := client.KoubeiTradeItemorderBuy(bm)
+	aliRsp, err := client.KoubeiTradeItemorderBuy(ctx, bm)
 	if err != nil {
-		xlog.Errorf("client.KoubeiTradeItemorderBuy(%+v),error:%+v", bm, err)
+		xlog.Errorf("client.KoubeiTradeItemorderBuy(ctx, %+v),error:%+v", bm, err)
 		return
 	}
 	xlog.Debug("aliRsp:", *aliRsp)
@@ -54,9 +54,9 @@ func TestKoubeiTradeOrderConsult(t *testing.T) {
 	bm.Set("total_amount", "88.88")
 	bm.Set("shop_id", "2015051100077000000000000300")
 
-	aliRsp, err := client.KoubeiTradeOrderConsult(bm)
+	aliRsp, err := client.KoubeiTradeOrderConsult(ctx, bm)
 	if err != nil {
-		xlog.Errorf("client.KoubeiTradeOrderConsult(%+v),error:%+v", bm, err)
+		xlog.Errorf("client.KoubeiTradeOrderConsult(ctx, %+v),error:%+v", bm, err)
 		return
 	}
 	xlog.Debug("aliRsp:", *aliRsp)
@@ -73,9 +73,9 @@ func TestKoubeiTradeItemorderRefund(t *testing.T) {
 		bm.Set("amount", "10.00")
 	})
 
-	aliRsp, err := client.KoubeiTradeItemorderRefund(bm)
+	aliRsp, err := client.KoubeiTradeItemorderRefund(ctx, bm)
 	if err != nil {
-		xlog.Errorf("client.KoubeiTradeItemorderRefund(%+v),error:%+v", bm, err)
+		xlog.Errorf("client.KoubeiTradeItemorderRefund(ctx, %+v),error:%+v", bm, err)
 		return
 	}
 	xlog.Debug("aliRsp:", *aliRsp)
@@ -103,9 +103,9 @@ func TestKoubeiTradeItemorderQuery(t *testing.T) {
 		bm.Set("status", "SUCCESS")
 	})
 
-	aliRsp, err := client.KoubeiTradeItemorderQuery(bm)
+	aliRsp, err := client.KoubeiTradeItemorderQuery(ctx, bm)
 	if err != nil {
-		xlog.Errorf("client.KoubeiTradeItemorderQuery(%+v),error:%+v", bm, err)
+		xlog.Errorf("client.KoubeiTradeItemorderQuery(ctx, %+v),error:%+v", bm, err)
 		return
 	}
 	xlog.Debug("aliRsp:", *aliRsp)
@@ -123,9 +123,9 @@ func TestKoubeiTradeTicketTicketcodeSend(t *testing.T) {
 		bm.Set("num", "2")
 	})
 
-	aliRsp, err := client.KoubeiTradeTicketTicketcodeSend(bm)
+	aliRsp, err := client.KoubeiTradeTicketTicketcodeSend(ctx, bm)
 	if err != nil {
-		xlog.Errorf("client.KoubeiTradeTicketTicketcodeSend(%+v),error:%+v", bm, err)
+		xlog.Errorf("client.KoubeiTradeTicketTicketcodeSend(ctx, %+v),error:%+v", bm, err)
 		return
 	}
 	xlog.Debug("aliRsp:", *aliRsp)
@@ -140,9 +140,9 @@ func TestKoubeiTradeTicketTicketcodeDelay(t *testing.T) {
 	bm.Set("code_type", "INTERNAL_CODE")
 	bm.Set("order_no", "20180404111040030100130500594477")
 
-	aliRsp, err := client.KoubeiTradeTicketTicketcodeDelay(bm)
+	aliRsp, err := client.KoubeiTradeTicketTicketcodeDelay(ctx, bm)
 	if err != nil {
-		xlog.Errorf("client.KoubeiTradeTicketTicketcodeDelay(%+v),error:%+v", bm, err)
+		xlog.Errorf("client.KoubeiTradeTicketTicketcodeDelay(ctx, %+v),error:%+v", bm, err)
 		return
 	}
 	xlog.Debug("aliRsp:", *aliRsp)
@@ -154,9 +154,9 @@ func TestKoubeiTradeTicketTicketcodeQuery(t *testing.T) {
 	bm.Set("ticket_code", "016569843362")
 	bm.Set("shop_id", "2017071200077000000039734370")
 
-	aliRsp, err := client.KoubeiTradeTicketTicketcodeQuery(bm)
+	aliRsp, err := client.KoubeiTradeTicketTicketcodeQuery(ctx, bm)
 	if err != nil {
-		xlog.Errorf("client.KoubeiTradeTicketTicketcodeQuery(%+v),error:%+v", bm, err)
+		xlog.Errorf("client.KoubeiTradeTicketTicketcodeQuery(ctx, %+v),error:%+v", bm, err)
 		return
 	}
 	xlog.Debug("aliRsp:", *aliRsp)
@@ -169,9 +169,9 @@ func TestKoubeiTradeTicketTicketcodeCancel(t *testing.T) {
 	bm.Set("request_biz_no", "2016102903214476899999999")
 	bm.Set("ticket_code", "016569843362")
 
-	aliRsp, err := client.KoubeiTradeTicketTicketcodeCancel(bm)
+	aliRsp, err := client.KoubeiTradeTicketTicketcodeCancel(ctx, bm)
 	if err != nil {
-		xlog.Errorf("client.KoubeiTradeTicketTicketcodeCancel(%+v),error:%+v", bm, err)
+		xlog.Errorf("client.KoubeiTradeTicketTicketcodeCancel(ctx, %+v),error:%+v", bm, err)
 		return
 	}
 	xlog.Debug("aliRsp:", *aliRsp)
@@ -1,21 +1,24 @@
 package alipay
 
 import (
+	"context"
 	"encoding/json"
-	"fmt"
 
 	"github.com/yuanqinguo/gopay"
 )
 
 // alipay.open.app.qrcode.create(小程序生成推广二维码接口)
 //	文档地址：https://opendocs.alipay.com/apis/009zva
-func (a *Client) OpenAppQrcodeCreate(bm gopay.BodyMap) (aliRsp *OpenAppQrcodeCreateRsp, err error) {
+func (a *Client) OpenAppQrcodeCreate(ctx context.Context, bm gopay.BodyMap) (aliRsp *OpenAppQrcodeCreateRsp, err error) {
 	err = bm.CheckEmptyError("url_param", "query_param", "describe")
 	if err != nil {
 		return nil, err
 	}
-	var bs []byte
-	if bs, err = a.doAliPay(bm, "alipay.open.app.qrcode.create"); err != nil {
+	var (
+		bs          []byte
+		encSignData string
+	)
+	if bs, encSignData, err = a.doAliPay(ctx, bm, "alipay.open.app.qrcode.create"); err != nil {
 		return nil, err
 	}
 	aliRsp = new(OpenAppQrcodeCreateRsp)
@@ -24,9 +27,9 @@ func (a *Client) OpenAppQrcodeCreate(bm gopay.BodyMap) (aliRsp *OpenAppQrcodeCre
 	}
 	if aliRsp.Response != nil && aliRsp.Response.Code != "10000" {
 		info := aliRsp.Response
-		return aliRsp, fmt.Errorf(`{"code":"%s","msg":"%s","sub_code":"%s","sub_msg":"%s"}`, info.Code, info.Msg, info.SubCode, info.SubMsg)
+		return aliRsp, bizError(info.Code, info.Msg, info.SubCode, info.SubMsg, bs)
 	}
-	signData, signDataErr := a.getSignData(bs, aliRsp.AlipayCertSn)
+	signData, signDataErr := a.getSignData(bs, encSignData, aliRsp.AlipayCertSn)
 	aliRsp.SignData = signData
 	return aliRsp, a.autoVerifySignByCert(aliRsp.Sign, signData, signDataErr)
 }
@@ -1,9 +1,9 @@
 package alipay
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
-	"fmt"
 
 	"github.com/yuanqinguo/gopay"
 	"github.com/yuanqinguo/gopay/pkg/util"
@@ -12,12 +12,15 @@ import (
 // alipay.user.info.share(支付宝会员授权信息查询接口)
 //	body：此接口无需body参数
 //	文档地址：https://opendocs.alipay.com/apis/api_2/alipay.user.info.share
-func (a *Client) UserInfoShare(authToken string) (aliRsp *UserInfoShareResponse, err error) {
+func (a *Client) UserInfoShare(ctx context.Context, authToken string) (aliRsp *UserInfoShareResponse, err error) {
 	if authToken == "" {
 		return nil, errors.New("auth_token can not be null")
 	}
-	var bs []byte
-	if bs, err = a.doAliPay(nil, "alipay.user.info.share", authToken); err != nil {
+	var (
+		bs          []byte
+		encSignData string
+	)
+	if bs, encSignData, err = a.doAliPay(ctx, nil, "alipay.user.info.share", authToken); err != nil {
 		return nil, err
 	}
 	aliRsp = new(UserInfoShareResponse)
@@ -26,22 +29,25 @@ func (a *Client) UserInfoShare(authToken string) (aliRsp *UserInfoShareResponse,
 	}
 	if aliRsp.Response != nil && aliRsp.Response.Code != "10000" {
 		info := aliRsp.Response
-		return aliRsp, fmt.Errorf(`{"code":"%s","msg":"%s","sub_code":"%s","sub_msg":"%s"}`, info.Code, info.Msg, info.SubCode, info.SubMsg)
+		return aliRsp, bizError(info.Code, info.Msg, info.SubCode, info.SubMsg, bs)
 	}
-	signData, signDataErr := a.getSignData(bs, aliRsp.AlipayCertSn)
+	signData, signDataErr := a.getSignData(bs, encSignData, aliRsp.AlipayCertSn)
 	aliRsp.SignData = signData
 	return aliRsp, a.autoVerifySignByCert(aliRsp.Sign, signData, signDataErr)
 }
 
 // alipay.user.certify.open.initialize(身份认证初始化服务)
 //	文档地址：https://opendocs.alipay.com/apis/api_2/alipay.user.certify.open.initialize
-func (a *Client) UserCertifyOpenInit(bm gopay.BodyMap) (aliRsp *UserCertifyOpenInitResponse, err error) {
+func (a *Client) UserCertifyOpenInit(ctx context.Context, bm gopay.BodyMap) (aliRsp *UserCertifyOpenInitResponse, err error) {
 	err = bm.CheckEmptyError("outer_order_no", "biz_code", "identity_param", "merchant_config")
 	if err != nil {
 		return nil, err
 	}
-	var bs []byte
-	if bs, err = a.doAliPay(bm, "alipay.user.certify.open.initialize"); err != nil {
+	var (
+		bs          []byte
+		encSignData string
+	)
+	if bs, encSignData, err = a.doAliPay(ctx, bm, "alipay.user.certify.open.initialize"); err != nil {
 		return nil, err
 	}
 	aliRsp = new(UserCertifyOpenInitResponse)
@@ -50,9 +56,9 @@ func (a *Client) UserCertifyOpenInit(bm gopay.BodyMap) (aliRsp *UserCertifyOpenI
 	}
 	if aliRsp.Response != nil && aliRsp.Response.Code != "10000" {
 		info := aliRsp.Response
-		return aliRsp, fmt.Errorf(`{"code":"%s","msg":"%s","sub_code":"%s","sub_msg":"%s"}`, info.Code, info.Msg, info.SubCode, info.SubMsg)
+		return aliRsp, bizError(info.Code, info.Msg, info.SubCode, info.SubMsg, bs)
 	}
-	signData, signDataErr := a.getSignData(bs, aliRsp.AlipayCertSn)
+	signData, signDataErr := a.getSignData(bs, encSignData, aliRsp.AlipayCertSn)
 	aliRsp.SignData = signData
 	return aliRsp, a.autoVerifySignByCert(aliRsp.Sign, signData, signDataErr)
 }
@@ -60,13 +66,13 @@ func (a *Client) UserCertifyOpenInit(bm gopay.BodyMap) (aliRsp *UserCertifyOpenI
 // alipay.user.certify.open.certify(身份认证开始认证)
 //	API文档地址：https://opendocs.alipay.com/apis/api_2/alipay.user.certify.open.certify
 //	产品文档地址：https://opendocs.alipay.com/open/20181012100420932508/quickstart
-func (a *Client) UserCertifyOpenCertify(bm gopay.BodyMap) (certifyUrl string, err error) {
+func (a *Client) UserCertifyOpenCertify(ctx context.Context, bm gopay.BodyMap) (certifyUrl string, err error) {
 	err = bm.CheckEmptyError("certify_id")
 	if err != nil {
 		return util.NULL, err
 	}
 	var bs []byte
-	if bs, err = a.doAliPay(bm, "alipay.user.certify.open.certify"); err != nil {
+	if bs, _, err = a.doAliPay(ctx, bm, "alipay.user.certify.open.certify"); err != nil {
 		return util.NULL, err
 	}
 	certifyUrl = string(bs)
@@ -75,13 +81,16 @@ func (a *Client) UserCertifyOpenCertify(bm gopay.BodyMap) (certifyUrl string, er
 
 // alipay.user.certify.open.query(身份认证记录查询)
 //	文档地址：https://opendocs.alipay.com/apis/api_2/alipay.user.certify.open.query
-func (a *Client) UserCertifyOpenQuery(bm gopay.BodyMap) (aliRsp *UserCertifyOpenQueryResponse, err error) {
+func (a *Client) UserCertifyOpenQuery(ctx context.Context, bm gopay.BodyMap) (aliRsp *UserCertifyOpenQueryResponse, err error) {
 	err = bm.CheckEmptyError("certify_id")
 	if err != nil {
 		return nil, err
 	}
-	var bs []byte
-	if bs, err = a.doAliPay(bm, "alipay.user.certify.open.query"); err != nil {
+	var (
+		bs          []byte
+		encSignData string
+	)
+	if bs, encSignData, err = a.doAliPay(ctx, bm, "alipay.user.certify.open.query"); err != nil {
 		return nil, err
 	}
 	aliRsp = new(UserCertifyOpenQueryResponse)
@@ -90,22 +99,25 @@ func (a *Client) UserCertifyOpenQuery(bm gopay.BodyMap) (aliRsp *UserCertifyOpen
 	}
 	if aliRsp.Response != nil && aliRsp.Response.Code != "10000" {
 		info := aliRsp.Response
-		return aliRsp, fmt.Errorf(`{"code":"%s","msg":"%s","sub_code":"%s","sub_msg":"%s"}`, info.Code, info.Msg, info.SubCode, info.SubMsg)
+		return aliRsp, bizError(info.Code, info.Msg, info.SubCode, info.SubMsg, bs)
 	}
-	signData, signDataErr := a.getSignData(bs, aliRsp.AlipayCertSn)
+	signData, signDataErr := a.getSignData(bs, encSignData, aliRsp.AlipayCertSn)
 	aliRsp.SignData = signData
 	return aliRsp, a.autoVerifySignByCert(aliRsp.Sign, signData, signDataErr)
 }
 
 // alipay.user.agreement.page.sign(支付宝个人协议页面签约接口)
 //	文档地址：https://opendocs.alipay.com/apis/api_2/alipay.user.agreement.page.sign
-func (a *Client) UserAgreementPageSign(bm gopay.BodyMap) (aliRsp *UserAgreementPageSignRsp, err error) {
+func (a *Client) UserAgreementPageSign(ctx context.Context, bm gopay.BodyMap) (aliRsp *UserAgreementPageSignRsp, err error) {
 	err = bm.CheckEmptyError("personal_product_code")
 	if err != nil {
 		return nil, err
 	}
-	var bs []byte
-	if bs, err = a.doAliPay(bm, "alipay.user.agreement.page.sign"); err != nil {
+	var (
+		bs          []byte
+		encSignData string
+	)
+	if bs, encSignData, err = a.doAliPay(ctx, bm, "alipay.user.agreement.page.sign"); err != nil {
 		return nil, err
 	}
 	aliRsp = new(UserAgreementPageSignRsp)
@@ -114,18 +126,21 @@ func (a *Client) UserAgreementPageSign(bm gopay.BodyMap) (aliRsp *UserAgreementP
 	}
 	if aliRsp.Response != nil && aliRsp.Response.Code != "10000" {
 		info := aliRsp.Response
-		return aliRsp, fmt.Errorf(`{"code":"%s","msg":"%s","sub_code":"%s","sub_msg":"%s"}`, info.Code, info.Msg, info.SubCode, info.SubMsg)
+		return aliRsp, bizError(info.Code, info.Msg, info.SubCode, info.SubMsg, bs)
 	}
-	signData, signDataErr := a.getSignData(bs, aliRsp.AlipayCertSn)
+	signData, signDataErr := a.getSignData(bs, encSignData, aliRsp.AlipayCertSn)
 	aliRsp.SignData = signData
 	return aliRsp, a.autoVerifySignByCert(aliRsp.Sign, signData, signDataErr)
 }
 
 // alipay.user.agreement.unsign(支付宝个人代扣协议解约接口)
 //	文档地址：https://opendocs.alipay.com/apis/api_2/alipay.user.agreement.page.unsign
-func (a *Client) UserAgreementPageUnSign(bm gopay.BodyMap) (aliRsp *UserAgreementPageUnSignRsp, err error) {
-	var bs []byte
-	if bs, err = a.doAliPay(bm, "alipay.user.agreement.unsign"); err != nil {
+func (a *Client) UserAgreementPageUnSign(ctx context.Context, bm gopay.BodyMap) (aliRsp *UserAgreementPageUnSignRsp, err error) {
+	var (
+		bs          []byte
+		encSignData string
+	)
+	if bs, encSignData, err = a.doAliPay(ctx, bm, "alipay.user.agreement.unsign"); err != nil {
 		return nil, err
 	}
 	aliRsp = new(UserAgreementPageUnSignRsp)
@@ -134,18 +149,21 @@ func (a *Client) UserAgreementPageUnSign(bm gopay.BodyMap) (aliRsp *UserAgreemen
 	}
 	if aliRsp.Response != nil && aliRsp.Response.Code != "10000" {
 		info := aliRsp.Response
-		return aliRsp, fmt.Errorf(`{"code":"%s","msg":"%s","sub_code":"%s","sub_msg":"%s"}`, info.Code, info.Msg, info.SubCode, info.SubMsg)
+		return aliRsp, bizError(info.Code, info.Msg, info.SubCode, info.SubMsg, bs)
 	}
-	signData, signDataErr := a.getSignData(bs, aliRsp.AlipayCertSn)
+	signData, signDataErr := a.getSignData(bs, encSignData, aliRsp.AlipayCertSn)
 	aliRsp.SignData = signData
 	return aliRsp, a.autoVerifySignByCert(aliRsp.Sign, signData, signDataErr)
 }
 
 // alipay.user.agreement.query(支付宝个人代扣协议查询接口)
 //	文档地址：https://opendocs.alipay.com/apis/api_2/alipay.user.agreement.query
-func (a *Client) UserAgreementQuery(bm gopay.BodyMap) (aliRsp *UserAgreementQueryRsp, err error) {
-	var bs []byte
-	if bs, err = a.doAliPay(bm, "alipay.user.agreement.query"); err != nil {
+func (a *Client) UserAgreementQuery(ctx context.Context, bm gopay.BodyMap) (aliRsp *UserAgreementQueryRsp, err error) {
+	var (
+		bs          []byte
+		encSignData string
+	)
+	if bs, encSignData, err = a.doAliPay(ctx, bm, "alipay.user.agreement.query"); err != nil {
 		return nil, err
 	}
 	aliRsp = new(UserAgreementQueryRsp)
@@ -154,22 +172,25 @@ func (a *Client) UserAgreementQuery(bm gopay.BodyMap) (aliRsp *UserAgreementQuer
 	}
 	if aliRsp.Response != nil && aliRsp.Response.Code != "10000" {
 		info := aliRsp.Response
-		return aliRsp, fmt.Errorf(`{"code":"%s","msg":"%s","sub_code":"%s","sub_msg":"%s"}`, info.Code, info.Msg, info.SubCode, info.SubMsg)
+		return aliRsp, bizError(info.Code, info.Msg, info.SubCode, info.SubMsg, bs)
 	}
-	signData, signDataErr := a.getSignData(bs, aliRsp.AlipayCertSn)
+	signData, signDataErr := a.getSignData(bs, encSignData, aliRsp.AlipayCertSn)
 	aliRsp.SignData = signData
 	return aliRsp, a.autoVerifySignByCert(aliRsp.Sign, signData, signDataErr)
 }
 
 // alipay.user.agreement.executionplan.modify(周期性扣款协议执行计划修改接口)
 //	文档地址：https://opendocs.alipay.com/apis/api_2/alipay.user.agreement.executionplan.modify
-func (a *Client) UserAgreementExecutionplanModify(bm gopay.BodyMap) (aliRsp *UserAgreementExecutionplanModifyRsp, err error) {
+func (a *Client) UserAgreementExecutionplanModify(ctx context.Context, bm gopay.BodyMap) (aliRsp *UserAgreementExecutionplanModifyRsp, err error) {
 	err = bm.CheckEmptyError("agreement_no", "deduct_time")
 	if err != nil {
 		return nil, err
 	}
-	var bs []byte
-	if bs, err = a.doAliPay(bm, "alipay.user.agreement.executionplan.modify"); err != nil {
+	var (
+		bs          []byte
+		encSignData string
+	)
+	if bs, encSignData, err = a.doAliPay(ctx, bm, "alipay.user.agreement.executionplan.modify"); err != nil {
 		return nil, err
 	}
 	aliRsp = new(UserAgreementExecutionplanModifyRsp)
@@ -178,22 +199,25 @@ func (a *Client) UserAgreementExecutionplanModify(bm gopay.BodyMap) (aliRsp *Use
 	}
 	if aliRsp.Response != nil && aliRsp.Response.Code != "10000" {
 		info := aliRsp.Response
-		return aliRsp, fmt.Errorf(`{"code":"%s","msg":"%s","sub_code":"%s","sub_msg":"%s"}`, info.Code, info.Msg, info.SubCode, info.SubMsg)
+		return aliRsp, bizError(info.Code, info.Msg, info.SubCode, info.SubMsg, bs)
 	}
-	signData, signDataErr := a.getSignData(bs, aliRsp.AlipayCertSn)
+	signData, signDataErr := a.getSignData(bs, encSignData, aliRsp.AlipayCertSn)
 	aliRsp.SignData = signData
 	return aliRsp, a.autoVerifySignByCert(aliRsp.Sign, signData, signDataErr)
 }
 
 // alipay.user.agreement.transfer(协议由普通通用代扣协议产品转移到周期扣协议产品)
 //	文档地址：https://opendocs.alipay.com/apis/api_2/alipay.user.agreement.transfer
-func (a *Client) UserAgreementTransfer(bm gopay.BodyMap) (aliRsp *UserAgreementTransferRsp, err error) {
+func (a *Client) UserAgreementTransfer(ctx context.Context, bm gopay.BodyMap) (aliRsp *UserAgreementTransferRsp, err error) {
 	err = bm.CheckEmptyError("agreement_no", "target_product_code", "period_rule_params")
 	if err != nil {
 		return nil, err
 	}
-	var bs []byte
-	if bs, err = a.doAliPay(bm, "alipay.user.agreement.transfer"); err != nil {
+	var (
+		bs          []byte
+		encSignData string
+	)
+	if bs, encSignData, err = a.doAliPay(ctx, bm, "alipay.user.agreement.transfer"); err != nil {
 		return nil, err
 	}
 	aliRsp = new(UserAgreementTransferRsp)
@@ -202,22 +226,25 @@ func (a *Client) UserAgreementTransfer(bm gopay.BodyMap) (aliRsp *UserAgreementT
 	}
 	if aliRsp.Response != nil && aliRsp.Response.Code != "10000" {
 		info := aliRsp.Response
-		return aliRsp, fmt.Errorf(`{"code":"%s","msg":"%s","sub_code":"%s","sub_msg":"%s"}`, info.Code, info.Msg, info.SubCode, info.SubMsg)
+		return aliRsp, bizError(info.Code, info.Msg, info.SubCode, info.SubMsg, bs)
 	}
-	signData, signDataErr := a.getSignData(bs, aliRsp.AlipayCertSn)
+	signData, signDataErr := a.getSignData(bs, encSignData, aliRsp.AlipayCertSn)
 	aliRsp.SignData = signData
 	return aliRsp, a.autoVerifySignByCert(aliRsp.Sign, signData, signDataErr)
 }
 
 // alipay.user.twostage.common.use(通用当面付二阶段接口)
 //	文档地址：https://opendocs.alipay.com/apis/api_2/alipay.user.twostage.common.use
-func (a *Client) UserTwostageCommonUse(bm gopay.BodyMap) (aliRsp *UserTwostageCommonUseRsp, err error) {
+func (a *Client) UserTwostageCommonUse(ctx context.Context, bm gopay.BodyMap) (aliRsp *UserTwostageCommonUseRsp, err error) {
 	err = bm.CheckEmptyError("dynamic_id", "sence_no", "pay_pid")
 	if err != nil {
 		return nil, err
 	}
-	var bs []byte
-	if bs, err = a.doAliPay(bm, "alipay.user.twostage.common.use"); err != nil {
+	var (
+		bs          []byte
+		encSignData string
+	)
+	if bs, encSignData, err = a.doAliPay(ctx, bm, "alipay.user.twostage.common.use"); err != nil {
 		return nil, err
 	}
 	aliRsp = new(UserTwostageCommonUseRsp)
@@ -226,22 +253,25 @@ func (a *Client) UserTwostageCommonUse(bm gopay.BodyMap) (aliRsp *UserTwostageCo
 	}
 	if aliRsp.Response != nil && aliRsp.Response.Code != "10000" {
 		info := aliRsp.Response
-		return aliRsp, fmt.Errorf(`{"code":"%s","msg":"%s","sub_code":"%s","sub_msg":"%s"}`, info.Code, info.Msg, info.SubCode, info.SubMsg)
+		return aliRsp, bizError(info.Code, info.Msg, info.SubCode, info.SubMsg, bs)
 	}
-	signData, signDataErr := a.getSignData(bs, aliRsp.AlipayCertSn)
+	signData, signDataErr := a.getSignData(bs, encSignData, aliRsp.AlipayCertSn)
 	aliRsp.SignData = signData
 	return aliRsp, a.autoVerifySignByCert(aliRsp.Sign, signData, signDataErr)
 }
 
 // alipay.user.auth.zhimaorg.identity.apply(芝麻企业征信基于身份的协议授权)
 //	文档地址：https://opendocs.alipay.com/apis/api_2/alipay.user.auth.zhimaorg.identity.apply
-func (a *Client) UserAuthZhimaorgIdentityApply(bm gopay.BodyMap) (aliRsp *UserAuthZhimaorgIdentityApplyRsp, err error) {
+func (a *Client) UserAuthZhimaorgIdentityApply(ctx context.Context, bm gopay.BodyMap) (aliRsp *UserAuthZhimaorgIdentityApplyRsp, err error) {
 	err = bm.CheckEmptyError("cert_type", "cert_no", "name")
 	if err != nil {
 		return nil, err
 	}
-	var bs []byte
-	if bs, err = a.doAliPay(bm, "alipay.user.auth.zhimaorg.identity.apply"); err != nil {
+	var (
+		bs          []byte
+		encSignData string
+	)
+	if bs, encSignData, err = a.doAliPay(ctx, bm, "alipay.user.auth.zhimaorg.identity.apply"); err != nil {
 		return nil, err
 	}
 	aliRsp = new(UserAuthZhimaorgIdentityApplyRsp)
@@ -250,22 +280,25 @@ func (a *Client) UserAuthZhimaorgIdentityApply(bm gopay.BodyMap) (aliRsp *UserAu
 	}
 	if aliRsp.Response != nil && aliRsp.Response.Code != "10000" {
 		info := aliRsp.Response
-		return aliRsp, fmt.Errorf(`{"code":"%s","msg":"%s","sub_code":"%s","sub_msg":"%s"}`, info.Code, info.Msg, info.SubCode, info.SubMsg)
+		return aliRsp, bizError(info.Code, info.Msg, info.SubCode, info.SubMsg, bs)
 	}
-	signData, signDataErr := a.getSignData(bs, aliRsp.AlipayCertSn)
+	signData, signDataErr := a.getSignData(bs, encSignData, aliRsp.AlipayCertSn)
 	aliRsp.SignData = signData
 	return aliRsp, a.autoVerifySignByCert(aliRsp.Sign, signData, signDataErr)
 }
 
 // alipay.user.charity.recordexist.query(查询是否在支付宝公益捐赠的接口)
 //	文档地址：https://opendocs.alipay.com/apis/api_2/alipay.user.charity.recordexist.query
-func (a *Client) UserCharityRecordexistQuery(bm gopay.BodyMap) (aliRsp *UserCharityRecordexistQueryRsp, err error) {
+func (a *Client) UserCharityRecordexistQuery(ctx context.Context, bm gopay.BodyMap) (aliRsp *UserCharityRecordexistQueryRsp, err error) {
 	err = bm.CheckEmptyError("partner_id", "user_id")
 	if err != nil {
 		return nil, err
 	}
-	var bs []byte
-	if bs, err = a.doAliPay(bm, "alipay.user.charity.recordexist.query"); err != nil {
+	var (
+		bs          []byte
+		encSignData string
+	)
+	if bs, encSignData, err = a.doAliPay(ctx, bm, "alipay.user.charity.recordexist.query"); err != nil {
 		return nil, err
 	}
 	aliRsp = new(UserCharityRecordexistQueryRsp)
@@ -274,22 +307,25 @@ func (a *Client) UserCharityRecordexistQuery(bm gopay.BodyMap) (aliRsp *UserChar
 	}
 	if aliRsp.Response != nil && aliRsp.Response.Code != "10000" {
 		info := aliRsp.Response
-		return aliRsp, fmt.Errorf(`{"code":"%s","msg":"%s","sub_code":"%s","sub_msg":"%s"}`, info.Code, info.Msg, info.SubCode, info.SubMsg)
+		return aliRsp, bizError(info.Code, info.Msg, info.SubCode, info.SubMsg, bs)
 	}
-	signData, signDataErr := a.getSignData(bs, aliRsp.AlipayCertSn)
+	signData, signDataErr := a.getSignData(bs, encSignData, aliRsp.AlipayCertSn)
 	aliRsp.SignData = signData
 	return aliRsp, a.autoVerifySignByCert(aliRsp.Sign, signData, signDataErr)
 }
 
 // alipay.user.alipaypoint.send(集分宝发放接口)
 //	文档地址：https://opendocs.alipay.com/apis/api_2/alipay.user.alipaypoint.send
-func (a *Client) UserAlipaypointSend(bm gopay.BodyMap) (aliRsp *UserAlipaypointSendRsp, err error) {
+func (a *Client) UserAlipaypointSend(ctx context.Context, bm gopay.BodyMap) (aliRsp *UserAlipaypointSendRsp, err error) {
 	err = bm.CheckEmptyError("budget_code", "partner_biz_no", "point_amount")
 	if err != nil {
 		return nil, err
 	}
-	var bs []byte
-	if bs, err = a.doAliPay(bm, "alipay.user.alipaypoint.send"); err != nil {
+	var (
+		bs          []byte
+		encSignData string
+	)
+	if bs, encSignData, err = a.doAliPay(ctx, bm, "alipay.user.alipaypoint.send"); err != nil {
 		return nil, err
 	}
 	aliRsp = new(UserAlipaypointSendRsp)
@@ -298,22 +334,25 @@ func (a *Client) UserAlipaypointSend(bm gopay.BodyMap) (aliRsp *UserAlipaypointS
 	}
 	if aliRsp.Response != nil && aliRsp.Response.Code != "10000" {
 		info := aliRsp.Response
-		return aliRsp, fmt.Errorf(`{"code":"%s","msg":"%s","sub_code":"%s","sub_msg":"%s"}`, info.Code, info.Msg, info.SubCode, info.SubMsg)
+		return aliRsp, bizError(info.Code, info.Msg, info.SubCode, info.SubMsg, bs)
 	}
-	signData, signDataErr := a.getSignData(bs, aliRsp.AlipayCertSn)
+	signData, signDataErr := a.getSignData(bs, encSignData, aliRsp.AlipayCertSn)
 	aliRsp.SignData = signData
 	return aliRsp, a.autoVerifySignByCert(aliRsp.Sign, signData, signDataErr)
 }
 
 // koubei.member.data.isv.create(isv 会员CRM数据回流)
 //	文档地址：https://opendocs.alipay.com/apis/api_2/koubei.member.data.isv.create
-func (a *Client) MemberDataIsvCreate(bm gopay.BodyMap) (aliRsp *MemberDataIsvCreateRsp, err error) {
+func (a *Client) MemberDataIsvCreate(ctx context.Context, bm gopay.BodyMap) (aliRsp *MemberDataIsvCreateRsp, err error) {
 	err = bm.CheckEmptyError("member_card_id", "member_source", "member_status", "gmt_merber_card_create", "parter_id")
 	if err != nil {
 		return nil, err
 	}
-	var bs []byte
-	if bs, err = a.doAliPay(bm, "koubei.member.data.isv.create"); err != nil {
+	var (
+		bs          []byte
+		encSignData string
+	)
+	if bs, encSignData, err = a.doAliPay(ctx, bm, "koubei.member.data.isv.create"); err != nil {
 		return nil, err
 	}
 	aliRsp = new(MemberDataIsvCreateRsp)
@@ -322,22 +361,25 @@ func (a *Client) MemberDataIsvCreate(bm gopay.BodyMap) (aliRsp *MemberDataIsvCre
 	}
 	if aliRsp.Response != nil && aliRsp.Response.Code != "10000" {
 		info := aliRsp.Response
-		return aliRsp, fmt.Errorf(`{"code":"%s","msg":"%s","sub_code":"%s","sub_msg":"%s"}`, info.Code, info.Msg, info.SubCode, info.SubMsg)
+		return aliRsp, bizError(info.Code, info.Msg, info.SubCode, info.SubMsg, bs)
 	}
-	signData, signDataErr := a.getSignData(bs, aliRsp.AlipayCertSn)
+	signData, signDataErr := a.getSignData(bs, encSignData, aliRsp.AlipayCertSn)
 	aliRsp.SignData = signData
 	return aliRsp, a.autoVerifySignByCert(aliRsp.Sign, signData, signDataErr)
 }
 
 // alipay.user.family.archive.query(查询家人信息档案(选人授权)组件已选的家人档案信息)
 //	文档地址：https://opendocs.alipay.com/apis/api_2/alipay.user.family.archive.query
-func (a *Client) UserFamilyArchiveQuery(bm gopay.BodyMap) (aliRsp *UserFamilyArchiveQueryRsp, err error) {
+func (a *Client) UserFamilyArchiveQuery(ctx context.Context, bm gopay.BodyMap) (aliRsp *UserFamilyArchiveQueryRsp, err error) {
 	err = bm.CheckEmptyError("archive_token")
 	if err != nil {
 		return nil, err
 	}
-	var bs []byte
-	if bs, err = a.doAliPay(bm, "alipay.user.family.archive.query"); err != nil {
+	var (
+		bs          []byte
+		encSignData string
+	)
+	if bs, encSignData, err = a.doAliPay(ctx, bm, "alipay.user.family.archive.query"); err != nil {
 		return nil, err
 	}
 	aliRsp = new(UserFamilyArchiveQueryRsp)
@@ -346,22 +388,25 @@ func (a *Client) UserFamilyArchiveQuery(bm gopay.BodyMap) (aliRsp *UserFamilyArc
 	}
 	if aliRsp.Response != nil && aliRsp.Response.Code != "10000" {
 		info := aliRsp.Response
-		return aliRsp, fmt.Errorf(`{"code":"%s","msg":"%s","sub_code":"%s","sub_msg":"%s"}`, info.Code, info.Msg, info.SubCode, info.SubMsg)
+		return aliRsp, bizError(info.Code, info.Msg, info.SubCode, info.SubMsg, bs)
 	}
-	signData, signDataErr := a.getSignData(bs, aliRsp.AlipayCertSn)
+	signData, signDataErr := a.getSignData(bs, encSignData, aliRsp.AlipayCertSn)
 	aliRsp.SignData = signData
 	return aliRsp, a.autoVerifySignByCert(aliRsp.Sign, signData, signDataErr)
 }
 
 // alipay.user.family.archive.initialize(初始化家人信息档案(选人授权)组件)
 //	文档地址：https://opendocs.alipay.com/apis/api_2/alipay.user.family.archive.initialize
-func (a *Client) UserFamilyArchiveInitialize(bm gopay.BodyMap) (aliRsp *UserFamilyArchiveInitializeRsp, err error) {
+func (a *Client) UserFamilyArchiveInitialize(ctx context.Context, bm gopay.BodyMap) (aliRsp *UserFamilyArchiveInitializeRsp, err error) {
 	err = bm.CheckEmptyError("out_biz_no", "template_id", "redirect_uri")
 	if err != nil {
 		return nil, err
 	}
-	var bs []byte
-	if bs, err = a.doAliPay(bm, "alipay.user.family.archive.initialize"); err != nil {
+	var (
+		bs          []byte
+		encSignData string
+	)
+	if bs, encSignData, err = a.doAliPay(ctx, bm, "alipay.user.family.archive.initialize"); err != nil {
 		return nil, err
 	}
 	aliRsp = new(UserFamilyArchiveInitializeRsp)
@@ -370,22 +415,25 @@ func (a *Client) UserFamilyArchiveInitialize(bm gopay.BodyMap) (aliRsp *UserFami
 	}
 	if aliRsp.Response != nil && aliRsp.Response.Code != "10000" {
 		info := aliRsp.Response
-		return aliRsp, fmt.Errorf(`{"code":"%s","msg":"%s","sub_code":"%s","sub_msg":"%s"}`, info.Code, info.Msg, info.SubCode, info.SubMsg)
+		return aliRsp, bizError(info.Code, info.Msg, info.SubCode, info.SubMsg, bs)
 	}
-	signData, signDataErr := a.getSignData(bs, aliRsp.AlipayCertSn)
+	signData, signDataErr := a.getSignData(bs, encSignData, aliRsp.AlipayCertSn)
 	aliRsp.SignData = signData
 	return aliRsp, a.autoVerifySignByCert(aliRsp.Sign, signData, signDataErr)
 }
 
 // alipay.user.certdoc.certverify.preconsult(实名证件信息比对验证预咨询)
 //	文档地址：https://opendocs.alipay.com/apis/api_2/alipay.user.certdoc.certverify.preconsult
-func (a *Client) UserCertdocCertverifyPreconsult(bm gopay.BodyMap) (aliRsp *UserCertdocCertverifyPreconsultRsp, err error) {
+func (a *Client) UserCertdocCertverifyPreconsult(ctx context.Context, bm gopay.BodyMap) (aliRsp *UserCertdocCertverifyPreconsultRsp, err error) {
 	err = bm.CheckEmptyError("user_name", "cert_type", "cert_no")
 	if err != nil {
 		return nil, err
 	}
-	var bs []byte
-	if bs, err = a.doAliPay(bm, "alipay.user.certdoc.certverify.preconsult"); err != nil {
+	var (
+		bs          []byte
+		encSignData string
+	)
+	if bs, encSignData, err = a.doAliPay(ctx, bm, "alipay.user.certdoc.certverify.preconsult"); err != nil {
 		return nil, err
 	}
 	aliRsp = new(UserCertdocCertverifyPreconsultRsp)
@@ -394,18 +442,21 @@ func (a *Client) UserCertdocCertverifyPreconsult(bm gopay.BodyMap) (aliRsp *User
 	}
 	if aliRsp.Response != nil && aliRsp.Response.Code != "10000" {
 		info := aliRsp.Response
-		return aliRsp, fmt.Errorf(`{"code":"%s","msg":"%s","sub_code":"%s","sub_msg":"%s"}`, info.Code, info.Msg, info.SubCode, info.SubMsg)
+		return aliRsp, bizError(info.Code, info.Msg, info.SubCode, info.SubMsg, bs)
 	}
-	signData, signDataErr := a.getSignData(bs, aliRsp.AlipayCertSn)
+	signData, signDataErr := a.getSignData(bs, encSignData, aliRsp.AlipayCertSn)
 	aliRsp.SignData = signData
 	return aliRsp, a.autoVerifySignByCert(aliRsp.Sign, signData, signDataErr)
 }
 
 // alipay.user.certdoc.certverify.consult(实名证件信息比对验证咨询)
 //	文档地址：https://opendocs.alipay.com/apis/api_2/alipay.user.certdoc.certverify.consult
-func (a *Client) UserCertdocCertverifyConsult(bm gopay.BodyMap) (aliRsp *UserCertdocCertverifyConsultRsp, err error) {
-	var bs []byte
-	if bs, err = a.doAliPay(bm, "alipay.user.certdoc.certverify.consult"); err != nil {
+func (a *Client) UserCertdocCertverifyConsult(ctx context.Context, bm gopay.BodyMap) (aliRsp *UserCertdocCertverifyConsultRsp, err error) {
+	var (
+		bs          []byte
+		encSignData string
+	)
+	if bs, encSignData, err = a.doAliPay(ctx, bm, "alipay.user.certdoc.certverify.consult"); err != nil {
 		return nil, err
 	}
 	aliRsp = new(UserCertdocCertverifyConsultRsp)
@@ -414,22 +465,25 @@ func (a *Client) UserCertdocCertverifyConsult(bm gopay.BodyMap) (aliRsp *UserCer
 	}
 	if aliRsp.Response != nil && aliRsp.Response.Code != "10000" {
 		info := aliRsp.Response
-		return aliRsp, fmt.Errorf(`{"code":"%s","msg":"%s","sub_code":"%s","sub_msg":"%s"}`, info.Code, info.Msg, info.SubCode, info.SubMsg)
+		return aliRsp, bizError(info.Code, info.Msg, info.SubCode, info.SubMsg, bs)
 	}
-	signData, signDataErr := a.getSignData(bs, aliRsp.AlipayCertSn)
+	signData, signDataErr := a.getSignData(bs, encSignData, aliRsp.AlipayCertSn)
 	aliRsp.SignData = signData
 	return aliRsp, a.autoVerifySignByCert(aliRsp.Sign, signData, signDataErr)
 }
 
 // alipay.user.family.share.zmgo.initialize(初始化家庭芝麻GO共享组件)
 //	文档地址：https://opendocs.alipay.com/apis/01n4yx
-func (a *Client) UserFamilyShareZmgoInitialize(bm gopay.BodyMap) (aliRsp *UserFamilyShareZmgoInitializeRsp, err error) {
+func (a *Client) UserFamilyShareZmgoInitialize(ctx context.Context, bm gopay.BodyMap) (aliRsp *UserFamilyShareZmgoInitializeRsp, err error) {
 	err = bm.CheckEmptyError("user_id", "scene_id", "template_id", "out_request_no")
 	if err != nil {
 		return nil, err
 	}
-	var bs []byte
-	if bs, err = a.doAliPay(bm, "alipay.user.family.share.zmgo.initialize"); err != nil {
+	var (
+		bs          []byte
+		encSignData string
+	)
+	if bs, encSignData, err = a.doAliPay(ctx, bm, "alipay.user.family.share.zmgo.initialize"); err != nil {
 		return nil, err
 	}
 	aliRsp = new(UserFamilyShareZmgoInitializeRsp)
@@ -438,22 +492,25 @@ func (a *Client) UserFamilyShareZmgoInitialize(bm gopay.BodyMap) (aliRsp *UserFa
 	}
 	if aliRsp.Response != nil && aliRsp.Response.Code != "10000" {
 		info := aliRsp.Response
-		return aliRsp, fmt.Errorf(`{"code":"%s","msg":"%s","sub_code":"%s","sub_msg":"%s"}`, info.Code, info.Msg, info.SubCode, info.SubMsg)
+		return aliRsp, bizError(info.Code, info.Msg, info.SubCode, info.SubMsg, bs)
 	}
-	signData, signDataErr := a.getSignData(bs, aliRsp.AlipayCertSn)
+	signData, signDataErr := a.getSignData(bs, encSignData, aliRsp.AlipayCertSn)
 	aliRsp.SignData = signData
 	return aliRsp, a.autoVerifySignByCert(aliRsp.Sign, signData, signDataErr)
 }
 
 // alipay.user.dtbank.qrcodedata.query(数字分行银行码明细数据查询)
 //	文档地址：https://opendocs.alipay.com/apis/01ozks
-func (a *Client) UserDtbankQrcodedataQuery(bm gopay.BodyMap) (aliRsp *UserDtbankQrcodedataQueryRsp, err error) {
+func (a *Client) UserDtbankQrcodedataQuery(ctx context.Context, bm gopay.BodyMap) (aliRsp *UserDtbankQrcodedataQueryRsp, err error) {
 	err = bm.CheckEmptyError("data_date", "qrcode_id", "qrcode_out_id")
 	if err != nil {
 		return nil, err
 	}
-	var bs []byte
-	if bs, err = a.doAliPay(bm, "alipay.user.dtbank.qrcodedata.query"); err != nil {
+	var (
+		bs          []byte
+		encSignData string
+	)
+	if bs, encSignData, err = a.doAliPay(ctx, bm, "alipay.user.dtbank.qrcodedata.query"); err != nil {
 		return nil, err
 	}
 	aliRsp = new(UserDtbankQrcodedataQueryRsp)
@@ -462,22 +519,25 @@ func (a *Client) UserDtbankQrcodedataQuery(bm gopay.BodyMap) (aliRsp *UserDtbank
 	}
 	if aliRsp.Response != nil && aliRsp.Response.Code != "10000" {
 		info := aliRsp.Response
-		return aliRsp, fmt.Errorf(`{"code":"%s","msg":"%s","sub_code":"%s","sub_msg":"%s"}`, info.Code, info.Msg, info.SubCode, info.SubMsg)
+		return aliRsp, bizError(info.Code, info.Msg, info.SubCode, info.SubMsg, bs)
 	}
-	signData, signDataErr := a.getSignData(bs, aliRsp.AlipayCertSn)
+	signData, signDataErr := a.getSignData(bs, encSignData, aliRsp.AlipayCertSn)
 	aliRsp.SignData = signData
 	return aliRsp, a.autoVerifySignByCert(aliRsp.Sign, signData, signDataErr)
 }
 
 // alipay.user.alipaypoint.budgetlib.query(查询集分宝预算库详情)
 //	文档地址：https://opendocs.alipay.com/apis/01zrby
-func (a *Client) UserAlipaypointBudgetlibQuery(bm gopay.BodyMap) (aliRsp *UserAlipaypointBudgetlibQueryRsp, err error) {
+func (a *Client) UserAlipaypointBudgetlibQuery(ctx context.Context, bm gopay.BodyMap) (aliRsp *UserAlipaypointBudgetlibQueryRsp, err error) {
 	err = bm.CheckEmptyError("budget_code")
 	if err != nil {
 		return nil, err
 	}
-	var bs []byte
-	if bs, err = a.doAliPay(bm, "alipay.user.alipaypoint.budgetlib.query"); err != nil {
+	var (
+		bs          []byte
+		encSignData string
+	)
+	if bs, encSignData, err = a.doAliPay(ctx, bm, "alipay.user.alipaypoint.budgetlib.query"); err != nil {
 		return nil, err
 	}
 	aliRsp = new(UserAlipaypointBudgetlibQueryRsp)
@@ -486,9 +546,9 @@ func (a *Client) UserAlipaypointBudgetlibQuery(bm gopay.BodyMap) (aliRsp *UserAl
 	}
 	if aliRsp.Response != nil && aliRsp.Response.Code != "10000" {
 		info := aliRsp.Response
-		return aliRsp, fmt.Errorf(`{"code":"%s","msg":"%s","sub_code":"%s","sub_msg":"%s"}`, info.Code, info.Msg, info.SubCode, info.SubMsg)
+		return aliRsp, bizError(info.Code, info.Msg, info.SubCode, info.SubMsg, bs)
 	}
-	signData, signDataErr := a.getSignData(bs, aliRsp.AlipayCertSn)
+	signData, signDataErr := a.getSignData(bs, encSignData, aliRsp.AlipayCertSn)
 	aliRsp.SignData = signData
 	return aliRsp, a.autoVerifySignByCert(aliRsp.Sign, signData, signDataErr)
 }
@@ -6,6 +6,8 @@ const (
 	sandboxBaseUrl     = "https://openapi.alipaydev.com/gateway.do"
 	baseUrlUtf8        = "https://openapi.alipay.com/gateway.do?charset=utf-8"
 	sandboxBaseUrlUtf8 = "https://openapi.alipaydev.com/gateway.do?charset=utf-8"
+	v3BaseUrl          = "https://openapi.alipay.com"
+	v3SandboxBaseUrl   = "https://openapi-sandbox.dl.alipaydev.com"
 
 	LocationShanghai          = "Asia/Shanghai"
 	PKCS1            PKCSType = 1 // 非Java
@@ -15,8 +17,150 @@ const (
 	UTF8                      = "utf-8"
 )
 
+const (
+	// 异步通知类型 notify_type
+	NotifyTypeTradeStatusSync  = "trade_status_sync"  // 交易状态同步（支付、退款）
+	NotifyTypeFundAuthFreeze   = "fund_auth_freeze"   // 资金授权冻结
+	NotifyTypeFundAuthUnfreeze = "fund_auth_unfreeze" // 资金授权解冻
+	NotifyTypeDutUserSign      = "dut_user_sign"      // 商户代扣签约
+	NotifyTypeDutUserUnsign    = "dut_user_unsign"    // 商户代扣解约
+)
+
+const (
+	// OpenAPI v3
+	AuthorizationV3      = "ALIPAY-SHA256withRSA"
+	HeaderV3RequestId    = "alipay-request-id"
+	HeaderV3AppAuthToken = "alipay-app-auth-token"
+	HeaderV3RootCertSn   = "alipay-root-cert-sn"
+	HeaderV3Timestamp    = "alipay-timestamp"
+	HeaderV3Nonce        = "alipay-nonce"
+	HeaderV3Signature    = "alipay-signature"
+	HeaderV3Sn           = "alipay-sn"
+	HeaderV3TraceId      = "alipay-trace-id"
+)
+
 type PKCSType uint8
 
+// 异步通知公共参数
+type NotifyBase struct {
+	NotifyTime string `json:"notify_time,omitempty"`
+	NotifyType string `json:"notify_type,omitempty"`
+	NotifyId   string `json:"notify_id,omitempty"`
+	AppId      string `json:"app_id,omitempty"`
+	Charset    string `json:"charset,omitempty"`
+	Version    string `json:"version,omitempty"`
+	SignType   string `json:"sign_type,omitempty"`
+	Sign       string `json:"sign,omitempty"`
+	AuthAppId  string `json:"auth_app_id,omitempty"`
+}
+
+// 交易支付异步通知
+//	文档：https://opendocs.alipay.com/open/203/105286
+type TradeNotify struct {
+	NotifyBase
+	TradeNo           string            `json:"trade_no,omitempty"`
+	OutTradeNo        string            `json:"out_trade_no,omitempty"`
+	OutBizNo          string            `json:"out_biz_no,omitempty"`
+	BuyerId           string            `json:"buyer_id,omitempty"`
+	BuyerLogonId      string            `json:"buyer_logon_id,omitempty"`
+	SellerId          string            `json:"seller_id,omitempty"`
+	SellerEmail       string            `json:"seller_email,omitempty"`
+	TradeStatus       string            `json:"trade_status,omitempty"`
+	TotalAmount       string            `json:"total_amount,omitempty"`
+	ReceiptAmount     string            `json:"receipt_amount,omitempty"`
+	InvoiceAmount     string            `json:"invoice_amount,omitempty"`
+	BuyerPayAmount    string            `json:"buyer_pay_amount,omitempty"`
+	PointAmount       string            `json:"point_amount,omitempty"`
+	RefundFee         string            `json:"refund_fee,omitempty"`
+	Subject           string            `json:"subject,omitempty"`
+	Body              string            `json:"body,omitempty"`
+	GmtCreate         string            `json:"gmt_create,omitempty"`
+	GmtPayment        string            `json:"gmt_payment,omitempty"`
+	GmtRefund         string            `json:"gmt_refund,omitempty"`
+	GmtClose          string            `json:"gmt_close,omitempty"`
+	FundBillList      []*NotifyFundBill `json:"-"`
+	PassbackParams    string            `json:"passback_params,omitempty"`
+	VoucherDetailList []*VoucherDetail  `json:"-"`
+}
+
+// 交易退款异步通知（notify_type 为 trade_status_sync，且包含 refund_fee、gmt_refund）
+//	文档：https://opendocs.alipay.com/open/203/105286
+type RefundNotify struct {
+	NotifyBase
+	TradeNo        string            `json:"trade_no,omitempty"`
+	OutTradeNo     string            `json:"out_trade_no,omitempty"`
+	OutBizNo       string            `json:"out_biz_no,omitempty"` // 退款请求号
+	BuyerId        string            `json:"buyer_id,omitempty"`
+	BuyerLogonId   string            `json:"buyer_logon_id,omitempty"`
+	SellerId       string            `json:"seller_id,omitempty"`
+	SellerEmail    string            `json:"seller_email,omitempty"`
+	TradeStatus    string            `json:"trade_status,omitempty"`
+	TotalAmount    string            `json:"total_amount,omitempty"`
+	RefundFee      string            `json:"refund_fee,omitempty"` // 总退款金额
+	Subject        string            `json:"subject,omitempty"`
+	GmtCreate      string            `json:"gmt_create,omitempty"`
+	GmtPayment     string            `json:"gmt_payment,omitempty"`
+	GmtRefund      string            `json:"gmt_refund,omitempty"`
+	GmtClose       string            `json:"gmt_close,omitempty"`
+	FundBillList   []*NotifyFundBill `json:"-"`
+	PassbackParams string            `json:"passback_params,omitempty"`
+}
+
+type NotifyFundBill struct {
+	Amount      string `json:"amount,omitempty"`
+	FundChannel string `json:"fundChannel,omitempty"` // 异步通知里是 fundChannel
+}
+
+// 资金授权操作异步通知（冻结、解冻）
+//	文档：https://opendocs.alipay.com/open/20190308105425129272/notify
+type FundAuthNotify struct {
+	NotifyBase
+	AuthNo              string `json:"auth_no,omitempty"`
+	OutOrderNo          string `json:"out_order_no,omitempty"`
+	OperationId         string `json:"operation_id,omitempty"`
+	OutRequestNo        string `json:"out_request_no,omitempty"`
+	OperationType       string `json:"operation_type,omitempty"`
+	Amount              string `json:"amount,omitempty"`
+	Status              string `json:"status,omitempty"`
+	GmtCreate           string `json:"gmt_create,omitempty"`
+	GmtTrans            string `json:"gmt_trans,omitempty"`
+	PayerLogonId        string `json:"payer_logon_id,omitempty"`
+	PayerUserId         string `json:"payer_user_id,omitempty"`
+	PayeeLogonId        string `json:"payee_logon_id,omitempty"`
+	PayeeUserId         string `json:"payee_user_id,omitempty"`
+	TotalFreezeAmount   string `json:"total_freeze_amount,omitempty"`
+	TotalUnfreezeAmount string `json:"total_unfreeze_amount,omitempty"`
+	TotalPayAmount      string `json:"total_pay_amount,omitempty"`
+	RestAmount          string `json:"rest_amount,omitempty"`
+	CreditAmount        string `json:"credit_amount,omitempty"`
+	FundAmount          string `json:"fund_amount,omitempty"`
+	PreAuthType         string `json:"pre_auth_type,omitempty"`
+	TransCurrency       string `json:"trans_currency,omitempty"`
+}
+
+// 商户代扣签约、解约异步通知
+//	文档：https://opendocs.alipay.com/open/20190319114403226822/notify
+type AgreementNotify struct {
+	NotifyBase
+	AgreementNo         string `json:"agreement_no,omitempty"`
+	ExternalAgreementNo string `json:"external_agreement_no,omitempty"`
+	PersonalProductCode string `json:"personal_product_code,omitempty"`
+	SignScene           string `json:"sign_scene,omitempty"`
+	Status              string `json:"status,omitempty"`
+	AlipayUserId        string `json:"alipay_user_id,omitempty"`
+	AlipayLogonId       string `json:"alipay_logon_id,omitempty"`
+	ExternalLogonId     string `json:"external_logon_id,omitempty"`
+	SignTime            string `json:"sign_time,omitempty"`
+	UnsignTime          string `json:"unsign_time,omitempty"`
+	ValidTime           string `json:"valid_time,omitempty"`
+	InvalidTime         string `json:"invalid_time,omitempty"`
+	PartnerId           string `json:"partner_id,omitempty"`
+	MerchantAppId       string `json:"merchant_app_id,omitempty"`
+	ZmOpenId            string `json:"zm_open_id,omitempty"`
+	CreditAuthMode      string `json:"credit_auth_mode,omitempty"`
+	SingleQuota         string `json:"single_quota,omitempty"`
+}
+
 // Deprecated
 type NotifyRequest struct {
 	NotifyTime        string              `json:"notify_time,omitempty"`
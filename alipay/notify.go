@@ -44,6 +44,13 @@ func ParseNotifyByURLValues(value url.Values) (bm gopay.BodyMap, err error) {
 	return
 }
 
+// 获取异步通知去重使用的 key，配合 gopay.NotifyDeduper 使用
+//	bm：ParseNotifyToBodyMap() 解析的异步通知参数
+//	返回格式：alipay:notify:{notify_id}，notify_id 为空时返回空字符串，不做去重
+func NotifyDedupeKey(bm gopay.BodyMap) (key string) {
+	return gopay.NotifyDedupeKey(gopay.ProviderAlipay, "notify", bm.GetString("notify_id"))
+}
+
 // Deprecated
 // 解析支付宝支付异步通知的参数到Struct
 //	req：*http.Request
@@ -0,0 +1,212 @@
+package alipay
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/yuanqinguo/gopay"
+	"github.com/yuanqinguo/gopay/pkg/util"
+	"github.com/yuanqinguo/gopay/pkg/xlog"
+)
+
+const (
+	notifySuccess = "success"
+	notifyFail    = "fail"
+)
+
+// NotifyHandler 支付宝异步通知 http.Handler
+//	依次完成：解析表单参数、验签、解析为对应类型的通知结构体、调用回调方法、返回 success 或 fail
+//	回调方法返回 nil 时，返回 success；返回 err、验签失败、或未设置对应回调方法时，返回 fail，支付宝会重新通知
+//	文档：https://opendocs.alipay.com/open/203/105286
+type NotifyHandler struct {
+	alipayPublicKey     string
+	alipayPublicKeyCert interface{}
+	onTrade             func(ctx context.Context, notify *TradeNotify) error
+	onRefund            func(ctx context.Context, notify *RefundNotify) error
+	onFundAuth          func(ctx context.Context, notify *FundAuthNotify) error
+	onAgreement         func(ctx context.Context, notify *AgreementNotify) error
+	onOther             func(ctx context.Context, bm gopay.BodyMap) error
+	onError             func(req *http.Request, err error)
+	deduper             *gopay.NotifyDeduper
+}
+
+// NewNotifyHandler 初始化支付宝异步通知 http.Handler（普通公钥模式验签）
+//	alipayPublicKey：支付宝平台获取的支付宝公钥
+func NewNotifyHandler(alipayPublicKey string) (handler *NotifyHandler) {
+	return &NotifyHandler{alipayPublicKey: alipayPublicKey}
+}
+
+// NewNotifyHandlerWithCert 初始化支付宝异步通知 http.Handler（公钥证书模式验签）
+//	aliPayPublicKeyCert：支付宝公钥证书存放路径 alipayCertPublicKey_RSA2.crt 或文件内容[]byte
+func NewNotifyHandlerWithCert(aliPayPublicKeyCert interface{}) (handler *NotifyHandler) {
+	return &NotifyHandler{alipayPublicKeyCert: aliPayPublicKeyCert}
+}
+
+// OnTrade 设置交易支付通知回调（notify_type 为 trade_status_sync，且非退款通知）
+func (h *NotifyHandler) OnTrade(fn func(ctx context.Context, notify *TradeNotify) error) (handler *NotifyHandler) {
+	h.onTrade = fn
+	return h
+}
+
+// OnRefund 设置交易退款通知回调（notify_type 为 trade_status_sync，且包含 refund_fee、gmt_refund）
+//	未设置时，退款通知使用 OnTrade 回调
+func (h *NotifyHandler) OnRefund(fn func(ctx context.Context, notify *RefundNotify) error) (handler *NotifyHandler) {
+	h.onRefund = fn
+	return h
+}
+
+// OnFundAuth 设置资金授权操作通知回调（notify_type 为 fund_auth_freeze、fund_auth_unfreeze）
+func (h *NotifyHandler) OnFundAuth(fn func(ctx context.Context, notify *FundAuthNotify) error) (handler *NotifyHandler) {
+	h.onFundAuth = fn
+	return h
+}
+
+// OnAgreement 设置商户代扣签约、解约通知回调（notify_type 为 dut_user_sign、dut_user_unsign）
+func (h *NotifyHandler) OnAgreement(fn func(ctx context.Context, notify *AgreementNotify) error) (handler *NotifyHandler) {
+	h.onAgreement = fn
+	return h
+}
+
+// OnOther 设置其他类型通知的回调，bm 为验签通过的通知参数
+func (h *NotifyHandler) OnOther(fn func(ctx context.Context, bm gopay.BodyMap) error) (handler *NotifyHandler) {
+	h.onOther = fn
+	return h
+}
+
+// SetDeduper 设置异步通知去重，按 notify_id 去重，重复的通知不再调用回调方法
+//	已处理完成的重复通知直接返回 success；首次通知仍在处理中时返回 fail，由支付宝稍后重新通知
+//	deduper：gopay.NewNotifyDeduper(nil, 0) 使用内存 LRU 存储，多实例部署时请自行实现 gopay.NotifyStore
+func (h *NotifyHandler) SetDeduper(deduper *gopay.NotifyDeduper) (handler *NotifyHandler) {
+	h.deduper = deduper
+	return h
+}
+
+// OnError 设置处理失败时的回调，可用于记录日志，不设置时使用 xlog 输出错误日志
+func (h *NotifyHandler) OnError(fn func(req *http.Request, err error)) (handler *NotifyHandler) {
+	h.onError = fn
+	return h
+}
+
+func (h *NotifyHandler) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	if err := h.handle(req); err != nil {
+		if h.onError != nil {
+			h.onError(req, err)
+		} else {
+			xlog.Errorf("alipay notify handle error: %+v", err)
+		}
+		w.Write([]byte(notifyFail))
+		return
+	}
+	w.Write([]byte(notifySuccess))
+}
+
+func (h *NotifyHandler) handle(req *http.Request) (err error) {
+	bm, err := ParseNotifyToBodyMap(req)
+	if err != nil {
+		return fmt.Errorf("ParseNotifyToBodyMap：%w", err)
+	}
+	if err = h.verifySign(bm); err != nil {
+		return err
+	}
+	if h.deduper == nil {
+		return h.dispatch(req.Context(), bm)
+	}
+	err = h.deduper.Do(NotifyDedupeKey(bm), func() error {
+		return h.dispatch(req.Context(), bm)
+	})
+	// 处理中的重复通知（gopay.ErrNotifyProcessing）返回 fail
+	if errors.Is(err, gopay.ErrNotifyDuplicate) {
+		return nil
+	}
+	return err
+}
+
+func (h *NotifyHandler) dispatch(ctx context.Context, bm gopay.BodyMap) (err error) {
+	notifyType := bm.GetString("notify_type")
+	switch {
+	case notifyType == NotifyTypeTradeStatusSync && bm.GetString("refund_fee") != util.NULL && bm.GetString("gmt_refund") != util.NULL && h.onRefund != nil:
+		notify := new(RefundNotify)
+		if err = decodeNotify(bm, notify); err != nil {
+			return err
+		}
+		if notify.FundBillList, err = decodeNotifyFundBill(bm); err != nil {
+			return err
+		}
+		return h.onRefund(ctx, notify)
+	case notifyType == NotifyTypeTradeStatusSync && h.onTrade != nil:
+		notify := new(TradeNotify)
+		if err = decodeNotify(bm, notify); err != nil {
+			return err
+		}
+		if notify.FundBillList, err = decodeNotifyFundBill(bm); err != nil {
+			return err
+		}
+		if detailList := bm.GetString("voucher_detail_list"); detailList != util.NULL {
+			if err = json.Unmarshal([]byte(detailList), &notify.VoucherDetailList); err != nil {
+				return fmt.Errorf(`"voucher_detail_list" json.Unmarshal(%s)：%w`, detailList, err)
+			}
+		}
+		return h.onTrade(ctx, notify)
+	case strings.HasPrefix(notifyType, "fund_auth") && h.onFundAuth != nil:
+		notify := new(FundAuthNotify)
+		if err = decodeNotify(bm, notify); err != nil {
+			return err
+		}
+		return h.onFundAuth(ctx, notify)
+	case (notifyType == NotifyTypeDutUserSign || notifyType == NotifyTypeDutUserUnsign) && h.onAgreement != nil:
+		notify := new(AgreementNotify)
+		if err = decodeNotify(bm, notify); err != nil {
+			return err
+		}
+		return h.onAgreement(ctx, notify)
+	case h.onOther != nil:
+		return h.onOther(ctx, bm)
+	}
+	return fmt.Errorf("notify_type [%s] has no callback", notifyType)
+}
+
+// verifySign 验签会移除 bm 中的 sign 和 sign_type，此处使用副本验签
+func (h *NotifyHandler) verifySign(bm gopay.BodyMap) (err error) {
+	signBm := make(gopay.BodyMap, len(bm))
+	for k, v := range bm {
+		signBm[k] = v
+	}
+	switch {
+	case h.alipayPublicKeyCert != nil:
+		_, err = VerifySignWithCert(h.alipayPublicKeyCert, signBm)
+	case h.alipayPublicKey != util.NULL:
+		_, err = VerifySign(h.alipayPublicKey, signBm)
+	default:
+		return errors.New("alipayPublicKey and alipayPublicKeyCert are both empty")
+	}
+	if err != nil {
+		return fmt.Errorf("verify sign failed: %w", err)
+	}
+	return nil
+}
+
+func decodeNotify(bm gopay.BodyMap, notify interface{}) (err error) {
+	bs, err := json.Marshal(bm)
+	if err != nil {
+		return fmt.Errorf("json.Marshal：%w", err)
+	}
+	if err = json.Unmarshal(bs, notify); err != nil {
+		return fmt.Errorf("json.Unmarshal(%s)：%w", string(bs), err)
+	}
+	return nil
+}
+
+func decodeNotifyFundBill(bm gopay.BodyMap) (bills []*NotifyFundBill, err error) {
+	billList := bm.GetString("fund_bill_list")
+	if billList == util.NULL {
+		return nil, nil
+	}
+	if err = json.Unmarshal([]byte(billList), &bills); err != nil {
+		return nil, fmt.Errorf(`"fund_bill_list" json.Unmarshal(%s)：%w`, billList, err)
+	}
+	return bills, nil
+}
@@ -0,0 +1,151 @@
+package alipay
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/yuanqinguo/gopay"
+)
+
+func newTestNotifyRequest(t *testing.T, priKey *rsa.PrivateKey, bm gopay.BodyMap) *http.Request {
+	sign, err := GetRsaSign(bm, RSA2, priKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	form := make(url.Values)
+	for k := range bm {
+		form.Set(k, bm.GetString(k))
+	}
+	form.Set("sign", sign)
+	form.Set("sign_type", RSA2)
+	req := httptest.NewRequest(http.MethodPost, "/notify", strings.NewReader(form.Encode()))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	return req
+}
+
+func TestNotifyHandler(t *testing.T) {
+	priKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	pubKeyBytes, err := x509.MarshalPKIXPublicKey(&priKey.PublicKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var (
+		trade  *TradeNotify
+		refund *RefundNotify
+	)
+	handler := NewNotifyHandler(base64.StdEncoding.EncodeToString(pubKeyBytes)).
+		OnTrade(func(ctx context.Context, notify *TradeNotify) error {
+			trade = notify
+			return nil
+		}).
+		OnRefund(func(ctx context.Context, notify *RefundNotify) error {
+			refund = notify
+			return nil
+		}).
+		OnError(func(req *http.Request, err error) {})
+
+	bm := make(gopay.BodyMap)
+	bm.Set("notify_type", NotifyTypeTradeStatusSync).
+		Set("notify_id", "2020010200222161821001551453140885").
+		Set("app_id", "2015102700040153").
+		Set("out_trade_no", "1086209247658383466").
+		Set("trade_status", "TRADE_SUCCESS").
+		Set("total_amount", "0.02").
+		Set("fund_bill_list", `[{"amount":"0.02","fundChannel":"PCREDIT"}]`)
+
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, newTestNotifyRequest(t, priKey, bm))
+	if w.Body.String() != "success" {
+		t.Fatalf("expected success, got: %s", w.Body.String())
+	}
+	if trade == nil || trade.OutTradeNo != "1086209247658383466" || trade.NotifyId == "" || len(trade.FundBillList) != 1 || trade.FundBillList[0].FundChannel != "PCREDIT" {
+		t.Fatalf("unexpected trade notify: %+v", trade)
+	}
+
+	bm.Set("refund_fee", "0.01").Set("gmt_refund", "2020-01-03 10:00:00.123").Set("out_biz_no", "R1")
+	w = httptest.NewRecorder()
+	handler.ServeHTTP(w, newTestNotifyRequest(t, priKey, bm))
+	if w.Body.String() != "success" || refund == nil || refund.RefundFee != "0.01" || refund.OutBizNo != "R1" {
+		t.Fatalf("unexpected refund notify: %s, %+v", w.Body.String(), refund)
+	}
+
+	// 参数被篡改，验签失败
+	req := newTestNotifyRequest(t, priKey, bm)
+	req.Body = http.NoBody
+	req.URL.RawQuery = "notify_type=trade_status_sync&total_amount=100&sign=xxx&sign_type=RSA2"
+	w = httptest.NewRecorder()
+	handler.ServeHTTP(w, req)
+	if w.Body.String() != "fail" {
+		t.Fatalf("expected fail, got: %s", w.Body.String())
+	}
+
+	// 未设置回调的通知类型
+	bm = make(gopay.BodyMap)
+	bm.Set("notify_type", NotifyTypeDutUserSign).Set("agreement_no", "20185909000458725113")
+	w = httptest.NewRecorder()
+	handler.ServeHTTP(w, newTestNotifyRequest(t, priKey, bm))
+	if w.Body.String() != "fail" {
+		t.Fatalf("expected fail, got: %s", w.Body.String())
+	}
+}
+
+func TestNotifyHandlerDedupe(t *testing.T) {
+	priKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	pubKeyBytes, err := x509.MarshalPKIXPublicKey(&priKey.PublicKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var (
+		count   int
+		handler *NotifyHandler
+		bm      = make(gopay.BodyMap)
+	)
+	bm.Set("notify_type", NotifyTypeTradeStatusSync).
+		Set("notify_id", "2020010200222161821001551453140885").
+		Set("out_trade_no", "1086209247658383466")
+	handler = NewNotifyHandler(base64.StdEncoding.EncodeToString(pubKeyBytes)).
+		SetDeduper(gopay.NewNotifyDeduper(nil, 0)).
+		OnTrade(func(ctx context.Context, notify *TradeNotify) error {
+			count++
+			// 首次通知处理中，重复通知返回 fail
+			w := httptest.NewRecorder()
+			handler.ServeHTTP(w, newTestNotifyRequest(t, priKey, bm))
+			if w.Body.String() != "fail" {
+				t.Fatalf("expected fail, got: %s", w.Body.String())
+			}
+			return nil
+		})
+
+	for i := 0; i < 2; i++ {
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, newTestNotifyRequest(t, priKey, bm))
+		if w.Body.String() != "success" {
+			t.Fatalf("expected success, got: %s", w.Body.String())
+		}
+	}
+	if count != 1 {
+		t.Fatalf("expected callback called once, got: %d", count)
+	}
+	if key := NotifyDedupeKey(bm); key != "alipay:notify:2020010200222161821001551453140885" {
+		t.Fatalf("unexpected dedupe key: %s", key)
+	}
+	// notify_id 为空时不去重
+	bm.Remove("notify_id")
+	if key := NotifyDedupeKey(bm); key != "" {
+		t.Fatalf("expected empty dedupe key, got: %s", key)
+	}
+}
@@ -3,6 +3,7 @@ package alipay
 import (
 	"fmt"
 	"log"
+	"net/http"
 	"time"
 
 	"github.com/yuanqinguo/gopay/pkg/util"
@@ -147,6 +148,13 @@ func (a *Client) SetSignType(signType string) (client *Client) {
 	return a
 }
 
+// 设置自定义的 http.Client，client 的所有请求均通过此 http.Client 发出
+//	可用于代理、连接复用、链路追踪、单元测试替身等场景，不设置则每次请求使用默认配置
+func (a *Client) SetHttpClient(httpClient *http.Client) (client *Client) {
+	a.hc = httpClient
+	return a
+}
+
 // 设置应用授权
 func (a *Client) SetAppAuthToken(appAuthToken string) (client *Client) {
 	a.AppAuthToken = appAuthToken
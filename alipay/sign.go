@@ -175,37 +175,69 @@ func GetRsaSign(bm gopay.BodyMap, signType string, privateKey *rsa.PrivateKey) (
 // =============================== 获取SignData ===============================
 
 // 需注意的是，公钥签名模式和公钥证书签名模式的不同之处
+//	encSignData：接口内容加密时，验签内容为响应中的密文（含引号），即 decryptResponse() 返回的 signData
 //	验签文档：https://opendocs.alipay.com/open/200/106120
-func (a *Client) getSignData(bs []byte, alipayCertSN string) (signData string, err error) {
+func (a *Client) getSignData(bs []byte, encSignData, alipayCertSN string) (signData string, err error) {
+	if encSignData != util.NULL {
+		if alipayCertSN != "" && alipayCertSN != a.AliPayPublicCertSN {
+			return encSignData, errors.New("当前使用的支付宝公钥证书SN与网关响应报文中的SN不匹配")
+		}
+		return encSignData, nil
+	}
 	var (
 		str        = string(bs)
 		indexStart = strings.Index(str, `_response":`)
 		indexEnd   int
 	)
+	if indexStart == -1 {
+		return util.NULL, errors.New("response format error, xxx_response not found")
+	}
+	indexStart += 11
 	if alipayCertSN != "" {
 		// 公钥证书模式
-		indexEnd = strings.Index(str, `,"alipay_cert_sn":`)
-		signData = str[indexStart+11 : indexEnd]
+		if indexEnd = strings.Index(str[indexStart:], `,"alipay_cert_sn":`); indexEnd == -1 {
+			return util.NULL, errors.New("response format error, alipay_cert_sn not found")
+		}
+		signData = str[indexStart : indexStart+indexEnd]
 		if alipayCertSN != a.AliPayPublicCertSN {
 			return signData, errors.New("当前使用的支付宝公钥证书SN与网关响应报文中的SN不匹配")
 		}
 		return
 	}
 	// 普通公钥模式
-	indexEnd = strings.Index(str, `,"sign":`)
-	signData = str[indexStart+11 : indexEnd]
+	if indexEnd = strings.Index(str[indexStart:], `,"sign":`); indexEnd == -1 {
+		return util.NULL, errors.New("response format error, sign not found")
+	}
+	signData = str[indexStart : indexStart+indexEnd]
 	return
 }
 
 // =============================== 同步验签 ===============================
 
+// VerifySignError 验签失败，包括签名不匹配、支付宝公钥证书SN不匹配、缺少签名
+//	client 开启自动验签后，接口方法及 client.DoAliPayAPISelfV3() 验签失败时返回此错误，同步、异步验签方法验签失败时同样返回此错误
+//	可通过 errors.As(err, &verifyErr) 与 *gopay.Error（http 状态码错误、业务错误）区分，此时接口响应可能被篡改，不应使用
+type VerifySignError struct {
+	SignData string // 待验签内容
+	Sign     string // 签名
+	Err      error  // 验签失败原因
+}
+
+func (e *VerifySignError) Error() string {
+	return fmt.Sprintf("alipay verify sign failed: %v", e.Err)
+}
+
+func (e *VerifySignError) Unwrap() error {
+	return e.Err
+}
+
 // VerifySyncSign 支付宝同步返回验签（公钥模式）
 //	注意：APP支付，手机网站支付，电脑网站支付，身份认证开始认证 不支持同步返回验签
 //	aliPayPublicKey：支付宝平台获取的支付宝公钥
 //	signData：待验签参数，aliRsp.SignData
 //	sign：待验签sign，aliRsp.Sign
 //	返回参数ok：是否验签通过
-//	返回参数err：错误信息
+//	返回参数err：错误信息，验签失败时为 *VerifySignError
 //	验签文档：https://opendocs.alipay.com/open/200/106120
 func VerifySyncSign(aliPayPublicKey, signData, sign string) (ok bool, err error) {
 	// 支付宝公钥验签
@@ -222,7 +254,7 @@ func VerifySyncSign(aliPayPublicKey, signData, sign string) (ok bool, err error)
 //	signData：待验签参数，aliRsp.SignData
 //	sign：待验签sign，aliRsp.Sign
 //	返回参数ok：是否验签通过
-//	返回参数err：错误信息
+//	返回参数err：错误信息，验签失败时为 *VerifySignError
 //	验签文档：https://opendocs.alipay.com/open/200/106120
 func VerifySyncSignWithCert(alipayPublicKeyCert interface{}, signData, sign string) (ok bool, err error) {
 	switch alipayPublicKeyCert.(type) {
@@ -240,21 +272,28 @@ func VerifySyncSignWithCert(alipayPublicKeyCert interface{}, signData, sign stri
 	return true, nil
 }
 
+// 同步返回自动验签，公钥模式、公钥证书模式均使用 a.aliPayPublicKey 验签
+//	验签失败时返回 *VerifySignError
 func (a *Client) autoVerifySignByCert(sign, signData string, signDataErr error) (err error) {
 	if a.autoSign && a.aliPayPublicKey != nil {
 		if a.DebugSwitch == gopay.DebugOn {
 			xlog.Debugf("Alipay_SyncSignData: %s, Sign=[%s]", signData, sign)
 		}
-		// 只有证书验签时，才可能出现此error
+		// 响应格式错误或证书SN不匹配
 		if signDataErr != nil {
-			return signDataErr
+			return &VerifySignError{SignData: signData, Sign: sign, Err: signDataErr}
+		}
+		if sign == util.NULL {
+			return &VerifySignError{SignData: signData, Sign: sign, Err: errors.New("sign is empty")}
 		}
 
 		signBytes, _ := base64.StdEncoding.DecodeString(sign)
 		hashs := crypto.SHA256
 		h := hashs.New()
 		h.Write([]byte(signData))
-		return rsa.VerifyPKCS1v15(a.aliPayPublicKey, hashs, h.Sum(nil), signBytes)
+		if err = rsa.VerifyPKCS1v15(a.aliPayPublicKey, hashs, h.Sum(nil), signBytes); err != nil {
+			return &VerifySignError{SignData: signData, Sign: sign, Err: err}
+		}
 	}
 	return nil
 }
@@ -266,7 +305,7 @@ func (a *Client) autoVerifySignByCert(sign, signData string, signDataErr error)
 //	alipayPublicKey：支付宝平台获取的支付宝公钥
 //	notifyBean：此参数为异步通知解析的结构体或BodyMap：notifyReq 或 bm，推荐通 BodyMap 验签
 //	返回参数ok：是否验签通过
-//	返回参数err：错误信息
+//	返回参数err：错误信息，验签失败时为 *VerifySignError
 //	验签文档：https://opendocs.alipay.com/open/200/106120
 func VerifySign(alipayPublicKey string, notifyBean interface{}) (ok bool, err error) {
 	if alipayPublicKey == util.NULL || notifyBean == nil {
@@ -312,7 +351,7 @@ func VerifySign(alipayPublicKey string, notifyBean interface{}) (ok bool, err er
 //	aliPayPublicKeyCert：支付宝公钥证书存放路径 alipayCertPublicKey_RSA2.crt 或文件内容[]byte
 //	notifyBean：此参数为异步通知解析的结构体或BodyMap：notifyReq 或 bm，推荐通 BodyMap 验签
 //	返回参数ok：是否验签通过
-//	返回参数err：错误信息
+//	返回参数err：错误信息，验签失败时为 *VerifySignError
 //	验签文档：https://opendocs.alipay.com/open/200/106120
 func VerifySignWithCert(aliPayPublicKeyCert, notifyBean interface{}) (ok bool, err error) {
 	if notifyBean == nil || aliPayPublicKeyCert == nil {
@@ -384,7 +423,10 @@ func verifySign(signData, sign, signType, alipayPublicKey string) (err error) {
 	}
 	h = hashs.New()
 	h.Write([]byte(signData))
-	return rsa.VerifyPKCS1v15(publicKey, hashs, h.Sum(nil), signBytes)
+	if err = rsa.VerifyPKCS1v15(publicKey, hashs, h.Sum(nil), signBytes); err != nil {
+		return &VerifySignError{SignData: signData, Sign: sign, Err: err}
+	}
+	return nil
 }
 
 func verifySignCert(signData, sign, signType string, alipayPublicKeyCert interface{}) (err error) {
@@ -419,5 +461,8 @@ func verifySignCert(signData, sign, signType string, alipayPublicKeyCert interfa
 	}
 	h = hashs.New()
 	h.Write([]byte(signData))
-	return rsa.VerifyPKCS1v15(publicKey, hashs, h.Sum(nil), signBytes)
+	if err = rsa.VerifyPKCS1v15(publicKey, hashs, h.Sum(nil), signBytes); err != nil {
+		return &VerifySignError{SignData: signData, Sign: sign, Err: err}
+	}
+	return nil
 }
@@ -1,10 +1,21 @@
 package alipay
 
 import (
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"errors"
 	"io/ioutil"
+	"net/http"
+	"strings"
 	"testing"
 
 	"github.com/yuanqinguo/gopay"
+	"github.com/yuanqinguo/gopay/alipay/cert"
 	"github.com/yuanqinguo/gopay/pkg/xlog"
 	"github.com/yuanqinguo/gopay/pkg/xrsa"
 )
@@ -145,3 +156,144 @@ func TestGetCertSN(t *testing.T) {
 	// 687b59193f3f462dd5336e5abf83c5d8_02941eef3187dddf3d3b83462e1dfcf6
 	// 687b59193f3f462dd5336e5abf83c5d8_02941eef3187dddf3d3b83462e1dfcf6
 }
+
+func TestAutoVerifySignByPublicKey(t *testing.T) {
+	c, err := NewClient(cert.Appid, cert.PrivateKey, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = c.AutoVerifySignByPublicKey("MIIBIjANBgkqhkiG9w0BAQEFAAOCAQ8AMIIBCgKCAQEA"); err == nil {
+		t.Fatal("expected invalid public key error")
+	}
+	pubBs, _ := x509.MarshalPKIXPublicKey(&c.privateKey.PublicKey)
+	// 支持 PEM 格式
+	if err = c.AutoVerifySignByPublicKey(string(pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: pubBs}))); err != nil {
+		t.Fatal(err)
+	}
+	pubKey := base64.StdEncoding.EncodeToString(pubBs)
+	if err = c.AutoVerifySignByPublicKey(pubKey); err != nil {
+		t.Fatal(err)
+	}
+
+	signData := `{"code":"10000","msg":"Success","trade_no":"2013112011001004330000121536","out_trade_no":"6823789339978248","trade_status":"TRADE_SUCCESS"}`
+	h := sha256.Sum256([]byte(signData))
+	signBytes, err := rsa.SignPKCS1v15(rand.Reader, c.privateKey, crypto.SHA256, h[:])
+	if err != nil {
+		t.Fatal(err)
+	}
+	sign := base64.StdEncoding.EncodeToString(signBytes)
+	var rspSignData string
+	c.SetHttpClient(&http.Client{Transport: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		rspBody := `{"alipay_trade_query_response":` + rspSignData + `,"sign":"` + sign + `"}`
+		return &http.Response{StatusCode: http.StatusOK, Header: make(http.Header), Body: ioutil.NopCloser(strings.NewReader(rspBody))}, nil
+	})})
+	bm := make(gopay.BodyMap)
+	bm.Set("out_trade_no", "6823789339978248")
+
+	rspSignData = signData
+	aliRsp, err := c.TradeQuery(ctx, bm)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ok, err := VerifySyncSign(pubKey, aliRsp.SignData, aliRsp.Sign); !ok || err != nil {
+		t.Fatalf("VerifySyncSign: %v", err)
+	}
+
+	// 响应被篡改
+	rspSignData = strings.Replace(signData, "TRADE_SUCCESS", "TRADE_FINISHED", 1)
+	_, err = c.TradeQuery(ctx, bm)
+	verifyErr := new(VerifySignError)
+	if !errors.As(err, &verifyErr) || verifyErr.SignData != rspSignData {
+		t.Fatalf("expected sign verify error, got: %v", err)
+	}
+	if _, ok := gopay.AsError(err); ok {
+		t.Fatal("verify sign error should not be *gopay.Error")
+	}
+	if _, err = VerifySyncSign(pubKey, rspSignData, sign); !errors.As(err, &verifyErr) {
+		t.Fatalf("expected sign verify error, got: %v", err)
+	}
+}
+
+func TestAutoVerifySignSelf(t *testing.T) {
+	c, err := NewClient(cert.Appid, cert.PrivateKey, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	pubBs, _ := x509.MarshalPKIXPublicKey(&c.privateKey.PublicKey)
+	if err = c.AutoVerifySignByPublicKey(base64.StdEncoding.EncodeToString(pubBs)); err != nil {
+		t.Fatal(err)
+	}
+	signData := `{"code":"10000","msg":"Success","out_trade_no":"6823789339978248","qr_code":"https://qr.alipay.com/bax03431ljhokirwl38f00a7"}`
+	h := sha256.Sum256([]byte(signData))
+	signBytes, _ := rsa.SignPKCS1v15(rand.Reader, c.privateKey, crypto.SHA256, h[:])
+	sign := base64.StdEncoding.EncodeToString(signBytes)
+	var rspSignData string
+	c.SetHttpClient(&http.Client{Transport: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		rspBody := `{"alipay_trade_precreate_response":` + rspSignData + `,"sign":"` + sign + `"}`
+		return &http.Response{StatusCode: http.StatusOK, Header: make(http.Header), Body: ioutil.NopCloser(strings.NewReader(rspBody))}, nil
+	})})
+	newBm := func() gopay.BodyMap {
+		bm := make(gopay.BodyMap)
+		bm.SetBodyMap("biz_content", func(bz gopay.BodyMap) {
+			bz.Set("out_trade_no", "6823789339978248")
+		})
+		return bm
+	}
+
+	rspSignData = signData
+	aliRsp := new(TradePrecreateResponse)
+	if err = c.PostAliPayAPISelfV2(ctx, newBm(), "alipay.trade.precreate", aliRsp); err != nil {
+		t.Fatal(err)
+	}
+	if err = c.PostAliPayAPISelf(ctx, gopay.BodyMap{"out_trade_no": "6823789339978248"}, "alipay.trade.precreate", aliRsp); err != nil {
+		t.Fatal(err)
+	}
+
+	// 响应被篡改
+	rspSignData = strings.Replace(signData, "6823789339978248", "6823789339978249", 1)
+	err = c.PostAliPayAPISelfV2(ctx, newBm(), "alipay.trade.precreate", aliRsp)
+	if verifyErr := new(VerifySignError); !errors.As(err, &verifyErr) || verifyErr.SignData != rspSignData {
+		t.Fatalf("expected sign verify error, got: %v", err)
+	}
+	err = c.PostAliPayAPISelf(ctx, gopay.BodyMap{"out_trade_no": "6823789339978248"}, "alipay.trade.precreate", aliRsp)
+	if verifyErr := new(VerifySignError); !errors.As(err, &verifyErr) {
+		t.Fatalf("expected sign verify error, got: %v", err)
+	}
+
+	// 接口失败时不验签
+	rspSignData = `{"code":"40004","msg":"Business Failed","sub_code":"ACQ.TRADE_HAS_SUCCESS","sub_msg":"交易已被支付"}`
+	if err = c.PostAliPayAPISelfV2(ctx, newBm(), "alipay.trade.precreate", aliRsp); err != nil {
+		t.Fatal(err)
+	}
+
+	// 响应中没有 sign
+	c.SetHttpClient(&http.Client{Transport: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		rspBody := `{"alipay_trade_precreate_response":` + signData + `}`
+		return &http.Response{StatusCode: http.StatusOK, Header: make(http.Header), Body: ioutil.NopCloser(strings.NewReader(rspBody))}, nil
+	})})
+	err = c.PostAliPayAPISelfV2(ctx, newBm(), "alipay.trade.precreate", aliRsp)
+	if verifyErr := new(VerifySignError); !errors.As(err, &verifyErr) {
+		t.Fatalf("expected sign verify error, got: %v", err)
+	}
+}
+
+func TestGetSignDataMalformed(t *testing.T) {
+	c := &Client{AliPayPublicCertSN: "sn"}
+	for _, rsp := range []string{
+		`{"sign":"xxx"}`,
+		`{"alipay_trade_query_response":{"code":"10000"}}`,
+		`{"alipay_trade_query_response":{"code":"10000"},"sign":"xxx"}`,
+	} {
+		if _, err := c.getSignData([]byte(rsp), "", "sn"); err == nil {
+			t.Errorf("cert mode: expected format error: %s", rsp)
+		}
+	}
+	for _, rsp := range []string{
+		`{"sign":"xxx"}`,
+		`{"alipay_trade_query_response":{"code":"10000"}}`,
+	} {
+		if _, err := c.getSignData([]byte(rsp), "", ""); err == nil {
+			t.Errorf("public key mode: expected format error: %s", rsp)
+		}
+	}
+}
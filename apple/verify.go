@@ -1,6 +1,8 @@
 package apple
 
 import (
+	"net/http"
+
 	"github.com/yuanqinguo/gopay/pkg/xhttp"
 )
 
@@ -17,9 +19,16 @@ const (
 //	pwd：苹果APP秘钥，https://help.apple.com/app-store-connect/#/devf341c0f01
 // 	文档：https://developer.apple.com/documentation/appstorereceipts/verifyreceipt
 func VerifyReceipt(url, pwd, receipt string) (*VerifyResponse, error) {
+	return VerifyReceiptWithClient(nil, url, pwd, receipt)
+}
+
+// VerifyReceiptWithClient 使用自定义的 http.Client 请求APP Store 校验支付请求
+//	hc：自定义的 http.Client，可用于代理、连接复用、链路追踪、单元测试替身等场景，传 nil 则使用默认配置
+//	其他参数同 VerifyReceipt()
+func VerifyReceiptWithClient(hc *http.Client, url, pwd, receipt string) (*VerifyResponse, error) {
 	req := &VerifyRequest{Receipt: receipt, Password: pwd}
 	vr := new(VerifyResponse)
-	_, errs := xhttp.NewClient().Type(xhttp.TypeJSON).Post(url).SendStruct(req).EndStruct(vr)
+	_, errs := xhttp.NewClient().SetHttpClient(hc).Type(xhttp.TypeJSON).Post(url).SendStruct(req).EndStruct(vr)
 	if len(errs) > 0 {
 		return nil, errs[0]
 	}
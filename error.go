@@ -0,0 +1,63 @@
+package gopay
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+)
+
+// 支付平台
+const (
+	ProviderAlipay   = "alipay"
+	ProviderWechat   = "wechat"
+	ProviderWechatV3 = "wechat_v3"
+	ProviderQQ       = "qq"
+	ProviderPayPal   = "paypal"
+)
+
+// Error 支付平台接口返回的错误，包括 http 状态码错误和平台业务错误
+//	各平台 client 接口方法返回的 err 可通过 errors.As(err, &gopayErr) 或 gopay.AsError(err) 获取，统一处理重试、告警等逻辑
+type Error struct {
+	Provider   string // 支付平台，如 gopay.ProviderAlipay
+	StatusCode int    // http 状态码，业务错误时一般为 200
+	Code       string // 平台错误码，如支付宝 sub_code、微信 err_code、微信v3 code、PayPal name
+	Message    string // 平台错误信息
+	RequestId  string // 平台请求 id，如微信v3 Request-ID、PayPal debug_id，用于联系平台排查问题
+	Retryable  bool   // 是否可重试，如 http 429、5xx，或平台返回系统繁忙等错误码
+	Body       string // 平台原始返回内容
+}
+
+// NewError 初始化支付平台错误
+//	http 状态码为 429 或 5xx 时，Retryable 为 true
+func NewError(provider string, statusCode int, code, message string) (e *Error) {
+	return &Error{
+		Provider:   provider,
+		StatusCode: statusCode,
+		Code:       code,
+		Message:    message,
+		Retryable:  statusCode == http.StatusTooManyRequests || statusCode >= http.StatusInternalServerError,
+	}
+}
+
+func (e *Error) Error() string {
+	if e.Code == NULL && e.Message == NULL {
+		return fmt.Sprintf("%s: HTTP Request Error, StatusCode = %d", e.Provider, e.StatusCode)
+	}
+	s := fmt.Sprintf("%s: StatusCode = %d, Code = %s, Message = %s", e.Provider, e.StatusCode, e.Code, e.Message)
+	if e.RequestId != NULL {
+		s += ", RequestId = " + e.RequestId
+	}
+	return s
+}
+
+// AsError 从 err 中获取支付平台错误
+func AsError(err error) (e *Error, ok bool) {
+	ok = errors.As(err, &e)
+	return e, ok
+}
+
+// IsRetryable 判断 err 是否为可重试的支付平台错误
+func IsRetryable(err error) bool {
+	e, ok := AsError(err)
+	return ok && e.Retryable
+}
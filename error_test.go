@@ -0,0 +1,31 @@
+package gopay
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+)
+
+func TestError(t *testing.T) {
+	err := fmt.Errorf("wrap: %w", NewError(ProviderWechatV3, http.StatusTooManyRequests, "FREQUENCY_LIMITED", "频率超限"))
+	var gopayErr *Error
+	if !errors.As(err, &gopayErr) {
+		t.Fatalf("expected *gopay.Error, got: %v", err)
+	}
+	if gopayErr.Provider != ProviderWechatV3 || gopayErr.Code != "FREQUENCY_LIMITED" {
+		t.Fatalf("unexpected error: %+v", gopayErr)
+	}
+	if !IsRetryable(err) {
+		t.Fatal("expected 429 retryable")
+	}
+	if IsRetryable(NewError(ProviderAlipay, http.StatusOK, "40004", "Business Failed")) {
+		t.Fatal("expected business error not retryable")
+	}
+	if IsRetryable(errors.New("other")) {
+		t.Fatal("expected non gopay error not retryable")
+	}
+	if _, ok := AsError(errors.New("other")); ok {
+		t.Fatal("expected AsError false")
+	}
+}
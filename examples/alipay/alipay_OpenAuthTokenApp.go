@@ -1,6 +1,8 @@
 package alipay
 
 import (
+	"context"
+
 	"github.com/yuanqinguo/gopay"
 	"github.com/yuanqinguo/gopay/alipay"
 	"github.com/yuanqinguo/gopay/pkg/xlog"
@@ -27,7 +29,7 @@ func OpenAuthTokenApp() {
 		Set("grant_type", "authorization_code").
 		Set("code", "866185490c4e40efa9f71efea6766X02")
 	//发起请求
-	aliRsp, err := client.OpenAuthTokenApp(bm)
+	aliRsp, err := client.OpenAuthTokenApp(context.Background(), bm)
 	if err != nil {
 		xlog.Error("err:", err)
 		return
@@ -1,6 +1,8 @@
 package alipay
 
 import (
+	"context"
+
 	"github.com/yuanqinguo/gopay"
 	"github.com/yuanqinguo/gopay/alipay"
 	"github.com/yuanqinguo/gopay/pkg/xlog"
@@ -28,7 +30,7 @@ func SystemOauthToken() {
 	body.Set("code", "3a06216ac8f84b8c93507bb9774bWX11")
 
 	//发起请求
-	aliRsp, err := client.SystemOauthToken(body)
+	aliRsp, err := client.SystemOauthToken(context.Background(), body)
 	if err != nil {
 		xlog.Error("err:", err)
 		return
@@ -44,7 +46,7 @@ func SystemOauthToken() {
 	body2.Set("out_trade_no", "GZ201901301040355708")
 	body2.Set("total_amount", "0.01")
 
-	rsp, err := client.TradeCreate(body2)
+	rsp, err := client.TradeCreate(context.Background(), body2)
 	if err != nil {
 		xlog.Error("err:", err)
 		return
@@ -1,6 +1,8 @@
 package alipay
 
 import (
+	"context"
+
 	"github.com/yuanqinguo/gopay"
 	"github.com/yuanqinguo/gopay/alipay"
 	"github.com/yuanqinguo/gopay/pkg/xlog"
@@ -29,7 +31,7 @@ func TradeAppPay() {
 	body.Set("out_trade_no", "GZ201901301040355706100469")
 	body.Set("total_amount", "1.00")
 	//手机APP支付参数请求
-	payParam, err := client.TradeAppPay(body)
+	payParam, err := client.TradeAppPay(context.Background(), body)
 	if err != nil {
 		xlog.Error("err:", err)
 		return
@@ -1,6 +1,8 @@
 package alipay
 
 import (
+	"context"
+
 	"github.com/yuanqinguo/gopay"
 	"github.com/yuanqinguo/gopay/alipay"
 	"github.com/yuanqinguo/gopay/pkg/xlog"
@@ -27,7 +29,7 @@ func TradeClose() {
 	body := make(gopay.BodyMap)
 	body.Set("out_trade_no", "GYWX201901301040355706100459")
 	//条码支付
-	aliRsp, err := client.TradeClose(body)
+	aliRsp, err := client.TradeClose(context.Background(), body)
 	if err != nil {
 		xlog.Error("err:", err)
 		return
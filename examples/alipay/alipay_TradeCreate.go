@@ -1,6 +1,8 @@
 package alipay
 
 import (
+	"context"
+
 	"github.com/yuanqinguo/gopay"
 	"github.com/yuanqinguo/gopay/alipay"
 	"github.com/yuanqinguo/gopay/pkg/xlog"
@@ -31,7 +33,7 @@ func TradeCreate() {
 		Set("out_trade_no", "GZ201901301040355709").
 		Set("total_amount", "0.01")
 	//创建订单
-	aliRsp, err := client.TradeCreate(bm)
+	aliRsp, err := client.TradeCreate(context.Background(), bm)
 	if err != nil {
 		xlog.Error("err:", err)
 		return
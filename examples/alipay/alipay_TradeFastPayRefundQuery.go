@@ -1,6 +1,8 @@
 package alipay
 
 import (
+	"context"
+
 	"github.com/yuanqinguo/gopay"
 	"github.com/yuanqinguo/gopay/alipay"
 	"github.com/yuanqinguo/gopay/pkg/xlog"
@@ -27,7 +29,7 @@ func TradeFastPayRefundQuery() {
 	body.Set("out_trade_no", "GZ201907301420334577")
 	body.Set("out_request_no", "GZ201907301420334577")
 	//发起退款查询请求
-	aliRsp, err := client.TradeFastPayRefundQuery(body)
+	aliRsp, err := client.TradeFastPayRefundQuery(context.Background(), body)
 	if err != nil {
 		xlog.Error("err:", err)
 		return
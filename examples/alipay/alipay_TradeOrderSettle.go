@@ -1,6 +1,8 @@
 package alipay
 
 import (
+	"context"
+
 	"github.com/yuanqinguo/gopay"
 	"github.com/yuanqinguo/gopay/alipay"
 	"github.com/yuanqinguo/gopay/pkg/xlog"
@@ -33,7 +35,7 @@ func TradeOrderSettle() {
 	xlog.Debug("listParams:", bm.GetString("royalty_parameters"))
 
 	//发起交易结算接口
-	aliRsp, err := client.TradeOrderSettle(bm)
+	aliRsp, err := client.TradeOrderSettle(context.Background(), bm)
 	if err != nil {
 		xlog.Error("err:", err)
 		return
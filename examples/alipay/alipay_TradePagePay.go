@@ -1,6 +1,8 @@
 package alipay
 
 import (
+	"context"
+
 	"github.com/yuanqinguo/gopay"
 	"github.com/yuanqinguo/gopay/alipay"
 	"github.com/yuanqinguo/gopay/pkg/xlog"
@@ -31,7 +33,7 @@ func TradePagePay() {
 	body.Set("product_code", "FAST_INSTANT_TRADE_PAY")
 
 	//电脑网站支付请求
-	payUrl, err := client.TradePagePay(body)
+	payUrl, err := client.TradePagePay(context.Background(), body)
 	if err != nil {
 		xlog.Error("err:", err)
 		return
@@ -1,6 +1,8 @@
 package alipay
 
 import (
+	"context"
+
 	"github.com/yuanqinguo/gopay"
 	"github.com/yuanqinguo/gopay/alipay"
 	"github.com/yuanqinguo/gopay/pkg/xlog"
@@ -33,7 +35,7 @@ func TradePay() {
 	bm.Set("total_amount", "0.01")
 	bm.Set("timeout_express", "2m")
 	//条码支付
-	aliRsp, err := client.TradePay(bm)
+	aliRsp, err := client.TradePay(context.Background(), bm)
 	if err != nil {
 		xlog.Error("err:", err)
 		return
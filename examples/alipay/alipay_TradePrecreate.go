@@ -1,6 +1,8 @@
 package alipay
 
 import (
+	"context"
+
 	"github.com/yuanqinguo/gopay"
 	"github.com/yuanqinguo/gopay/alipay"
 	"github.com/yuanqinguo/gopay/pkg/xlog"
@@ -28,7 +30,7 @@ func TradePrecreate() {
 	body.Set("out_trade_no", "GZ201907301040355704")
 	body.Set("total_amount", "100")
 	//创建订单
-	aliRsp, err := client.TradePrecreate(body)
+	aliRsp, err := client.TradePrecreate(context.Background(), body)
 	if err != nil {
 		xlog.Error("err:", err)
 		return
@@ -1,6 +1,8 @@
 package alipay
 
 import (
+	"context"
+
 	"github.com/yuanqinguo/gopay"
 	"github.com/yuanqinguo/gopay/alipay"
 	"github.com/yuanqinguo/gopay/pkg/xlog"
@@ -28,7 +30,7 @@ func TradeQuery() {
 	body.Set("out_trade_no", "GZ201909081743431443")
 
 	//查询订单
-	aliRsp, err := client.TradeQuery(body)
+	aliRsp, err := client.TradeQuery(context.Background(), body)
 	if err != nil {
 		xlog.Error("err:", err)
 		return
@@ -1,6 +1,8 @@
 package alipay
 
 import (
+	"context"
+
 	"github.com/yuanqinguo/gopay"
 	"github.com/yuanqinguo/gopay/alipay"
 	"github.com/yuanqinguo/gopay/pkg/xlog"
@@ -28,7 +30,7 @@ func TradeRefund() {
 	body.Set("refund_amount", "5")
 	body.Set("refund_reason", "测试退款")
 	//发起退款请求
-	aliRsp, err := client.TradeRefund(body)
+	aliRsp, err := client.TradeRefund(context.Background(), body)
 	if err != nil {
 		xlog.Error("err:", err)
 		return
@@ -1,6 +1,8 @@
 package alipay
 
 import (
+	"context"
+
 	"github.com/yuanqinguo/gopay"
 	"github.com/yuanqinguo/gopay/alipay"
 	"github.com/yuanqinguo/gopay/pkg/xlog"
@@ -31,7 +33,7 @@ func TradeWapPay() {
 	body.Set("total_amount", "100.00")
 	body.Set("product_code", "QUICK_WAP_WAY")
 	//手机网站支付请求
-	payUrl, err := client.TradeWapPay(body)
+	payUrl, err := client.TradeWapPay(context.Background(), body)
 	if err != nil {
 		xlog.Error("err:", err)
 		return
@@ -1,6 +1,8 @@
 package alipay
 
 import (
+	"context"
+
 	"github.com/yuanqinguo/gopay"
 	"github.com/yuanqinguo/gopay/alipay"
 	"github.com/yuanqinguo/gopay/pkg/xlog"
@@ -28,7 +30,7 @@ func UserCertifyOpenCertify() {
 	bm.Set("certify_id", "OC201809253000000393900404029253")
 
 	//发起请求
-	certifyUrl, err := client.UserCertifyOpenCertify(bm)
+	certifyUrl, err := client.UserCertifyOpenCertify(context.Background(), bm)
 	if err != nil {
 		xlog.Error("err:", err)
 		return
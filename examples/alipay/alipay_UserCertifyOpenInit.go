@@ -1,6 +1,8 @@
 package alipay
 
 import (
+	"context"
+
 	"github.com/yuanqinguo/gopay"
 	"github.com/yuanqinguo/gopay/alipay"
 	"github.com/yuanqinguo/gopay/pkg/xlog"
@@ -40,7 +42,7 @@ func UserCertifyOpenInit() {
 	bm.Set("merchant_config", merchant)
 
 	//发起请求
-	aliRsp, err := client.UserCertifyOpenInit(bm)
+	aliRsp, err := client.UserCertifyOpenInit(context.Background(), bm)
 	if err != nil {
 		xlog.Error("err:", err)
 		return
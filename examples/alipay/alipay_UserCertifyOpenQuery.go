@@ -1,6 +1,8 @@
 package alipay
 
 import (
+	"context"
+
 	"github.com/yuanqinguo/gopay"
 	"github.com/yuanqinguo/gopay/alipay"
 	"github.com/yuanqinguo/gopay/pkg/xlog"
@@ -28,7 +30,7 @@ func UserCertifyOpenQuery() {
 	bm.Set("certify_id", "OC201809253000000393900404029253")
 
 	//发起请求
-	aliRsp, err := client.UserCertifyOpenQuery(bm)
+	aliRsp, err := client.UserCertifyOpenQuery(context.Background(), bm)
 	if err != nil {
 		xlog.Error("err:", err)
 		return
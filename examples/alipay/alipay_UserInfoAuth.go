@@ -1,6 +1,8 @@
 package alipay
 
 import (
+	"context"
+
 	"github.com/yuanqinguo/gopay"
 	"github.com/yuanqinguo/gopay/alipay"
 	"github.com/yuanqinguo/gopay/pkg/xlog"
@@ -29,7 +31,7 @@ func UserInfoAuth() {
 	bm.Set("state", "init")
 
 	// 发起请求
-	aliRsp, err := client.UserInfoAuth(bm)
+	aliRsp, err := client.UserInfoAuth(context.Background(), bm)
 	if err != nil {
 		xlog.Error("err:", err)
 		return
@@ -1,6 +1,8 @@
 package alipay
 
 import (
+	"context"
+
 	"github.com/yuanqinguo/gopay/alipay"
 	"github.com/yuanqinguo/gopay/pkg/xlog"
 )
@@ -22,7 +24,7 @@ func UserInfoShare() {
 		SetSignType(alipay.RSA2)
 
 	// 发起请求
-	aliRsp, err := client.UserInfoShare("authusrB3888b190f6df4aea964d66129f8a5X11")
+	aliRsp, err := client.UserInfoShare(context.Background(), "authusrB3888b190f6df4aea964d66129f8a5X11")
 	if err != nil {
 		xlog.Error("err:", err)
 		return
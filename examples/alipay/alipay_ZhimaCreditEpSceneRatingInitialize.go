@@ -1,6 +1,8 @@
 package alipay
 
 import (
+	"context"
+
 	"github.com/yuanqinguo/gopay"
 	"github.com/yuanqinguo/gopay/alipay"
 	"github.com/yuanqinguo/gopay/pkg/xlog"
@@ -28,7 +30,7 @@ func ZhimaCreditEpSceneRatingInitialize() {
 	body.Set("out_order_no", "201805301527674106562F0000954216")
 	body.Set("user_id", "2088302248028263")
 
-	aliRsp, err := client.ZhimaCreditEpSceneRatingInitialize(body)
+	aliRsp, err := client.ZhimaCreditEpSceneRatingInitialize(context.Background(), body)
 	if err != nil {
 		xlog.Error("err:", err)
 		return
@@ -1,5 +1,11 @@
 module github.com/yuanqinguo/gopay
 
-go 1.13.5
+go 1.17
 
-require golang.org/x/crypto v0.0.0-20210921155107-089bfa567519
+require (
+	github.com/emmansun/gmsm v0.15.5
+	golang.org/x/crypto v0.4.0
+	golang.org/x/text v0.5.0
+)
+
+require golang.org/x/sys v0.3.0 // indirect
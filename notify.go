@@ -0,0 +1,182 @@
+package gopay
+
+import (
+	"container/list"
+	"errors"
+	"sync"
+	"time"
+)
+
+const (
+	// 异步通知去重默认保存时间，需覆盖各平台的重试周期（支付宝约25小时，微信约24小时）
+	DefaultNotifyDedupeTTL = 48 * time.Hour
+	// 通知处理中状态默认保存时间，进程在处理中退出时，超过此时间后允许重新处理
+	DefaultNotifyProcessingTTL = 10 * time.Minute
+	// 内存去重存储默认容量
+	DefaultNotifyStoreCapacity = 10000
+)
+
+var (
+	// 重复的异步通知（已处理完成），直接返回成功应答即可
+	ErrNotifyDuplicate = errors.New("duplicate notify")
+	// 重复的异步通知（首次通知仍在处理中），应返回失败应答，由平台稍后重新通知
+	//	此时不能返回成功应答：首次处理失败时，平台已收到成功应答，不会再次通知
+	ErrNotifyProcessing = errors.New("duplicate notify is processing")
+)
+
+// NotifyState 异步通知处理状态
+type NotifyState uint8
+
+const (
+	NotifyStateProcessing NotifyState = 1 // 处理中
+	NotifyStateDone       NotifyState = 2 // 已处理完成
+)
+
+// NotifyStore 异步通知去重存储，多实例部署时，可基于 Redis 等实现
+//	如 SetNX：SET key processing NX EX ttl，失败时 GET key 获取状态；SetDone：SET key done EX ttl；Delete：DEL key
+type NotifyStore interface {
+	// SetNX key 不存在时保存为 NotifyStateProcessing 并返回 true；已存在时返回 false 及当前状态
+	SetNX(key string, ttl time.Duration) (ok bool, state NotifyState, err error)
+	// SetDone 通知处理完成时调用，将 key 保存为 NotifyStateDone
+	SetDone(key string, ttl time.Duration) (err error)
+	// Delete 删除 key，通知处理失败时调用，允许平台重新通知后再次处理
+	Delete(key string) (err error)
+}
+
+// NotifyDeduper 异步通知去重
+type NotifyDeduper struct {
+	store         NotifyStore
+	ttl           time.Duration
+	processingTTL time.Duration
+}
+
+// NewNotifyDeduper 初始化异步通知去重
+//	store：去重存储，传 nil 使用默认容量的内存 LRU 存储
+//	ttl：已处理完成的通知 key 保存时间，小于等于0时使用 DefaultNotifyDedupeTTL
+func NewNotifyDeduper(store NotifyStore, ttl time.Duration) (deduper *NotifyDeduper) {
+	if store == nil {
+		store = NewMemoryNotifyStore(DefaultNotifyStoreCapacity)
+	}
+	if ttl <= 0 {
+		ttl = DefaultNotifyDedupeTTL
+	}
+	return &NotifyDeduper{store: store, ttl: ttl, processingTTL: DefaultNotifyProcessingTTL}
+}
+
+// SetProcessingTTL 设置通知处理中状态的保存时间，需大于回调处理的最长耗时，小于等于0时不修改
+func (d *NotifyDeduper) SetProcessingTTL(ttl time.Duration) (deduper *NotifyDeduper) {
+	if ttl > 0 {
+		d.processingTTL = ttl
+	}
+	return d
+}
+
+// Do 同一 key 的通知只处理一次
+//	key 已处理完成时，不执行 fn，返回 ErrNotifyDuplicate，应返回成功应答
+//	key 正在处理中时，不执行 fn，返回 ErrNotifyProcessing，应返回失败应答
+//	fn 返回 err 时删除 key，允许平台重新通知后再次处理
+//	key 为空（通知缺少id）时无法去重，直接执行 fn
+func (d *NotifyDeduper) Do(key string, fn func() error) (err error) {
+	if key == NULL {
+		return fn()
+	}
+	ok, state, err := d.store.SetNX(key, d.processingTTL)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		if state == NotifyStateDone {
+			return ErrNotifyDuplicate
+		}
+		return ErrNotifyProcessing
+	}
+	if err = fn(); err != nil {
+		d.store.Delete(key)
+		return err
+	}
+	return d.store.SetDone(key, d.ttl)
+}
+
+// NotifyDedupeKey 生成异步通知去重使用的 key，格式为 provider:kind:id
+//	provider：支付平台，如 gopay.ProviderAlipay
+//	kind：通知类型，如 notify、pay、refund
+//	id：通知唯一标识，为空时返回空字符串（不去重）
+func NotifyDedupeKey(provider, kind, id string) (key string) {
+	if id == NULL {
+		return NULL
+	}
+	return provider + ":" + kind + ":" + id
+}
+
+// MemoryNotifyStore 内存 LRU 去重存储，仅当前进程内有效
+type MemoryNotifyStore struct {
+	capacity int
+	ll       *list.List
+	items    map[string]*list.Element
+	mu       sync.Mutex
+}
+
+type notifyStoreEntry struct {
+	key      string
+	state    NotifyState
+	expireAt time.Time
+}
+
+// NewMemoryNotifyStore 初始化内存 LRU 去重存储
+//	capacity：最多保存的 key 数量，超出时淘汰最久未使用的 key，小于等于0时使用 DefaultNotifyStoreCapacity
+func NewMemoryNotifyStore(capacity int) (store *MemoryNotifyStore) {
+	if capacity <= 0 {
+		capacity = DefaultNotifyStoreCapacity
+	}
+	return &MemoryNotifyStore{
+		capacity: capacity,
+		ll:       list.New(),
+		items:    make(map[string]*list.Element),
+	}
+}
+
+func (m *MemoryNotifyStore) SetNX(key string, ttl time.Duration) (ok bool, state NotifyState, err error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	now := time.Now()
+	if e, exist := m.items[key]; exist {
+		if entry := e.Value.(*notifyStoreEntry); entry.expireAt.After(now) {
+			m.ll.MoveToFront(e)
+			return false, entry.state, nil
+		}
+		m.ll.Remove(e)
+		delete(m.items, key)
+	}
+	m.set(key, NotifyStateProcessing, now.Add(ttl))
+	return true, NotifyStateProcessing, nil
+}
+
+func (m *MemoryNotifyStore) SetDone(key string, ttl time.Duration) (err error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if e, exist := m.items[key]; exist {
+		m.ll.Remove(e)
+		delete(m.items, key)
+	}
+	m.set(key, NotifyStateDone, time.Now().Add(ttl))
+	return nil
+}
+
+func (m *MemoryNotifyStore) set(key string, state NotifyState, expireAt time.Time) {
+	m.items[key] = m.ll.PushFront(&notifyStoreEntry{key: key, state: state, expireAt: expireAt})
+	for m.ll.Len() > m.capacity {
+		e := m.ll.Back()
+		m.ll.Remove(e)
+		delete(m.items, e.Value.(*notifyStoreEntry).key)
+	}
+}
+
+func (m *MemoryNotifyStore) Delete(key string) (err error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if e, exist := m.items[key]; exist {
+		m.ll.Remove(e)
+		delete(m.items, key)
+	}
+	return nil
+}
@@ -0,0 +1,97 @@
+package gopay
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestNotifyDeduper(t *testing.T) {
+	d := NewNotifyDeduper(NewMemoryNotifyStore(2), time.Hour)
+	var count int
+	fn := func() error {
+		count++
+		return nil
+	}
+	if err := d.Do("a", fn); err != nil {
+		t.Fatal(err)
+	}
+	if err := d.Do("a", fn); !errors.Is(err, ErrNotifyDuplicate) {
+		t.Fatalf("expected ErrNotifyDuplicate, got: %v", err)
+	}
+	// 处理失败时，允许重新处理
+	if err := d.Do("b", func() error { return errors.New("failed") }); err == nil {
+		t.Fatal("expected error")
+	}
+	if err := d.Do("b", fn); err != nil {
+		t.Fatal(err)
+	}
+	// 超出容量，淘汰最久未使用的 key
+	if err := d.Do("c", fn); err != nil {
+		t.Fatal(err)
+	}
+	if err := d.Do("a", fn); err != nil {
+		t.Fatalf("expected a evicted, got: %v", err)
+	}
+	if count != 4 {
+		t.Fatalf("expected 4 calls, got: %d", count)
+	}
+
+	// 过期后允许重新处理
+	d = NewNotifyDeduper(NewMemoryNotifyStore(0), time.Millisecond)
+	if err := d.Do("a", fn); err != nil {
+		t.Fatal(err)
+	}
+	time.Sleep(5 * time.Millisecond)
+	if err := d.Do("a", fn); err != nil {
+		t.Fatalf("expected expired key reprocessed, got: %v", err)
+	}
+}
+
+func TestNotifyDeduperProcessing(t *testing.T) {
+	d := NewNotifyDeduper(nil, 0)
+	var count int
+	fn := func() error {
+		count++
+		return nil
+	}
+	// 首次通知处理中，重复通知返回 ErrNotifyProcessing（失败应答），首次处理失败后允许重新处理
+	err := d.Do("a", func() error {
+		if err := d.Do("a", fn); !errors.Is(err, ErrNotifyProcessing) {
+			t.Fatalf("expected ErrNotifyProcessing, got: %v", err)
+		}
+		return errors.New("failed")
+	})
+	if err == nil {
+		t.Fatal("expected error")
+	}
+	if err = d.Do("a", fn); err != nil {
+		t.Fatal(err)
+	}
+	if err = d.Do("a", fn); !errors.Is(err, ErrNotifyDuplicate) {
+		t.Fatalf("expected ErrNotifyDuplicate, got: %v", err)
+	}
+
+	// 处理中状态过期后允许重新处理
+	d.SetProcessingTTL(time.Millisecond)
+	if ok, _, _ := d.store.SetNX("b", d.processingTTL); !ok {
+		t.Fatal("expected b claimed")
+	}
+	time.Sleep(5 * time.Millisecond)
+	if err = d.Do("b", fn); err != nil {
+		t.Fatalf("expected expired processing key reprocessed, got: %v", err)
+	}
+
+	// 缺少通知id时不去重
+	for i := 0; i < 2; i++ {
+		if err = d.Do(NotifyDedupeKey(ProviderAlipay, "notify", ""), fn); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if count != 4 {
+		t.Fatalf("expected 4 calls, got: %d", count)
+	}
+	if key := NotifyDedupeKey(ProviderWechatV3, "notify", "EV-2018022511223320873"); key != "wechat_v3:notify:EV-2018022511223320873" {
+		t.Fatalf("unexpected key: %s", key)
+	}
+}
@@ -1,10 +1,12 @@
 package paypal
 
 import (
+	"context"
 	"encoding/base64"
 	"encoding/json"
 	"fmt"
 	"net/http"
+	"time"
 
 	"github.com/yuanqinguo/gopay"
 	"github.com/yuanqinguo/gopay/pkg/xhttp"
@@ -13,7 +15,7 @@ import (
 
 // 获取AccessToken（Get an access token）
 //	文档：https://developer.paypal.com/docs/api/reference/get-an-access-token
-func (c *Client) GetAccessToken() (token *AccessToken, err error) {
+func (c *Client) GetAccessToken(ctx context.Context) (token *AccessToken, err error) {
 	var (
 		baseUrl = baseUrlProd
 		url     string
@@ -25,7 +27,7 @@ func (c *Client) GetAccessToken() (token *AccessToken, err error) {
 	// Authorization
 	authHeader := AuthorizationPrefixBasic + base64.StdEncoding.EncodeToString([]byte(c.Clientid+":"+c.Secret))
 	// Request
-	httpClient := xhttp.NewClient()
+	httpClient := xhttp.NewClient().SetHttpClient(c.getHttpClient())
 	httpClient.Header.Add(HeaderAuthorization, authHeader)
 	httpClient.Header.Add("Accept", "*/*")
 	// Body
@@ -35,7 +37,7 @@ func (c *Client) GetAccessToken() (token *AccessToken, err error) {
 		xlog.Debugf("PayPal_RequestBody: %s", bm.JsonBody())
 		xlog.Debugf("PayPal_Authorization: %s", authHeader)
 	}
-	res, bs, errs := httpClient.Type(xhttp.TypeForm).Post(url).SendBodyMap(bm).EndBytes()
+	res, bs, errs := httpClient.Type(xhttp.TypeForm).Post(url).SendBodyMap(bm).EndBytesWithContext(ctx)
 	if len(errs) > 0 {
 		return nil, errs[0]
 	}
@@ -44,14 +46,128 @@ func (c *Client) GetAccessToken() (token *AccessToken, err error) {
 		xlog.Debugf("PayPal_Headers: %#v", res.Header)
 	}
 	if res.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("HTTP Request Error, StatusCode = %d", res.StatusCode)
+		e := newError(res, bs, nil)
+		errRsp := new(struct {
+			Error            string `json:"error"`
+			ErrorDescription string `json:"error_description"`
+		})
+		if json.Unmarshal(bs, errRsp) == nil {
+			e.Code = errRsp.Error
+			e.Message = errRsp.ErrorDescription
+		}
+		return nil, e
 	}
 	token = new(AccessToken)
 	if err = json.Unmarshal(bs, token); err != nil {
 		return nil, fmt.Errorf("json.Unmarshal(%s)：%w", string(bs), err)
 	}
+	c.setAccessToken(token)
+	return token, nil
+}
+
+const (
+	// tokenRefreshAhead 过期前主动刷新 AccessToken 的提前时间
+	tokenRefreshAhead = 5 * time.Minute
+	// tokenRefreshTimeout 刷新 AccessToken 请求的超时时间
+	tokenRefreshTimeout = 30 * time.Second
+)
+
+// tokenCall 进行中的 AccessToken 刷新，并发刷新请求等待同一次结果
+type tokenCall struct {
+	done chan struct{}
+	err  error
+}
+
+func (c *Client) setAccessToken(token *AccessToken) {
+	now := time.Now()
+	lifetime := time.Duration(token.ExpiresIn) * time.Second
+	ahead := tokenRefreshAhead
+	if lifetime < 2*ahead {
+		ahead = lifetime / 2
+	}
+	c.mu.Lock()
 	c.Appid = token.Appid
 	c.AccessToken = token.AccessToken
 	c.ExpiresIn = token.ExpiresIn
-	return token, nil
+	c.refreshAt = now.Add(lifetime - ahead)
+	c.expireAt = now.Add(lifetime)
+	c.mu.Unlock()
+}
+
+// getAccessToken 获取当前可用的 AccessToken，即将过期时主动刷新
+//	刷新失败但 AccessToken 尚未过期时，继续使用当前 AccessToken
+//	手动设置 client.AccessToken（未知过期时间）时不主动刷新，仅在返回 401 时刷新
+func (c *Client) getAccessToken(ctx context.Context) (accessToken string, err error) {
+	c.mu.RLock()
+	accessToken, refreshAt, expireAt := c.AccessToken, c.refreshAt, c.expireAt
+	c.mu.RUnlock()
+	if accessToken != gopay.NULL && (refreshAt.IsZero() || time.Now().Before(refreshAt)) {
+		return accessToken, nil
+	}
+	if err = c.refreshAccessToken(ctx); err != nil {
+		if accessToken != gopay.NULL && time.Now().Before(expireAt) {
+			xlog.Warnf("PayPal refresh access token failed, use current token: %v", err)
+			return accessToken, nil
+		}
+		return gopay.NULL, err
+	}
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.AccessToken, nil
+}
+
+// refreshAccessToken 刷新 AccessToken，并发调用时只请求一次，其余调用等待结果
+//	ctx 仅控制当前调用的等待，取消后刷新请求仍继续完成
+func (c *Client) refreshAccessToken(ctx context.Context) (err error) {
+	c.tokenMu.Lock()
+	call := c.tokenCall
+	if call == nil {
+		call = &tokenCall{done: make(chan struct{})}
+		c.tokenCall = call
+		go c.fetchAccessToken(call)
+	}
+	c.tokenMu.Unlock()
+	select {
+	case <-call.done:
+		return call.err
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// fetchAccessToken 使用独立的 ctx 请求 AccessToken，发起刷新的调用方取消 ctx 不影响其他等待的调用
+func (c *Client) fetchAccessToken(call *tokenCall) {
+	ctx, cancel := context.WithTimeout(context.Background(), tokenRefreshTimeout)
+	defer cancel()
+	_, call.err = c.GetAccessToken(ctx)
+
+	c.tokenMu.Lock()
+	c.tokenCall = nil
+	c.tokenMu.Unlock()
+	close(call.done)
+}
+
+// doWithAccessToken 使用当前 AccessToken 发送请求，返回 401 时刷新 AccessToken 后重试一次
+//	其他请求已刷新 AccessToken 时，直接使用新的 AccessToken 重试
+func (c *Client) doWithAccessToken(ctx context.Context, do func(accessToken string) (res *http.Response, bs []byte, err error)) (res *http.Response, bs []byte, err error) {
+	accessToken, err := c.getAccessToken(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+	res, bs, err = do(accessToken)
+	if err != nil || res.StatusCode != http.StatusUnauthorized {
+		return res, bs, err
+	}
+	c.mu.RLock()
+	current := c.AccessToken
+	c.mu.RUnlock()
+	if current == accessToken {
+		if err = c.refreshAccessToken(ctx); err != nil {
+			return nil, nil, err
+		}
+		c.mu.RLock()
+		current = c.AccessToken
+		c.mu.RUnlock()
+	}
+	return do(current)
 }
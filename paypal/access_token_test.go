@@ -0,0 +1,116 @@
+package paypal
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestAccessTokenLifecycle(t *testing.T) {
+	var (
+		fetches int32
+		mu      sync.RWMutex
+		valid   string
+	)
+	hc := &http.Client{Transport: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		rsp := &http.Response{StatusCode: http.StatusOK, Header: make(http.Header)}
+		if req.URL.Path == getAccessToken {
+			time.Sleep(10 * time.Millisecond)
+			token := fmt.Sprintf("token-%d", atomic.AddInt32(&fetches, 1))
+			mu.Lock()
+			valid = token
+			mu.Unlock()
+			rsp.Body = ioutil.NopCloser(strings.NewReader(`{"access_token":"` + token + `","expires_in":32400}`))
+			return rsp, nil
+		}
+		mu.RLock()
+		ok := req.Header.Get(HeaderAuthorization) == AuthorizationPrefixBearer+valid
+		mu.RUnlock()
+		if !ok {
+			rsp.StatusCode = http.StatusUnauthorized
+			rsp.Body = ioutil.NopCloser(strings.NewReader(`{"error":"invalid_token","error_description":"Token signature verification failed"}`))
+			return rsp, nil
+		}
+		rsp.Body = ioutil.NopCloser(strings.NewReader(`{"id":"5O190127TN364715T","status":"COMPLETED"}`))
+		return rsp, nil
+	})}
+	c, err := NewClientWithHttpClient("clientid", "secret", false, hc)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if c.AccessToken != "token-1" || fetches != 1 {
+		t.Fatalf("unexpected token: %s, fetches: %d", c.AccessToken, fetches)
+	}
+
+	// 服务端 AccessToken 失效，返回 401 后刷新并重试一次
+	mu.Lock()
+	valid = "revoked"
+	mu.Unlock()
+	ppRsp, err := c.OrderDetail(ctx, "5O190127TN364715T", nil)
+	if err != nil || ppRsp.Code != Success {
+		t.Fatalf("expected retry success, got: %+v, %v", ppRsp, err)
+	}
+	if c.AccessToken != "token-2" || fetches != 2 {
+		t.Fatalf("unexpected token: %s, fetches: %d", c.AccessToken, fetches)
+	}
+
+	// 即将过期时，并发请求只触发一次刷新
+	c.mu.Lock()
+	c.refreshAt = time.Now().Add(-time.Second)
+	c.mu.Unlock()
+	var wg sync.WaitGroup
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, err := c.OrderDetail(ctx, "5O190127TN364715T", nil); err != nil {
+				t.Error(err)
+			}
+		}()
+	}
+	wg.Wait()
+	if fetches != 3 {
+		t.Fatalf("expected single refresh, fetches: %d", fetches)
+	}
+
+	// 发起刷新的调用方取消 ctx，刷新请求仍继续完成
+	cancelCtx, cancel := context.WithCancel(ctx)
+	cancel()
+	if err = c.refreshAccessToken(cancelCtx); err != context.Canceled {
+		t.Fatalf("expected context canceled, got: %v", err)
+	}
+	c.tokenMu.Lock()
+	call := c.tokenCall
+	c.tokenMu.Unlock()
+	if call != nil {
+		<-call.done
+	}
+	if call == nil || call.err != nil || c.AccessToken != "token-4" || fetches != 4 {
+		t.Fatalf("unexpected refresh result: %+v, token: %s, fetches: %d", call, c.AccessToken, fetches)
+	}
+
+	// 后台刷新 AccessToken 时并发设置 http.Client
+	c.mu.Lock()
+	c.refreshAt = time.Now().Add(-time.Second)
+	c.mu.Unlock()
+	for i := 0; i < 10; i++ {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			c.SetHttpClient(hc)
+		}()
+		go func() {
+			defer wg.Done()
+			if _, err := c.OrderDetail(ctx, "5O190127TN364715T", nil); err != nil {
+				t.Error(err)
+			}
+		}()
+	}
+	wg.Wait()
+}
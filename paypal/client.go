@@ -4,6 +4,8 @@ import (
 	"context"
 	"encoding/json"
 	"net/http"
+	"sync"
+	"time"
 
 	"github.com/yuanqinguo/gopay"
 	"github.com/yuanqinguo/gopay/pkg/xhttp"
@@ -11,6 +13,7 @@ import (
 )
 
 // Client PayPal支付客
+//	AccessToken 由 client 自动管理：过期前主动刷新，接口返回 401 时刷新后重试一次，并发请求只触发一次刷新
 type Client struct {
 	Clientid    string
 	Secret      string
@@ -19,45 +22,77 @@ type Client struct {
 	ExpiresIn   int
 	IsProd      bool
 	DebugSwitch gopay.DebugSwitch
+	hc          *http.Client
+	mu          sync.RWMutex // 保护 hc、Appid、AccessToken、ExpiresIn、refreshAt、expireAt
+	refreshAt   time.Time    // 主动刷新时间
+	expireAt    time.Time    // 过期时间
+	tokenMu     sync.Mutex
+	tokenCall   *tokenCall // 进行中的 AccessToken 刷新
 }
 
 // NewClient 初始化PayPal支付客户端
 func NewClient(clientid, secret string, isProd bool) (client *Client, err error) {
+	return NewClientWithHttpClient(clientid, secret, isProd, nil)
+}
+
+// NewClientWithHttpClient 初始化PayPal支付客户端，并指定所有请求使用的 http.Client
+//	httpClient：自定义的 http.Client，传 nil 则使用默认配置
+func NewClientWithHttpClient(clientid, secret string, isProd bool, httpClient *http.Client) (client *Client, err error) {
 	client = &Client{
 		Clientid:    clientid,
 		Secret:      secret,
 		IsProd:      isProd,
 		DebugSwitch: gopay.DebugOff,
+		hc:          httpClient,
 	}
-	_, err = client.GetAccessToken()
+	_, err = client.GetAccessToken(context.Background())
 	if err != nil {
 		return nil, err
 	}
 	return client, nil
 }
 
+// SetHttpClient 设置自定义的 http.Client，client 的所有请求均通过此 http.Client 发出
+//	可用于代理、连接复用、链路追踪、单元测试替身等场景，不设置则每次请求使用默认配置
+//	注意：NewClient() 初始化时获取 AccessToken 的请求使用默认配置，如需全部请求使用自定义 http.Client，请使用 NewClientWithHttpClient()
+func (c *Client) SetHttpClient(httpClient *http.Client) (client *Client) {
+	c.mu.Lock()
+	c.hc = httpClient
+	c.mu.Unlock()
+	return c
+}
+
+// getHttpClient 获取自定义的 http.Client，后台刷新 AccessToken 时可能与 SetHttpClient() 并发
+func (c *Client) getHttpClient() *http.Client {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.hc
+}
+
 func (c *Client) doPayPalGet(ctx context.Context, uri string) (res *http.Response, bs []byte, err error) {
 	var url = baseUrlProd + uri
 	if !c.IsProd {
 		url = baseUrlSandbox + uri
 	}
-	httpClient := xhttp.NewClient()
-	authHeader := AuthorizationPrefixBearer + c.AccessToken
-	if c.DebugSwitch == gopay.DebugOn {
-		xlog.Debugf("PayPal_Url: %s", url)
-		xlog.Debugf("PayPal_Authorization: %s", authHeader)
-	}
-	httpClient.Header.Add(HeaderAuthorization, authHeader)
-	httpClient.Header.Add("Accept", "*/*")
-	res, bs, errs := httpClient.Type(xhttp.TypeJSON).Get(url).EndBytes()
-	if len(errs) > 0 {
-		return nil, nil, errs[0]
-	}
-	if c.DebugSwitch == gopay.DebugOn {
-		xlog.Debugf("PayPal_Response: %d > %s", res.StatusCode, string(bs))
-		xlog.Debugf("PayPal_Headers: %#v", res.Header)
-	}
-	return res, bs, nil
+	return c.doWithAccessToken(ctx, func(accessToken string) (res *http.Response, bs []byte, err error) {
+		httpClient := xhttp.NewClient().SetHttpClient(c.getHttpClient())
+		authHeader := AuthorizationPrefixBearer + accessToken
+		if c.DebugSwitch == gopay.DebugOn {
+			xlog.Debugf("PayPal_Url: %s", url)
+			xlog.Debugf("PayPal_Authorization: %s", authHeader)
+		}
+		httpClient.Header.Add(HeaderAuthorization, authHeader)
+		httpClient.Header.Add("Accept", "*/*")
+		res, bs, errs := httpClient.Type(xhttp.TypeJSON).Get(url).EndBytesWithContext(ctx)
+		if len(errs) > 0 {
+			return nil, nil, errs[0]
+		}
+		if c.DebugSwitch == gopay.DebugOn {
+			xlog.Debugf("PayPal_Response: %d > %s", res.StatusCode, string(bs))
+			xlog.Debugf("PayPal_Headers: %#v", res.Header)
+		}
+		return res, bs, nil
+	})
 }
 
 func (c *Client) doPayPalPost(ctx context.Context, bm gopay.BodyMap, path string) (res *http.Response, bs []byte, err error) {
@@ -65,23 +100,25 @@ func (c *Client) doPayPalPost(ctx context.Context, bm gopay.BodyMap, path string
 	if !c.IsProd {
 		url = baseUrlSandbox + path
 	}
-	httpClient := xhttp.NewClient()
-	authHeader := AuthorizationPrefixBearer + c.AccessToken
-	if c.DebugSwitch == gopay.DebugOn {
-		xlog.Debugf("PayPal_RequestBody: %s", bm.JsonBody())
-		xlog.Debugf("PayPal_Authorization: %s", authHeader)
-	}
-	httpClient.Header.Add(HeaderAuthorization, authHeader)
-	httpClient.Header.Add("Accept", "*/*")
-	res, bs, errs := httpClient.Type(xhttp.TypeJSON).Post(url).SendBodyMap(bm).EndBytes()
-	if len(errs) > 0 {
-		return nil, nil, errs[0]
-	}
-	if c.DebugSwitch == gopay.DebugOn {
-		xlog.Debugf("PayPal_Response: %d > %s", res.StatusCode, string(bs))
-		xlog.Debugf("PayPal_Headers: %#v", res.Header)
-	}
-	return res, bs, nil
+	return c.doWithAccessToken(ctx, func(accessToken string) (res *http.Response, bs []byte, err error) {
+		httpClient := xhttp.NewClient().SetHttpClient(c.getHttpClient())
+		authHeader := AuthorizationPrefixBearer + accessToken
+		if c.DebugSwitch == gopay.DebugOn {
+			xlog.Debugf("PayPal_RequestBody: %s", bm.JsonBody())
+			xlog.Debugf("PayPal_Authorization: %s", authHeader)
+		}
+		httpClient.Header.Add(HeaderAuthorization, authHeader)
+		httpClient.Header.Add("Accept", "*/*")
+		res, bs, errs := httpClient.Type(xhttp.TypeJSON).Post(url).SendBodyMap(bm).EndBytesWithContext(ctx)
+		if len(errs) > 0 {
+			return nil, nil, errs[0]
+		}
+		if c.DebugSwitch == gopay.DebugOn {
+			xlog.Debugf("PayPal_Response: %d > %s", res.StatusCode, string(bs))
+			xlog.Debugf("PayPal_Headers: %#v", res.Header)
+		}
+		return res, bs, nil
+	})
 }
 
 func (c *Client) doPayPalPatch(ctx context.Context, patchs []*Patch, path string) (res *http.Response, bs []byte, err error) {
@@ -89,22 +126,122 @@ func (c *Client) doPayPalPatch(ctx context.Context, patchs []*Patch, path string
 	if !c.IsProd {
 		url = baseUrlSandbox + path
 	}
-	httpClient := xhttp.NewClient()
-	authHeader := AuthorizationPrefixBearer + c.AccessToken
-	if c.DebugSwitch == gopay.DebugOn {
-		jb, _ := json.Marshal(patchs)
-		xlog.Debugf("PayPal_RequestBody: %s", string(jb))
-		xlog.Debugf("PayPal_Authorization: %s", authHeader)
+	return c.doWithAccessToken(ctx, func(accessToken string) (res *http.Response, bs []byte, err error) {
+		httpClient := xhttp.NewClient().SetHttpClient(c.getHttpClient())
+		authHeader := AuthorizationPrefixBearer + accessToken
+		if c.DebugSwitch == gopay.DebugOn {
+			jb, _ := json.Marshal(patchs)
+			xlog.Debugf("PayPal_RequestBody: %s", string(jb))
+			xlog.Debugf("PayPal_Authorization: %s", authHeader)
+		}
+		httpClient.Header.Add(HeaderAuthorization, authHeader)
+		httpClient.Header.Add("Accept", "*/*")
+		res, bs, errs := httpClient.Type(xhttp.TypeJSON).Patch(url).SendStruct(patchs).EndBytesWithContext(ctx)
+		if len(errs) > 0 {
+			return nil, nil, errs[0]
+		}
+		if c.DebugSwitch == gopay.DebugOn {
+			xlog.Debugf("PayPal_Response: %d > %s", res.StatusCode, string(bs))
+			xlog.Debugf("PayPal_Headers: %#v", res.Header)
+		}
+		return res, bs, nil
+	})
+}
+
+func (c *Client) doPayPalPut(ctx context.Context, bm gopay.BodyMap, path string) (res *http.Response, bs []byte, err error) {
+	var url = baseUrlProd + path
+	if !c.IsProd {
+		url = baseUrlSandbox + path
+	}
+	return c.doWithAccessToken(ctx, func(accessToken string) (res *http.Response, bs []byte, err error) {
+		httpClient := xhttp.NewClient().SetHttpClient(c.getHttpClient())
+		authHeader := AuthorizationPrefixBearer + accessToken
+		if c.DebugSwitch == gopay.DebugOn {
+			xlog.Debugf("PayPal_RequestBody: %s", bm.JsonBody())
+			xlog.Debugf("PayPal_Authorization: %s", authHeader)
+		}
+		httpClient.Header.Add(HeaderAuthorization, authHeader)
+		httpClient.Header.Add("Accept", "*/*")
+		res, bs, errs := httpClient.Type(xhttp.TypeJSON).Put(url).SendBodyMap(bm).EndBytesWithContext(ctx)
+		if len(errs) > 0 {
+			return nil, nil, errs[0]
+		}
+		if c.DebugSwitch == gopay.DebugOn {
+			xlog.Debugf("PayPal_Response: %d > %s", res.StatusCode, string(bs))
+			xlog.Debugf("PayPal_Headers: %#v", res.Header)
+		}
+		return res, bs, nil
+	})
+}
+
+func (c *Client) doPayPalDelete(ctx context.Context, path string) (res *http.Response, bs []byte, err error) {
+	var url = baseUrlProd + path
+	if !c.IsProd {
+		url = baseUrlSandbox + path
 	}
-	httpClient.Header.Add(HeaderAuthorization, authHeader)
-	httpClient.Header.Add("Accept", "*/*")
-	res, bs, errs := httpClient.Type(xhttp.TypeJSON).Patch(url).SendStruct(patchs).EndBytes()
-	if len(errs) > 0 {
-		return nil, nil, errs[0]
+	return c.doWithAccessToken(ctx, func(accessToken string) (res *http.Response, bs []byte, err error) {
+		httpClient := xhttp.NewClient().SetHttpClient(c.getHttpClient())
+		authHeader := AuthorizationPrefixBearer + accessToken
+		if c.DebugSwitch == gopay.DebugOn {
+			xlog.Debugf("PayPal_Url: %s", url)
+			xlog.Debugf("PayPal_Authorization: %s", authHeader)
+		}
+		httpClient.Header.Add(HeaderAuthorization, authHeader)
+		httpClient.Header.Add("Accept", "*/*")
+		res, bs, errs := httpClient.Type(xhttp.TypeJSON).Delete(url).EndBytesWithContext(ctx)
+		if len(errs) > 0 {
+			return nil, nil, errs[0]
+		}
+		if c.DebugSwitch == gopay.DebugOn {
+			xlog.Debugf("PayPal_Response: %d > %s", res.StatusCode, string(bs))
+			xlog.Debugf("PayPal_Headers: %#v", res.Header)
+		}
+		return res, bs, nil
+	})
+}
+
+func (c *Client) doPayPalMultipartPost(ctx context.Context, bm gopay.BodyMap, path string) (res *http.Response, bs []byte, err error) {
+	var url = baseUrlProd + path
+	if !c.IsProd {
+		url = baseUrlSandbox + path
 	}
-	if c.DebugSwitch == gopay.DebugOn {
-		xlog.Debugf("PayPal_Response: %d > %s", res.StatusCode, string(bs))
-		xlog.Debugf("PayPal_Headers: %#v", res.Header)
+	return c.doWithAccessToken(ctx, func(accessToken string) (res *http.Response, bs []byte, err error) {
+		httpClient := xhttp.NewClient().SetHttpClient(c.getHttpClient())
+		authHeader := AuthorizationPrefixBearer + accessToken
+		if c.DebugSwitch == gopay.DebugOn {
+			xlog.Debugf("PayPal_Url: %s", url)
+			xlog.Debugf("PayPal_RequestBody: %s", bm.GetString("input"))
+			xlog.Debugf("PayPal_Authorization: %s", authHeader)
+		}
+		httpClient.Header.Add(HeaderAuthorization, authHeader)
+		httpClient.Header.Add("Accept", "*/*")
+		res, bs, errs := httpClient.Type(xhttp.TypeMultipartFormData).Post(url).SendMultipartBodyMap(bm).EndBytesWithContext(ctx)
+		if len(errs) > 0 {
+			return nil, nil, errs[0]
+		}
+		if c.DebugSwitch == gopay.DebugOn {
+			xlog.Debugf("PayPal_Response: %d > %s", res.StatusCode, string(bs))
+			xlog.Debugf("PayPal_Headers: %#v", res.Header)
+		}
+		return res, bs, nil
+	})
+}
+
+// newError PayPal 接口返回非成功状态码时的错误
+//	errRsp 为 nil 时，仅包含 http 状态码；name 为 INTERNAL_SERVER_ERROR、RATE_LIMIT_REACHED 时，可重试
+func newError(res *http.Response, bs []byte, errRsp *ErrorResponse) (e *gopay.Error) {
+	e = gopay.NewError(gopay.ProviderPayPal, res.StatusCode, gopay.NULL, gopay.NULL)
+	e.RequestId = res.Header.Get(HeaderDebugId)
+	e.Body = string(bs)
+	if errRsp != nil {
+		e.Code = errRsp.Name
+		e.Message = errRsp.Message
+		if errRsp.DebugId != gopay.NULL {
+			e.RequestId = errRsp.DebugId
+		}
+		if errRsp.Name == "INTERNAL_SERVER_ERROR" || errRsp.Name == "RATE_LIMIT_REACHED" {
+			e.Retryable = true
+		}
 	}
-	return res, bs, nil
+	return e
 }
@@ -3,7 +3,11 @@ package paypal
 import (
 	"context"
 	"encoding/base64"
+	"io/ioutil"
+	"net/http"
 	"os"
+	"strings"
+	"sync"
 	"testing"
 
 	"github.com/yuanqinguo/gopay"
@@ -21,8 +25,10 @@ var (
 func TestMain(m *testing.M) {
 	client, err = NewClient(Clientid, Secret, false)
 	if err != nil {
+		// 未配置 Clientid、Secret 或无法访问 PayPal 沙箱时，仅跳过在线测试，离线测试照常运行
 		xlog.Error(err)
-		return
+		client = nil
+		os.Exit(m.Run())
 	}
 	// 打开Debug开关，输出日志
 	client.DebugSwitch = gopay.DebugOff
@@ -33,6 +39,66 @@ func TestMain(m *testing.M) {
 	os.Exit(m.Run())
 }
 
+// skipIfNoClient client 未初始化时跳过需要访问 PayPal 沙箱的在线测试
+func skipIfNoClient(t *testing.T) {
+	if client == nil {
+		t.Skip("paypal client not initialized, skip live test")
+	}
+}
+
+// fakeRoute 模拟 PayPal 接口的响应
+type fakeRoute struct {
+	status int
+	body   string
+}
+
+// fakeReq 模拟 PayPal 网关收到的请求
+type fakeReq struct {
+	query string
+	body  string
+}
+
+// fakePayPal 模拟 PayPal 网关：返回 AccessToken，其他请求优先按 "METHOD path?query"、再按 "METHOD path" 匹配 routes，未匹配的请求测试失败
+//	reqs 记录收到的请求，key 同 routes
+func fakePayPal(t *testing.T, routes map[string]fakeRoute) (c *Client, reqs map[string]*fakeReq) {
+	var mu sync.Mutex
+	reqs = make(map[string]*fakeReq)
+	hc := &http.Client{Transport: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		rsp := &http.Response{StatusCode: http.StatusOK, Header: make(http.Header)}
+		if req.URL.Path == getAccessToken {
+			rsp.Body = ioutil.NopCloser(strings.NewReader(`{"access_token":"token","expires_in":32400}`))
+			return rsp, nil
+		}
+		if req.Header.Get(HeaderAuthorization) != AuthorizationPrefixBearer+"token" {
+			t.Errorf("unexpected authorization: %s", req.Header.Get(HeaderAuthorization))
+		}
+		key := req.Method + " " + req.URL.Path
+		route, ok := routes[key+"?"+req.URL.RawQuery]
+		if !ok {
+			route, ok = routes[key]
+		}
+		if !ok {
+			t.Errorf("unexpected request: %s", key)
+			route = fakeRoute{status: http.StatusNotFound, body: `{"name":"RESOURCE_NOT_FOUND"}`}
+		}
+		var body []byte
+		if req.Body != nil {
+			body, _ = ioutil.ReadAll(req.Body)
+		}
+		mu.Lock()
+		reqs[key] = &fakeReq{query: req.URL.RawQuery, body: string(body)}
+		mu.Unlock()
+		rsp.StatusCode = route.status
+		rsp.Body = ioutil.NopCloser(strings.NewReader(route.body))
+		return rsp, nil
+	})}
+	c, err := NewClientWithHttpClient("clientid", "secret", false, hc)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return c, reqs
+}
+
 func TestBasicAuth(t *testing.T) {
 	uname := "jerry"
 	passwd := "12346"
@@ -3,10 +3,18 @@ package paypal
 const (
 	Success = 0
 
-	HeaderAuthorization       = "Authorization" // 请求头Auth
+	HeaderAuthorization       = "Authorization"   // 请求头Auth
+	HeaderDebugId             = "Paypal-Debug-Id" // 响应头 debug_id
 	AuthorizationPrefixBasic  = "Basic "
 	AuthorizationPrefixBearer = "Bearer "
 
+	// Webhook 通知请求头
+	HeaderTransmissionId   = "Paypal-Transmission-Id"   // 通知 id
+	HeaderTransmissionTime = "Paypal-Transmission-Time" // 通知时间
+	HeaderTransmissionSig  = "Paypal-Transmission-Sig"  // 通知签名
+	HeaderCertUrl          = "Paypal-Cert-Url"          // 验签证书下载地址
+	HeaderAuthAlgo         = "Paypal-Auth-Algo"         // 签名算法，如 SHA256withRSA
+
 	baseUrlProd    = "https://api-m.paypal.com"         // 正式 URL
 	baseUrlSandbox = "https://api-m.sandbox.paypal.com" // 沙箱 URL
 
@@ -29,4 +37,68 @@ const (
 	paymentCaptureRefund    = "/v2/payments/captures/%s/refund"            // capture_id 支付捕获退款 POST
 	paymentRefundDetail     = "/v2/payments/refunds/%s"                    // refund_id 支付退款详情 GET
 
+	// 商品相关
+	productCreate = "/v1/catalogs/products"    // 创建商品 POST
+	productList   = "/v1/catalogs/products"    // 商品列表 GET
+	productDetail = "/v1/catalogs/products/%s" // product_id 商品详情 GET
+	productUpdate = "/v1/catalogs/products/%s" // product_id 更新商品 PATCH
+
+	// 订阅计划相关
+	planCreate        = "/v1/billing/plans"                           // 创建订阅计划 POST
+	planList          = "/v1/billing/plans"                           // 订阅计划列表 GET
+	planDetail        = "/v1/billing/plans/%s"                        // plan_id 订阅计划详情 GET
+	planUpdate        = "/v1/billing/plans/%s"                        // plan_id 更新订阅计划 PATCH
+	planActivate      = "/v1/billing/plans/%s/activate"               // plan_id 激活订阅计划 POST
+	planDeactivate    = "/v1/billing/plans/%s/deactivate"             // plan_id 停用订阅计划 POST
+	planUpdatePricing = "/v1/billing/plans/%s/update-pricing-schemes" // plan_id 更新订阅计划价格 POST
+
+	// 订阅相关
+	subscriptionCreate       = "/v1/billing/subscriptions"                 // 创建订阅 POST
+	subscriptionDetail       = "/v1/billing/subscriptions/%s"              // subscription_id 订阅详情 GET
+	subscriptionRevise       = "/v1/billing/subscriptions/%s/revise"       // subscription_id 修改订阅计划或数量 POST
+	subscriptionSuspend      = "/v1/billing/subscriptions/%s/suspend"      // subscription_id 暂停订阅 POST
+	subscriptionCancel       = "/v1/billing/subscriptions/%s/cancel"       // subscription_id 取消订阅 POST
+	subscriptionActivate     = "/v1/billing/subscriptions/%s/activate"     // subscription_id 激活订阅 POST
+	subscriptionCapture      = "/v1/billing/subscriptions/%s/capture"      // subscription_id 扣取订阅欠款 POST
+	subscriptionTransactions = "/v1/billing/subscriptions/%s/transactions" // subscription_id 订阅交易列表 GET
+
+	// 批量付款相关
+	payoutBatchCreate = "/v1/payments/payouts"                // 创建批量付款 POST
+	payoutBatchDetail = "/v1/payments/payouts/%s"             // payout_batch_id 批量付款详情 GET
+	payoutItemDetail  = "/v1/payments/payouts-item/%s"        // payout_item_id 付款项详情 GET
+	payoutItemCancel  = "/v1/payments/payouts-item/%s/cancel" // payout_item_id 取消未领取的付款项 POST
+
+	// 争议相关
+	disputeList            = "/v1/customer/disputes"                     // 争议列表 GET
+	disputeDetail          = "/v1/customer/disputes/%s"                  // dispute_id 争议详情 GET
+	disputeAcceptClaim     = "/v1/customer/disputes/%s/accept-claim"     // dispute_id 接受索赔 POST
+	disputeProvideEvidence = "/v1/customer/disputes/%s/provide-evidence" // dispute_id 提供证据 POST
+	disputeSendMessage     = "/v1/customer/disputes/%s/send-message"     // dispute_id 发送消息 POST
+	disputeMakeOffer       = "/v1/customer/disputes/%s/make-offer"       // dispute_id 提出和解方案 POST
+	disputeAcceptOffer     = "/v1/customer/disputes/%s/accept-offer"     // dispute_id 接受和解方案 POST
+	disputeEscalate        = "/v1/customer/disputes/%s/escalate"         // dispute_id 升级为索赔 POST
+
+	// 发票相关
+	invoiceGenerateNumber = "/v2/invoicing/generate-next-invoice-number" // 生成发票号 POST
+	invoiceCreate         = "/v2/invoicing/invoices"                     // 创建草稿发票 POST
+	invoiceList           = "/v2/invoicing/invoices"                     // 发票列表 GET
+	invoiceDetail         = "/v2/invoicing/invoices/%s"                  // invoice_id 发票详情 GET
+	invoiceUpdate         = "/v2/invoicing/invoices/%s"                  // invoice_id 更新发票 PUT
+	invoiceDelete         = "/v2/invoicing/invoices/%s"                  // invoice_id 删除草稿发票 DELETE
+	invoiceSend           = "/v2/invoicing/invoices/%s/send"             // invoice_id 发送发票 POST
+	invoiceRemind         = "/v2/invoicing/invoices/%s/remind"           // invoice_id 发送发票提醒 POST
+	invoiceCancel         = "/v2/invoicing/invoices/%s/cancel"           // invoice_id 取消已发送发票 POST
+	invoiceRecordPayment  = "/v2/invoicing/invoices/%s/payments"         // invoice_id 记录发票付款 POST
+	invoiceRecordRefund   = "/v2/invoicing/invoices/%s/refunds"          // invoice_id 记录发票退款 POST
+	invoiceGenerateQRCode = "/v2/invoicing/invoices/%s/generate-qr-code" // invoice_id 生成发票二维码 POST
+	invoiceSearch         = "/v2/invoicing/search-invoices"              // 搜索发票 POST
+
+	// Webhook 相关
+	webhookCreate          = "/v1/notifications/webhooks"                 // 创建 Webhook POST
+	webhookList            = "/v1/notifications/webhooks"                 // Webhook 列表 GET
+	webhookDetail          = "/v1/notifications/webhooks/%s"              // webhook_id Webhook 详情 GET
+	webhookUpdate          = "/v1/notifications/webhooks/%s"              // webhook_id 更新 Webhook PATCH
+	webhookDelete          = "/v1/notifications/webhooks/%s"              // webhook_id 删除 Webhook DELETE
+	webhookVerifySignature = "/v1/notifications/verify-webhook-signature" // 验证 Webhook 签名 POST
+
 )
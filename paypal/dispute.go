@@ -0,0 +1,295 @@
+package paypal
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"github.com/yuanqinguo/gopay"
+)
+
+// 争议列表（List disputes）
+//	bm：可选参数 start_time、disputed_transaction_id、page_size、next_page_token、dispute_state、update_time_before、update_time_after，传 nil 使用默认值
+//	翻页请使用 Response.NextPageToken() 或 client.DisputeListEach()
+//	Code = 0 is success
+//	文档：https://developer.paypal.com/docs/api/customer-disputes/v1/#disputes_list
+func (c *Client) DisputeList(ctx context.Context, bm gopay.BodyMap) (ppRsp *DisputeListRsp, err error) {
+	uri := disputeList
+	if bm != nil {
+		uri += "?" + bm.EncodeURLParams()
+	}
+	res, bs, err := c.doPayPalGet(ctx, uri)
+	if err != nil {
+		return nil, err
+	}
+	ppRsp = &DisputeListRsp{Code: Success}
+	ppRsp.Response = new(DisputeList)
+	if err = json.Unmarshal(bs, ppRsp.Response); err != nil {
+		return nil, fmt.Errorf("json.Unmarshal(%s)：%w", string(bs), err)
+	}
+	if res.StatusCode != http.StatusOK {
+		ppRsp.Code = res.StatusCode
+		ppRsp.Error = string(bs)
+		ppRsp.ErrorResponse = new(ErrorResponse)
+		_ = json.Unmarshal(bs, ppRsp.ErrorResponse)
+		return ppRsp, newError(res, bs, ppRsp.ErrorResponse)
+	}
+	return ppRsp, nil
+}
+
+// 争议详情（Show dispute details）
+//	Code = 0 is success
+//	文档：https://developer.paypal.com/docs/api/customer-disputes/v1/#disputes_get
+func (c *Client) DisputeDetail(ctx context.Context, disputeId string) (ppRsp *DisputeDetailRsp, err error) {
+	if disputeId == gopay.NULL {
+		return nil, errors.New("dispute_id is empty")
+	}
+	url := fmt.Sprintf(disputeDetail, disputeId)
+	res, bs, err := c.doPayPalGet(ctx, url)
+	if err != nil {
+		return nil, err
+	}
+	ppRsp = &DisputeDetailRsp{Code: Success}
+	ppRsp.Response = new(Dispute)
+	if err = json.Unmarshal(bs, ppRsp.Response); err != nil {
+		return nil, fmt.Errorf("json.Unmarshal(%s)：%w", string(bs), err)
+	}
+	if res.StatusCode != http.StatusOK {
+		ppRsp.Code = res.StatusCode
+		ppRsp.Error = string(bs)
+		ppRsp.ErrorResponse = new(ErrorResponse)
+		_ = json.Unmarshal(bs, ppRsp.ErrorResponse)
+		return ppRsp, newError(res, bs, ppRsp.ErrorResponse)
+	}
+	return ppRsp, nil
+}
+
+// 接受索赔（Accept claim）
+//	bm：note 必填，可选 accept_claim_reason、invoice_id、return_shipping_address、refund_amount 等
+//	Code = 0 is success
+//	文档：https://developer.paypal.com/docs/api/customer-disputes/v1/#disputes-actions_accept-claim
+func (c *Client) DisputeAcceptClaim(ctx context.Context, disputeId string, bm gopay.BodyMap) (ppRsp *DisputeActionRsp, err error) {
+	if disputeId == gopay.NULL {
+		return nil, errors.New("dispute_id is empty")
+	}
+	if err = bm.CheckEmptyError("note"); err != nil {
+		return nil, err
+	}
+	url := fmt.Sprintf(disputeAcceptClaim, disputeId)
+	res, bs, err := c.doPayPalPost(ctx, bm, url)
+	if err != nil {
+		return nil, err
+	}
+	ppRsp = &DisputeActionRsp{Code: Success}
+	ppRsp.Response = new(DisputeAction)
+	if err = json.Unmarshal(bs, ppRsp.Response); err != nil {
+		return nil, fmt.Errorf("json.Unmarshal(%s)：%w", string(bs), err)
+	}
+	if res.StatusCode != http.StatusOK {
+		ppRsp.Code = res.StatusCode
+		ppRsp.Error = string(bs)
+		ppRsp.ErrorResponse = new(ErrorResponse)
+		_ = json.Unmarshal(bs, ppRsp.ErrorResponse)
+		return ppRsp, newError(res, bs, ppRsp.ErrorResponse)
+	}
+	return ppRsp, nil
+}
+
+// 提供证据（Provide evidence）
+//	bm：input 为证据信息（evidences 等），证据文件以 *util.File 类型设置，每个 key 对应一个文件
+//	请求以 multipart/form-data 方式发送，单个文件不超过 10MB，总大小不超过 50MB
+//	Code = 0 is success
+//	文档：https://developer.paypal.com/docs/api/customer-disputes/v1/#disputes-actions_provide-evidence
+func (c *Client) DisputeProvideEvidence(ctx context.Context, disputeId string, bm gopay.BodyMap) (ppRsp *DisputeActionRsp, err error) {
+	if disputeId == gopay.NULL {
+		return nil, errors.New("dispute_id is empty")
+	}
+	if err = bm.CheckEmptyError("input"); err != nil {
+		return nil, err
+	}
+	url := fmt.Sprintf(disputeProvideEvidence, disputeId)
+	res, bs, err := c.doPayPalMultipartPost(ctx, bm, url)
+	if err != nil {
+		return nil, err
+	}
+	ppRsp = &DisputeActionRsp{Code: Success}
+	ppRsp.Response = new(DisputeAction)
+	if err = json.Unmarshal(bs, ppRsp.Response); err != nil {
+		return nil, fmt.Errorf("json.Unmarshal(%s)：%w", string(bs), err)
+	}
+	if res.StatusCode != http.StatusOK {
+		ppRsp.Code = res.StatusCode
+		ppRsp.Error = string(bs)
+		ppRsp.ErrorResponse = new(ErrorResponse)
+		_ = json.Unmarshal(bs, ppRsp.ErrorResponse)
+		return ppRsp, newError(res, bs, ppRsp.ErrorResponse)
+	}
+	return ppRsp, nil
+}
+
+// 发送争议消息（Send message about dispute to other party）
+//	bm：message 必填
+//	Code = 0 is success
+//	文档：https://developer.paypal.com/docs/api/customer-disputes/v1/#disputes-actions_send-message
+func (c *Client) DisputeSendMessage(ctx context.Context, disputeId string, bm gopay.BodyMap) (ppRsp *DisputeActionRsp, err error) {
+	if disputeId == gopay.NULL {
+		return nil, errors.New("dispute_id is empty")
+	}
+	if err = bm.CheckEmptyError("message"); err != nil {
+		return nil, err
+	}
+	url := fmt.Sprintf(disputeSendMessage, disputeId)
+	res, bs, err := c.doPayPalPost(ctx, bm, url)
+	if err != nil {
+		return nil, err
+	}
+	ppRsp = &DisputeActionRsp{Code: Success}
+	ppRsp.Response = new(DisputeAction)
+	if err = json.Unmarshal(bs, ppRsp.Response); err != nil {
+		return nil, fmt.Errorf("json.Unmarshal(%s)：%w", string(bs), err)
+	}
+	if res.StatusCode != http.StatusOK {
+		ppRsp.Code = res.StatusCode
+		ppRsp.Error = string(bs)
+		ppRsp.ErrorResponse = new(ErrorResponse)
+		_ = json.Unmarshal(bs, ppRsp.ErrorResponse)
+		return ppRsp, newError(res, bs, ppRsp.ErrorResponse)
+	}
+	return ppRsp, nil
+}
+
+// 提出和解方案（Make offer to resolve dispute）
+//	bm：note、offer_type 必填，offer_type：REFUND、REFUND_WITH_RETURN、REFUND_WITH_REPLACEMENT、REPLACEMENT_WITHOUT_REFUND
+//	Code = 0 is success
+//	文档：https://developer.paypal.com/docs/api/customer-disputes/v1/#disputes-actions_make-offer
+func (c *Client) DisputeMakeOffer(ctx context.Context, disputeId string, bm gopay.BodyMap) (ppRsp *DisputeActionRsp, err error) {
+	if disputeId == gopay.NULL {
+		return nil, errors.New("dispute_id is empty")
+	}
+	if err = bm.CheckEmptyError("note", "offer_type"); err != nil {
+		return nil, err
+	}
+	url := fmt.Sprintf(disputeMakeOffer, disputeId)
+	res, bs, err := c.doPayPalPost(ctx, bm, url)
+	if err != nil {
+		return nil, err
+	}
+	ppRsp = &DisputeActionRsp{Code: Success}
+	ppRsp.Response = new(DisputeAction)
+	if err = json.Unmarshal(bs, ppRsp.Response); err != nil {
+		return nil, fmt.Errorf("json.Unmarshal(%s)：%w", string(bs), err)
+	}
+	if res.StatusCode != http.StatusOK {
+		ppRsp.Code = res.StatusCode
+		ppRsp.Error = string(bs)
+		ppRsp.ErrorResponse = new(ErrorResponse)
+		_ = json.Unmarshal(bs, ppRsp.ErrorResponse)
+		return ppRsp, newError(res, bs, ppRsp.ErrorResponse)
+	}
+	return ppRsp, nil
+}
+
+// 接受和解方案（Accept offer to resolve dispute）
+//	bm：note 必填，买家接受卖家的和解方案
+//	Code = 0 is success
+//	文档：https://developer.paypal.com/docs/api/customer-disputes/v1/#disputes-actions_accept-offer
+func (c *Client) DisputeAcceptOffer(ctx context.Context, disputeId string, bm gopay.BodyMap) (ppRsp *DisputeActionRsp, err error) {
+	if disputeId == gopay.NULL {
+		return nil, errors.New("dispute_id is empty")
+	}
+	if err = bm.CheckEmptyError("note"); err != nil {
+		return nil, err
+	}
+	url := fmt.Sprintf(disputeAcceptOffer, disputeId)
+	res, bs, err := c.doPayPalPost(ctx, bm, url)
+	if err != nil {
+		return nil, err
+	}
+	ppRsp = &DisputeActionRsp{Code: Success}
+	ppRsp.Response = new(DisputeAction)
+	if err = json.Unmarshal(bs, ppRsp.Response); err != nil {
+		return nil, fmt.Errorf("json.Unmarshal(%s)：%w", string(bs), err)
+	}
+	if res.StatusCode != http.StatusOK {
+		ppRsp.Code = res.StatusCode
+		ppRsp.Error = string(bs)
+		ppRsp.ErrorResponse = new(ErrorResponse)
+		_ = json.Unmarshal(bs, ppRsp.ErrorResponse)
+		return ppRsp, newError(res, bs, ppRsp.ErrorResponse)
+	}
+	return ppRsp, nil
+}
+
+// 升级为索赔（Escalate dispute to claim）
+//	bm：note 必填，仅 INQUIRY 阶段的争议可升级
+//	Code = 0 is success
+//	文档：https://developer.paypal.com/docs/api/customer-disputes/v1/#disputes-actions_escalate
+func (c *Client) DisputeEscalate(ctx context.Context, disputeId string, bm gopay.BodyMap) (ppRsp *DisputeActionRsp, err error) {
+	if disputeId == gopay.NULL {
+		return nil, errors.New("dispute_id is empty")
+	}
+	if err = bm.CheckEmptyError("note"); err != nil {
+		return nil, err
+	}
+	url := fmt.Sprintf(disputeEscalate, disputeId)
+	res, bs, err := c.doPayPalPost(ctx, bm, url)
+	if err != nil {
+		return nil, err
+	}
+	ppRsp = &DisputeActionRsp{Code: Success}
+	ppRsp.Response = new(DisputeAction)
+	if err = json.Unmarshal(bs, ppRsp.Response); err != nil {
+		return nil, fmt.Errorf("json.Unmarshal(%s)：%w", string(bs), err)
+	}
+	if res.StatusCode != http.StatusOK {
+		ppRsp.Code = res.StatusCode
+		ppRsp.Error = string(bs)
+		ppRsp.ErrorResponse = new(ErrorResponse)
+		_ = json.Unmarshal(bs, ppRsp.ErrorResponse)
+		return ppRsp, newError(res, bs, ppRsp.ErrorResponse)
+	}
+	return ppRsp, nil
+}
+
+// NextPageToken 获取下一页的 next_page_token，没有下一页时返回空字符串
+func (l *DisputeList) NextPageToken() (token string) {
+	for _, link := range l.Links {
+		if link == nil || link.Rel != "next" {
+			continue
+		}
+		u, err := url.Parse(link.Href)
+		if err != nil {
+			return gopay.NULL
+		}
+		return u.Query().Get("next_page_token")
+	}
+	return gopay.NULL
+}
+
+// DisputeListEach 按页遍历争议列表，fn 返回 false 时停止遍历
+//	bm：同 DisputeList()，next_page_token 由此方法自动设置
+func (c *Client) DisputeListEach(ctx context.Context, bm gopay.BodyMap, fn func(dispute *Dispute) bool) (err error) {
+	query := make(gopay.BodyMap)
+	for k, v := range bm {
+		query[k] = v
+	}
+	for {
+		ppRsp, err := c.DisputeList(ctx, query)
+		if err != nil {
+			return err
+		}
+		for _, dispute := range ppRsp.Response.Items {
+			if !fn(dispute) {
+				return nil
+			}
+		}
+		token := ppRsp.Response.NextPageToken()
+		if token == gopay.NULL || token == query.GetString("next_page_token") {
+			return nil
+		}
+		query.Set("next_page_token", token)
+	}
+}
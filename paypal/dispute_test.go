@@ -0,0 +1,154 @@
+package paypal
+
+import (
+	"io/ioutil"
+	"mime"
+	"mime/multipart"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/yuanqinguo/gopay"
+	"github.com/yuanqinguo/gopay/pkg/util"
+	"github.com/yuanqinguo/gopay/pkg/xlog"
+)
+
+func TestDisputeList(t *testing.T) {
+	skipIfNoClient(t)
+	bm := make(gopay.BodyMap)
+	bm.Set("page_size", 10).
+		Set("dispute_state", "REQUIRED_ACTION")
+
+	ppRsp, err := client.DisputeList(ctx, bm)
+	if err != nil {
+		xlog.Error(err)
+		return
+	}
+	if ppRsp.Code != Success {
+		xlog.Debugf("ppRsp.Code: %+v", ppRsp.Code)
+		xlog.Debugf("ppRsp.Error: %+v", ppRsp.Error)
+		xlog.Debugf("ppRsp.ErrorResponse: %+v", ppRsp.ErrorResponse)
+		return
+	}
+	for _, v := range ppRsp.Response.Items {
+		xlog.Debugf("ppRsp.Response.Item: %+v", v)
+	}
+	xlog.Debugf("ppRsp.Response.NextPageToken: %s", ppRsp.Response.NextPageToken())
+}
+
+func TestDisputeProvideEvidence(t *testing.T) {
+	skipIfNoClient(t)
+	bm := make(gopay.BodyMap)
+	bm.SetBodyMap("input", func(bm gopay.BodyMap) {
+		bm.Set("evidences", []*Evidence{
+			{
+				EvidenceType: "PROOF_OF_FULFILLMENT",
+				EvidenceInfo: &EvidenceInfo{
+					TrackingInfo: []*TrackingInfo{{CarrierName: "FEDEX", TrackingNumber: "122533485"}},
+				},
+				Notes: "Test",
+			},
+		})
+	}).Set("evidence_file", &util.File{Name: "evidence.txt", Content: []byte("proof of fulfillment")})
+
+	ppRsp, err := client.DisputeProvideEvidence(ctx, "PP-D-27803", bm)
+	if err != nil {
+		xlog.Error(err)
+		return
+	}
+	if ppRsp.Code != Success {
+		xlog.Debugf("ppRsp.Code: %+v", ppRsp.Code)
+		xlog.Debugf("ppRsp.Error: %+v", ppRsp.Error)
+		xlog.Debugf("ppRsp.ErrorResponse: %+v", ppRsp.ErrorResponse)
+		return
+	}
+	xlog.Debugf("ppRsp.Response: %+v", ppRsp.Response)
+}
+
+func TestDisputeListEach(t *testing.T) {
+	pages := map[string]string{
+		"":        `{"items":[{"dispute_id":"PP-D-1"},{"dispute_id":"PP-D-2"}],"links":[{"href":"https://api-m.sandbox.paypal.com/v1/customer/disputes?page_size=2&next_page_token=token-2","rel":"next","method":"GET"}]}`,
+		"token-2": `{"items":[{"dispute_id":"PP-D-3"}],"links":[{"href":"https://api-m.sandbox.paypal.com/v1/customer/disputes?page_size=2","rel":"self","method":"GET"}]}`,
+	}
+	hc := &http.Client{Transport: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		rsp := &http.Response{StatusCode: http.StatusOK, Header: make(http.Header)}
+		if req.URL.Path == getAccessToken {
+			rsp.Body = ioutil.NopCloser(strings.NewReader(`{"access_token":"token","expires_in":32400}`))
+			return rsp, nil
+		}
+		if req.URL.Query().Get("page_size") != "2" {
+			t.Errorf("unexpected query: %s", req.URL.RawQuery)
+		}
+		rsp.Body = ioutil.NopCloser(strings.NewReader(pages[req.URL.Query().Get("next_page_token")]))
+		return rsp, nil
+	})}
+	c, err := NewClientWithHttpClient("clientid", "secret", false, hc)
+	if err != nil {
+		t.Fatal(err)
+	}
+	bm := make(gopay.BodyMap)
+	bm.Set("page_size", 2)
+
+	var ids []string
+	if err = c.DisputeListEach(ctx, bm, func(dispute *Dispute) bool {
+		ids = append(ids, dispute.DisputeId)
+		return true
+	}); err != nil {
+		t.Fatal(err)
+	}
+	if strings.Join(ids, ",") != "PP-D-1,PP-D-2,PP-D-3" {
+		t.Fatalf("unexpected disputes: %v", ids)
+	}
+	if bm.GetString("next_page_token") != gopay.NULL {
+		t.Fatal("bm should not be modified")
+	}
+
+	// fn 返回 false 时停止遍历
+	ids = ids[:0]
+	_ = c.DisputeListEach(ctx, bm, func(dispute *Dispute) bool {
+		ids = append(ids, dispute.DisputeId)
+		return false
+	})
+	if len(ids) != 1 {
+		t.Fatalf("unexpected disputes: %v", ids)
+	}
+}
+
+func TestDisputeProvideEvidenceMultipart(t *testing.T) {
+	hc := &http.Client{Transport: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		rsp := &http.Response{StatusCode: http.StatusOK, Header: make(http.Header)}
+		if req.URL.Path == getAccessToken {
+			rsp.Body = ioutil.NopCloser(strings.NewReader(`{"access_token":"token","expires_in":32400}`))
+			return rsp, nil
+		}
+		_, params, err := mime.ParseMediaType(req.Header.Get("Content-Type"))
+		if err != nil {
+			t.Errorf("mime.ParseMediaType: %v", err)
+		}
+		form, err := multipart.NewReader(req.Body, params["boundary"]).ReadForm(1 << 20)
+		if err != nil {
+			t.Errorf("ReadForm: %v", err)
+		}
+		if !strings.Contains(form.Value["input"][0], `"evidence_type":"PROOF_OF_REFUND"`) {
+			t.Errorf("unexpected input: %v", form.Value["input"])
+		}
+		if fhs := form.File["evidence_file"]; len(fhs) != 1 || fhs[0].Filename != "refund.pdf" {
+			t.Errorf("unexpected file: %v", form.File)
+		}
+		rsp.Body = ioutil.NopCloser(strings.NewReader(`{"links":[{"href":"https://api-m.sandbox.paypal.com/v1/customer/disputes/PP-D-1","rel":"self","method":"GET"}]}`))
+		return rsp, nil
+	})}
+	c, err := NewClientWithHttpClient("clientid", "secret", false, hc)
+	if err != nil {
+		t.Fatal(err)
+	}
+	bm := make(gopay.BodyMap)
+	bm.SetBodyMap("input", func(bm gopay.BodyMap) {
+		bm.Set("evidences", []*Evidence{{EvidenceType: "PROOF_OF_REFUND"}})
+	}).Set("evidence_file", &util.File{Name: "refund.pdf", Content: []byte("%PDF-1.4")})
+
+	ppRsp, err := c.DisputeProvideEvidence(ctx, "PP-D-1", bm)
+	if err != nil || ppRsp.Code != Success || len(ppRsp.Response.Links) != 1 {
+		t.Fatalf("unexpected response: %+v, %v", ppRsp, err)
+	}
+}
@@ -0,0 +1,396 @@
+package paypal
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"mime"
+	"mime/multipart"
+	"net/http"
+	"strings"
+
+	"github.com/yuanqinguo/gopay"
+)
+
+// 生成发票号（Generate invoice number）
+//	Code = 0 is success
+//	文档：https://developer.paypal.com/docs/api/invoicing/v2/#invoicing_generate-next-invoice-number
+func (c *Client) GenerateInvoiceNumber(ctx context.Context) (ppRsp *GenerateInvoiceNumberRsp, err error) {
+	res, bs, err := c.doPayPalPost(ctx, nil, invoiceGenerateNumber)
+	if err != nil {
+		return nil, err
+	}
+	ppRsp = &GenerateInvoiceNumberRsp{Code: Success}
+	ppRsp.Response = new(InvoiceNumber)
+	if err = json.Unmarshal(bs, ppRsp.Response); err != nil {
+		return nil, fmt.Errorf("json.Unmarshal(%s)：%w", string(bs), err)
+	}
+	if res.StatusCode != http.StatusOK {
+		ppRsp.Code = res.StatusCode
+		ppRsp.Error = string(bs)
+		ppRsp.ErrorResponse = new(ErrorResponse)
+		_ = json.Unmarshal(bs, ppRsp.ErrorResponse)
+		return ppRsp, newError(res, bs, ppRsp.ErrorResponse)
+	}
+	return ppRsp, nil
+}
+
+// 创建草稿发票（Create draft invoice）
+//	bm：detail 必填，可选 invoicer、primary_recipients、items、configuration、amount 等
+//	Response.Href 为草稿发票地址，末段为发票 id，可调用 client.InvoiceDetail() 查询发票详情
+//	Code = 0 is success
+//	文档：https://developer.paypal.com/docs/api/invoicing/v2/#invoices_create
+func (c *Client) CreateInvoice(ctx context.Context, bm gopay.BodyMap) (ppRsp *CreateInvoiceRsp, err error) {
+	if err = bm.CheckEmptyError("detail"); err != nil {
+		return nil, err
+	}
+	res, bs, err := c.doPayPalPost(ctx, bm, invoiceCreate)
+	if err != nil {
+		return nil, err
+	}
+	ppRsp = &CreateInvoiceRsp{Code: Success}
+	ppRsp.Response = new(Link)
+	if err = json.Unmarshal(bs, ppRsp.Response); err != nil {
+		return nil, fmt.Errorf("json.Unmarshal(%s)：%w", string(bs), err)
+	}
+	if res.StatusCode != http.StatusCreated {
+		ppRsp.Code = res.StatusCode
+		ppRsp.Error = string(bs)
+		ppRsp.ErrorResponse = new(ErrorResponse)
+		_ = json.Unmarshal(bs, ppRsp.ErrorResponse)
+		return ppRsp, newError(res, bs, ppRsp.ErrorResponse)
+	}
+	return ppRsp, nil
+}
+
+// 发票列表（List invoices）
+//	bm：可选参数 page、page_size、total_required、fields，传 nil 使用默认值
+//	Code = 0 is success
+//	文档：https://developer.paypal.com/docs/api/invoicing/v2/#invoices_list
+func (c *Client) InvoiceList(ctx context.Context, bm gopay.BodyMap) (ppRsp *InvoiceListRsp, err error) {
+	uri := invoiceList
+	if bm != nil {
+		uri += "?" + bm.EncodeURLParams()
+	}
+	res, bs, err := c.doPayPalGet(ctx, uri)
+	if err != nil {
+		return nil, err
+	}
+	ppRsp = &InvoiceListRsp{Code: Success}
+	ppRsp.Response = new(InvoiceList)
+	if err = json.Unmarshal(bs, ppRsp.Response); err != nil {
+		return nil, fmt.Errorf("json.Unmarshal(%s)：%w", string(bs), err)
+	}
+	if res.StatusCode != http.StatusOK {
+		ppRsp.Code = res.StatusCode
+		ppRsp.Error = string(bs)
+		ppRsp.ErrorResponse = new(ErrorResponse)
+		_ = json.Unmarshal(bs, ppRsp.ErrorResponse)
+		return ppRsp, newError(res, bs, ppRsp.ErrorResponse)
+	}
+	return ppRsp, nil
+}
+
+// 发票详情（Show invoice details）
+//	Code = 0 is success
+//	文档：https://developer.paypal.com/docs/api/invoicing/v2/#invoices_get
+func (c *Client) InvoiceDetail(ctx context.Context, invoiceId string) (ppRsp *InvoiceDetailRsp, err error) {
+	if invoiceId == gopay.NULL {
+		return nil, errors.New("invoice_id is empty")
+	}
+	url := fmt.Sprintf(invoiceDetail, invoiceId)
+	res, bs, err := c.doPayPalGet(ctx, url)
+	if err != nil {
+		return nil, err
+	}
+	ppRsp = &InvoiceDetailRsp{Code: Success}
+	ppRsp.Response = new(Invoice)
+	if err = json.Unmarshal(bs, ppRsp.Response); err != nil {
+		return nil, fmt.Errorf("json.Unmarshal(%s)：%w", string(bs), err)
+	}
+	if res.StatusCode != http.StatusOK {
+		ppRsp.Code = res.StatusCode
+		ppRsp.Error = string(bs)
+		ppRsp.ErrorResponse = new(ErrorResponse)
+		_ = json.Unmarshal(bs, ppRsp.ErrorResponse)
+		return ppRsp, newError(res, bs, ppRsp.ErrorResponse)
+	}
+	return ppRsp, nil
+}
+
+// 更新发票（Fully update invoice）
+//	bm：完整的发票信息，detail 必填，未传的字段将被清空
+//	Code = 0 is success
+//	文档：https://developer.paypal.com/docs/api/invoicing/v2/#invoices_update
+func (c *Client) UpdateInvoice(ctx context.Context, invoiceId string, bm gopay.BodyMap) (ppRsp *UpdateInvoiceRsp, err error) {
+	if invoiceId == gopay.NULL {
+		return nil, errors.New("invoice_id is empty")
+	}
+	if err = bm.CheckEmptyError("detail"); err != nil {
+		return nil, err
+	}
+	url := fmt.Sprintf(invoiceUpdate, invoiceId)
+	res, bs, err := c.doPayPalPut(ctx, bm, url)
+	if err != nil {
+		return nil, err
+	}
+	ppRsp = &UpdateInvoiceRsp{Code: Success}
+	ppRsp.Response = new(Invoice)
+	if err = json.Unmarshal(bs, ppRsp.Response); err != nil {
+		return nil, fmt.Errorf("json.Unmarshal(%s)：%w", string(bs), err)
+	}
+	if res.StatusCode != http.StatusOK {
+		ppRsp.Code = res.StatusCode
+		ppRsp.Error = string(bs)
+		ppRsp.ErrorResponse = new(ErrorResponse)
+		_ = json.Unmarshal(bs, ppRsp.ErrorResponse)
+		return ppRsp, newError(res, bs, ppRsp.ErrorResponse)
+	}
+	return ppRsp, nil
+}
+
+// 删除草稿发票（Delete invoice）
+//	仅 DRAFT、SCHEDULED 状态的发票可删除
+//	Code = 0 is success
+//	文档：https://developer.paypal.com/docs/api/invoicing/v2/#invoices_delete
+func (c *Client) DeleteInvoice(ctx context.Context, invoiceId string) (ppRsp *EmptyRsp, err error) {
+	if invoiceId == gopay.NULL {
+		return nil, errors.New("invoice_id is empty")
+	}
+	url := fmt.Sprintf(invoiceDelete, invoiceId)
+	res, bs, err := c.doPayPalDelete(ctx, url)
+	if err != nil {
+		return nil, err
+	}
+	ppRsp = &EmptyRsp{Code: Success}
+	if res.StatusCode != http.StatusNoContent {
+		ppRsp.Code = res.StatusCode
+		ppRsp.Error = string(bs)
+		ppRsp.ErrorResponse = new(ErrorResponse)
+		_ = json.Unmarshal(bs, ppRsp.ErrorResponse)
+		return ppRsp, newError(res, bs, ppRsp.ErrorResponse)
+	}
+	return ppRsp, nil
+}
+
+// 发送发票（Send invoice）
+//	bm：可选参数 subject、note、send_to_invoicer、send_to_recipient、additional_recipients，传 nil 使用默认值
+//	发票日期为未来日期时，发票进入 SCHEDULED 状态，返回 202
+//	Code = 0 is success
+//	文档：https://developer.paypal.com/docs/api/invoicing/v2/#invoices_send
+func (c *Client) InvoiceSend(ctx context.Context, invoiceId string, bm gopay.BodyMap) (ppRsp *InvoiceSendRsp, err error) {
+	if invoiceId == gopay.NULL {
+		return nil, errors.New("invoice_id is empty")
+	}
+	url := fmt.Sprintf(invoiceSend, invoiceId)
+	res, bs, err := c.doPayPalPost(ctx, bm, url)
+	if err != nil {
+		return nil, err
+	}
+	ppRsp = &InvoiceSendRsp{Code: Success}
+	if len(bs) > 0 {
+		ppRsp.Response = new(Link)
+		if err = json.Unmarshal(bs, ppRsp.Response); err != nil {
+			return nil, fmt.Errorf("json.Unmarshal(%s)：%w", string(bs), err)
+		}
+	}
+	if res.StatusCode != http.StatusOK && res.StatusCode != http.StatusAccepted {
+		ppRsp.Code = res.StatusCode
+		ppRsp.Error = string(bs)
+		ppRsp.ErrorResponse = new(ErrorResponse)
+		_ = json.Unmarshal(bs, ppRsp.ErrorResponse)
+		return ppRsp, newError(res, bs, ppRsp.ErrorResponse)
+	}
+	return ppRsp, nil
+}
+
+// 发送发票提醒（Send invoice reminder）
+//	bm：可选参数 subject、note、send_to_invoicer、send_to_recipient、additional_recipients，传 nil 使用默认值
+//	Code = 0 is success
+//	文档：https://developer.paypal.com/docs/api/invoicing/v2/#invoices_remind
+func (c *Client) InvoiceRemind(ctx context.Context, invoiceId string, bm gopay.BodyMap) (ppRsp *EmptyRsp, err error) {
+	if invoiceId == gopay.NULL {
+		return nil, errors.New("invoice_id is empty")
+	}
+	url := fmt.Sprintf(invoiceRemind, invoiceId)
+	res, bs, err := c.doPayPalPost(ctx, bm, url)
+	if err != nil {
+		return nil, err
+	}
+	ppRsp = &EmptyRsp{Code: Success}
+	if res.StatusCode != http.StatusNoContent {
+		ppRsp.Code = res.StatusCode
+		ppRsp.Error = string(bs)
+		ppRsp.ErrorResponse = new(ErrorResponse)
+		_ = json.Unmarshal(bs, ppRsp.ErrorResponse)
+		return ppRsp, newError(res, bs, ppRsp.ErrorResponse)
+	}
+	return ppRsp, nil
+}
+
+// 取消已发送发票（Cancel sent invoice）
+//	bm：可选参数 subject、note、send_to_invoicer、send_to_recipient、additional_recipients，传 nil 使用默认值
+//	Code = 0 is success
+//	文档：https://developer.paypal.com/docs/api/invoicing/v2/#invoices_cancel
+func (c *Client) InvoiceCancel(ctx context.Context, invoiceId string, bm gopay.BodyMap) (ppRsp *EmptyRsp, err error) {
+	if invoiceId == gopay.NULL {
+		return nil, errors.New("invoice_id is empty")
+	}
+	url := fmt.Sprintf(invoiceCancel, invoiceId)
+	res, bs, err := c.doPayPalPost(ctx, bm, url)
+	if err != nil {
+		return nil, err
+	}
+	ppRsp = &EmptyRsp{Code: Success}
+	if res.StatusCode != http.StatusNoContent {
+		ppRsp.Code = res.StatusCode
+		ppRsp.Error = string(bs)
+		ppRsp.ErrorResponse = new(ErrorResponse)
+		_ = json.Unmarshal(bs, ppRsp.ErrorResponse)
+		return ppRsp, newError(res, bs, ppRsp.ErrorResponse)
+	}
+	return ppRsp, nil
+}
+
+// 记录发票付款（Record payment for invoice）
+//	bm：method 必填，可选 payment_id、payment_date、amount、note、shipping_info
+//	记录 PayPal 之外（如现金、银行转账）收到的付款
+//	Code = 0 is success
+//	文档：https://developer.paypal.com/docs/api/invoicing/v2/#invoices_payments
+func (c *Client) InvoiceRecordPayment(ctx context.Context, invoiceId string, bm gopay.BodyMap) (ppRsp *InvoiceRecordPaymentRsp, err error) {
+	if invoiceId == gopay.NULL {
+		return nil, errors.New("invoice_id is empty")
+	}
+	if err = bm.CheckEmptyError("method"); err != nil {
+		return nil, err
+	}
+	url := fmt.Sprintf(invoiceRecordPayment, invoiceId)
+	res, bs, err := c.doPayPalPost(ctx, bm, url)
+	if err != nil {
+		return nil, err
+	}
+	ppRsp = &InvoiceRecordPaymentRsp{Code: Success}
+	ppRsp.Response = new(InvoicePaymentId)
+	if err = json.Unmarshal(bs, ppRsp.Response); err != nil {
+		return nil, fmt.Errorf("json.Unmarshal(%s)：%w", string(bs), err)
+	}
+	if res.StatusCode != http.StatusOK {
+		ppRsp.Code = res.StatusCode
+		ppRsp.Error = string(bs)
+		ppRsp.ErrorResponse = new(ErrorResponse)
+		_ = json.Unmarshal(bs, ppRsp.ErrorResponse)
+		return ppRsp, newError(res, bs, ppRsp.ErrorResponse)
+	}
+	return ppRsp, nil
+}
+
+// 记录发票退款（Record refund for invoice）
+//	bm：method 必填，可选 refund_date、amount
+//	记录 PayPal 之外退还的款项
+//	Code = 0 is success
+//	文档：https://developer.paypal.com/docs/api/invoicing/v2/#invoices_refunds
+func (c *Client) InvoiceRecordRefund(ctx context.Context, invoiceId string, bm gopay.BodyMap) (ppRsp *InvoiceRecordRefundRsp, err error) {
+	if invoiceId == gopay.NULL {
+		return nil, errors.New("invoice_id is empty")
+	}
+	if err = bm.CheckEmptyError("method"); err != nil {
+		return nil, err
+	}
+	url := fmt.Sprintf(invoiceRecordRefund, invoiceId)
+	res, bs, err := c.doPayPalPost(ctx, bm, url)
+	if err != nil {
+		return nil, err
+	}
+	ppRsp = &InvoiceRecordRefundRsp{Code: Success}
+	ppRsp.Response = new(RefundId)
+	if err = json.Unmarshal(bs, ppRsp.Response); err != nil {
+		return nil, fmt.Errorf("json.Unmarshal(%s)：%w", string(bs), err)
+	}
+	if res.StatusCode != http.StatusOK {
+		ppRsp.Code = res.StatusCode
+		ppRsp.Error = string(bs)
+		ppRsp.ErrorResponse = new(ErrorResponse)
+		_ = json.Unmarshal(bs, ppRsp.ErrorResponse)
+		return ppRsp, newError(res, bs, ppRsp.ErrorResponse)
+	}
+	return ppRsp, nil
+}
+
+// 搜索发票（Search for invoices）
+//	query：可选参数 page、page_size、total_required，传 nil 使用默认值
+//	bm：搜索条件，如 recipient_email、status、invoice_number、invoice_date_range、currency_code 等
+//	Code = 0 is success
+//	文档：https://developer.paypal.com/docs/api/invoicing/v2/#search-invoices_search-invoices
+func (c *Client) SearchInvoice(ctx context.Context, query, bm gopay.BodyMap) (ppRsp *InvoiceListRsp, err error) {
+	uri := invoiceSearch
+	if query != nil {
+		uri += "?" + query.EncodeURLParams()
+	}
+	res, bs, err := c.doPayPalPost(ctx, bm, uri)
+	if err != nil {
+		return nil, err
+	}
+	ppRsp = &InvoiceListRsp{Code: Success}
+	ppRsp.Response = new(InvoiceList)
+	if err = json.Unmarshal(bs, ppRsp.Response); err != nil {
+		return nil, fmt.Errorf("json.Unmarshal(%s)：%w", string(bs), err)
+	}
+	if res.StatusCode != http.StatusOK {
+		ppRsp.Code = res.StatusCode
+		ppRsp.Error = string(bs)
+		ppRsp.ErrorResponse = new(ErrorResponse)
+		_ = json.Unmarshal(bs, ppRsp.ErrorResponse)
+		return ppRsp, newError(res, bs, ppRsp.ErrorResponse)
+	}
+	return ppRsp, nil
+}
+
+// 生成发票二维码（Generate QR code）
+//	bm：可选参数 width、height、action（pay、details），传 nil 使用默认值
+//	Response.Image 为 Base64 编码的 PNG 图片
+//	Code = 0 is success
+//	文档：https://developer.paypal.com/docs/api/invoicing/v2/#invoices_generate-qr-code
+func (c *Client) InvoiceGenerateQRCode(ctx context.Context, invoiceId string, bm gopay.BodyMap) (ppRsp *InvoiceQRCodeRsp, err error) {
+	if invoiceId == gopay.NULL {
+		return nil, errors.New("invoice_id is empty")
+	}
+	url := fmt.Sprintf(invoiceGenerateQRCode, invoiceId)
+	res, bs, err := c.doPayPalPost(ctx, bm, url)
+	if err != nil {
+		return nil, err
+	}
+	ppRsp = &InvoiceQRCodeRsp{Code: Success}
+	if res.StatusCode != http.StatusOK {
+		ppRsp.Code = res.StatusCode
+		ppRsp.Error = string(bs)
+		ppRsp.ErrorResponse = new(ErrorResponse)
+		_ = json.Unmarshal(bs, ppRsp.ErrorResponse)
+		return ppRsp, newError(res, bs, ppRsp.ErrorResponse)
+	}
+	image, err := qrCodeImage(res.Header.Get("Content-Type"), bs)
+	if err != nil {
+		return nil, err
+	}
+	ppRsp.Response = &InvoiceQRCode{Image: image}
+	return ppRsp, nil
+}
+
+// qrCodeImage 二维码接口返回 multipart 时取第一部分，否则为 Base64 图片原文
+func qrCodeImage(contentType string, bs []byte) (image string, err error) {
+	mediaType, params, err := mime.ParseMediaType(contentType)
+	if err != nil || !strings.HasPrefix(mediaType, "multipart/") {
+		return strings.TrimSpace(string(bs)), nil
+	}
+	part, err := multipart.NewReader(bytes.NewReader(bs), params["boundary"]).NextPart()
+	if err != nil {
+		return gopay.NULL, fmt.Errorf("multipart.NextPart(%s)：%w", string(bs), err)
+	}
+	image64, err := ioutil.ReadAll(part)
+	if err != nil {
+		return gopay.NULL, fmt.Errorf("ioutil.ReadAll：%w", err)
+	}
+	return strings.TrimSpace(string(image64)), nil
+}
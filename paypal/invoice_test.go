@@ -0,0 +1,106 @@
+package paypal
+
+import (
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/yuanqinguo/gopay"
+	"github.com/yuanqinguo/gopay/pkg/xlog"
+)
+
+func TestCreateInvoice(t *testing.T) {
+	skipIfNoClient(t)
+	numRsp, err := client.GenerateInvoiceNumber(ctx)
+	if err != nil {
+		xlog.Error(err)
+		return
+	}
+	items := []*InvoiceItem{
+		{
+			Name:          "Yoga Mat",
+			Quantity:      "1",
+			UnitAmount:    &Amount{CurrencyCode: "USD", Value: "50.00"},
+			UnitOfMeasure: "QUANTITY",
+		},
+	}
+	bm := make(gopay.BodyMap)
+	bm.SetBodyMap("detail", func(bm gopay.BodyMap) {
+		bm.Set("invoice_number", numRsp.Response.InvoiceNumber).
+			Set("currency_code", "USD").
+			Set("note", "Thank you for your business.").
+			Set("payment_term", &PaymentTerm{TermType: "NET_10"})
+	}).Set("primary_recipients", []*InvoiceRecipient{
+		{BillingInfo: &InvoiceContact{EmailAddress: "bill-me@example.com"}},
+	}).Set("items", items)
+
+	ppRsp, err := client.CreateInvoice(ctx, bm)
+	if err != nil {
+		xlog.Error(err)
+		return
+	}
+	if ppRsp.Code != Success {
+		xlog.Debugf("ppRsp.Code: %+v", ppRsp.Code)
+		xlog.Debugf("ppRsp.Error: %+v", ppRsp.Error)
+		xlog.Debugf("ppRsp.ErrorResponse: %+v", ppRsp.ErrorResponse)
+		return
+	}
+	xlog.Debugf("ppRsp.Response: %+v", ppRsp.Response)
+}
+
+func TestSearchInvoice(t *testing.T) {
+	skipIfNoClient(t)
+	query := make(gopay.BodyMap)
+	query.Set("page", 1).
+		Set("page_size", 10).
+		Set("total_required", true)
+	bm := make(gopay.BodyMap)
+	bm.Set("status", []string{"SENT", "UNPAID"})
+
+	ppRsp, err := client.SearchInvoice(ctx, query, bm)
+	if err != nil {
+		xlog.Error(err)
+		return
+	}
+	if ppRsp.Code != Success {
+		xlog.Debugf("ppRsp.Code: %+v", ppRsp.Code)
+		xlog.Debugf("ppRsp.Error: %+v", ppRsp.Error)
+		xlog.Debugf("ppRsp.ErrorResponse: %+v", ppRsp.ErrorResponse)
+		return
+	}
+	for _, v := range ppRsp.Response.Items {
+		xlog.Debugf("ppRsp.Response.Item: %+v", v)
+	}
+}
+
+func TestInvoiceGenerateQRCode(t *testing.T) {
+	qrCode := "iVBORw0KGgoAAAANSUhEUgAAAAEAAAABCAYAAAAfFcSJAAAADUlEQVR42mNk"
+	bodys := map[string]string{
+		"text/plain":                     qrCode + "\r\n",
+		"multipart/related; boundary=qr": "--qr\r\nContent-Type: text/plain\r\n\r\n" + qrCode + "\r\n--qr--\r\n",
+	}
+	for contentType, body := range bodys {
+		hc := &http.Client{Transport: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+			rsp := &http.Response{StatusCode: http.StatusOK, Header: make(http.Header)}
+			if req.URL.Path == getAccessToken {
+				rsp.Body = ioutil.NopCloser(strings.NewReader(`{"access_token":"token","expires_in":32400}`))
+				return rsp, nil
+			}
+			rsp.Header.Set("Content-Type", contentType)
+			rsp.Body = ioutil.NopCloser(strings.NewReader(body))
+			return rsp, nil
+		})}
+		c, err := NewClientWithHttpClient("clientid", "secret", false, hc)
+		if err != nil {
+			t.Fatal(err)
+		}
+		ppRsp, err := c.InvoiceGenerateQRCode(ctx, "INV2-Z56S-5LLA-Q52L-CPZ5", nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if ppRsp.Response.Image != qrCode {
+			t.Fatalf("[%s] unexpected image: %q", contentType, ppRsp.Response.Image)
+		}
+	}
+}
@@ -101,6 +101,230 @@ type PaymentRefundDetailRsp struct {
 	Response      *PaymentCaptureRefund `json:"response,omitempty"`
 }
 
+type CreateWebhookRsp struct {
+	Code          int            `json:"-"`
+	Error         string         `json:"-"`
+	ErrorResponse *ErrorResponse `json:"-"`
+	Response      *Webhook       `json:"response,omitempty"`
+}
+
+type WebhookListRsp struct {
+	Code          int            `json:"-"`
+	Error         string         `json:"-"`
+	ErrorResponse *ErrorResponse `json:"-"`
+	Response      *WebhookList   `json:"response,omitempty"`
+}
+
+type WebhookDetailRsp struct {
+	Code          int            `json:"-"`
+	Error         string         `json:"-"`
+	ErrorResponse *ErrorResponse `json:"-"`
+	Response      *Webhook       `json:"response,omitempty"`
+}
+
+type UpdateWebhookRsp struct {
+	Code          int            `json:"-"`
+	Error         string         `json:"-"`
+	ErrorResponse *ErrorResponse `json:"-"`
+	Response      *Webhook       `json:"response,omitempty"`
+}
+
+type VerifyWebhookSignatureRsp struct {
+	Code          int                     `json:"-"`
+	Error         string                  `json:"-"`
+	ErrorResponse *ErrorResponse          `json:"-"`
+	Response      *VerifyWebhookSignature `json:"response,omitempty"`
+}
+
+type CreateProductRsp struct {
+	Code          int            `json:"-"`
+	Error         string         `json:"-"`
+	ErrorResponse *ErrorResponse `json:"-"`
+	Response      *Product       `json:"response,omitempty"`
+}
+
+type ProductListRsp struct {
+	Code          int            `json:"-"`
+	Error         string         `json:"-"`
+	ErrorResponse *ErrorResponse `json:"-"`
+	Response      *ProductList   `json:"response,omitempty"`
+}
+
+type ProductDetailRsp struct {
+	Code          int            `json:"-"`
+	Error         string         `json:"-"`
+	ErrorResponse *ErrorResponse `json:"-"`
+	Response      *Product       `json:"response,omitempty"`
+}
+
+type CreateBillingPlanRsp struct {
+	Code          int            `json:"-"`
+	Error         string         `json:"-"`
+	ErrorResponse *ErrorResponse `json:"-"`
+	Response      *BillingPlan   `json:"response,omitempty"`
+}
+
+type BillingPlanListRsp struct {
+	Code          int              `json:"-"`
+	Error         string           `json:"-"`
+	ErrorResponse *ErrorResponse   `json:"-"`
+	Response      *BillingPlanList `json:"response,omitempty"`
+}
+
+type BillingPlanDetailRsp struct {
+	Code          int            `json:"-"`
+	Error         string         `json:"-"`
+	ErrorResponse *ErrorResponse `json:"-"`
+	Response      *BillingPlan   `json:"response,omitempty"`
+}
+
+type CreateSubscriptionRsp struct {
+	Code          int            `json:"-"`
+	Error         string         `json:"-"`
+	ErrorResponse *ErrorResponse `json:"-"`
+	Response      *Subscription  `json:"response,omitempty"`
+}
+
+type SubscriptionDetailRsp struct {
+	Code          int            `json:"-"`
+	Error         string         `json:"-"`
+	ErrorResponse *ErrorResponse `json:"-"`
+	Response      *Subscription  `json:"response,omitempty"`
+}
+
+type SubscriptionReviseRsp struct {
+	Code          int                 `json:"-"`
+	Error         string              `json:"-"`
+	ErrorResponse *ErrorResponse      `json:"-"`
+	Response      *SubscriptionRevise `json:"response,omitempty"`
+}
+
+type SubscriptionCaptureRsp struct {
+	Code          int                      `json:"-"`
+	Error         string                   `json:"-"`
+	ErrorResponse *ErrorResponse           `json:"-"`
+	Response      *SubscriptionTransaction `json:"response,omitempty"`
+}
+
+type SubscriptionTransactionListRsp struct {
+	Code          int                          `json:"-"`
+	Error         string                       `json:"-"`
+	ErrorResponse *ErrorResponse               `json:"-"`
+	Response      *SubscriptionTransactionList `json:"response,omitempty"`
+}
+
+type CreatePayoutBatchRsp struct {
+	Code          int            `json:"-"`
+	Error         string         `json:"-"`
+	ErrorResponse *ErrorResponse `json:"-"`
+	Response      *PayoutBatch   `json:"response,omitempty"`
+}
+
+type PayoutBatchDetailRsp struct {
+	Code          int            `json:"-"`
+	Error         string         `json:"-"`
+	ErrorResponse *ErrorResponse `json:"-"`
+	Response      *PayoutBatch   `json:"response,omitempty"`
+}
+
+type PayoutItemDetailRsp struct {
+	Code          int               `json:"-"`
+	Error         string            `json:"-"`
+	ErrorResponse *ErrorResponse    `json:"-"`
+	Response      *PayoutItemDetail `json:"response,omitempty"`
+}
+
+type CancelPayoutItemRsp struct {
+	Code          int               `json:"-"`
+	Error         string            `json:"-"`
+	ErrorResponse *ErrorResponse    `json:"-"`
+	Response      *PayoutItemDetail `json:"response,omitempty"`
+}
+
+type DisputeListRsp struct {
+	Code          int            `json:"-"`
+	Error         string         `json:"-"`
+	ErrorResponse *ErrorResponse `json:"-"`
+	Response      *DisputeList   `json:"response,omitempty"`
+}
+
+type DisputeDetailRsp struct {
+	Code          int            `json:"-"`
+	Error         string         `json:"-"`
+	ErrorResponse *ErrorResponse `json:"-"`
+	Response      *Dispute       `json:"response,omitempty"`
+}
+
+type DisputeActionRsp struct {
+	Code          int            `json:"-"`
+	Error         string         `json:"-"`
+	ErrorResponse *ErrorResponse `json:"-"`
+	Response      *DisputeAction `json:"response,omitempty"`
+}
+
+type GenerateInvoiceNumberRsp struct {
+	Code          int            `json:"-"`
+	Error         string         `json:"-"`
+	ErrorResponse *ErrorResponse `json:"-"`
+	Response      *InvoiceNumber `json:"response,omitempty"`
+}
+
+type CreateInvoiceRsp struct {
+	Code          int            `json:"-"`
+	Error         string         `json:"-"`
+	ErrorResponse *ErrorResponse `json:"-"`
+	Response      *Link          `json:"response,omitempty"`
+}
+
+type InvoiceListRsp struct {
+	Code          int            `json:"-"`
+	Error         string         `json:"-"`
+	ErrorResponse *ErrorResponse `json:"-"`
+	Response      *InvoiceList   `json:"response,omitempty"`
+}
+
+type InvoiceDetailRsp struct {
+	Code          int            `json:"-"`
+	Error         string         `json:"-"`
+	ErrorResponse *ErrorResponse `json:"-"`
+	Response      *Invoice       `json:"response,omitempty"`
+}
+
+type UpdateInvoiceRsp struct {
+	Code          int            `json:"-"`
+	Error         string         `json:"-"`
+	ErrorResponse *ErrorResponse `json:"-"`
+	Response      *Invoice       `json:"response,omitempty"`
+}
+
+type InvoiceSendRsp struct {
+	Code          int            `json:"-"`
+	Error         string         `json:"-"`
+	ErrorResponse *ErrorResponse `json:"-"`
+	Response      *Link          `json:"response,omitempty"`
+}
+
+type InvoiceRecordPaymentRsp struct {
+	Code          int               `json:"-"`
+	Error         string            `json:"-"`
+	ErrorResponse *ErrorResponse    `json:"-"`
+	Response      *InvoicePaymentId `json:"response,omitempty"`
+}
+
+type InvoiceRecordRefundRsp struct {
+	Code          int            `json:"-"`
+	Error         string         `json:"-"`
+	ErrorResponse *ErrorResponse `json:"-"`
+	Response      *RefundId      `json:"response,omitempty"`
+}
+
+type InvoiceQRCodeRsp struct {
+	Code          int            `json:"-"`
+	Error         string         `json:"-"`
+	ErrorResponse *ErrorResponse `json:"-"`
+	Response      *InvoiceQRCode `json:"response,omitempty"`
+}
+
 // ==================================分割==================================
 
 type Patch struct {
@@ -337,3 +561,555 @@ type NetAmountBreakdown struct {
 	ConvertedAmount *Amount       `json:"converted_amount,omitempty"`
 	ExchangeRate    *ExchangeRate `json:"exchange_rate,omitempty"`
 }
+
+type Webhook struct {
+	Id         string       `json:"id,omitempty"`
+	Url        string       `json:"url,omitempty"`
+	EventTypes []*EventType `json:"event_types,omitempty"`
+	Links      []*Link      `json:"links,omitempty"`
+}
+
+type EventType struct {
+	Name             string   `json:"name,omitempty"`
+	Description      string   `json:"description,omitempty"`
+	Status           string   `json:"status,omitempty"`
+	ResourceVersions []string `json:"resource_versions,omitempty"`
+}
+
+type WebhookList struct {
+	Webhooks []*Webhook `json:"webhooks,omitempty"`
+}
+
+type VerifyWebhookSignature struct {
+	VerificationStatus string `json:"verification_status,omitempty"` // SUCCESS、FAILURE
+}
+
+type Product struct {
+	Id          string  `json:"id,omitempty"`
+	Name        string  `json:"name,omitempty"`
+	Description string  `json:"description,omitempty"`
+	Type        string  `json:"type,omitempty"` // PHYSICAL、DIGITAL、SERVICE
+	Category    string  `json:"category,omitempty"`
+	ImageUrl    string  `json:"image_url,omitempty"`
+	HomeUrl     string  `json:"home_url,omitempty"`
+	CreateTime  string  `json:"create_time,omitempty"`
+	UpdateTime  string  `json:"update_time,omitempty"`
+	Links       []*Link `json:"links,omitempty"`
+}
+
+type ProductList struct {
+	Products   []*Product `json:"products,omitempty"`
+	TotalItems int        `json:"total_items,omitempty"`
+	TotalPages int        `json:"total_pages,omitempty"`
+	Links      []*Link    `json:"links,omitempty"`
+}
+
+type BillingPlan struct {
+	Id                 string              `json:"id,omitempty"`
+	ProductId          string              `json:"product_id,omitempty"`
+	Name               string              `json:"name,omitempty"`
+	Status             string              `json:"status,omitempty"` // CREATED、INACTIVE、ACTIVE
+	Description        string              `json:"description,omitempty"`
+	UsageType          string              `json:"usage_type,omitempty"`
+	BillingCycles      []*BillingCycle     `json:"billing_cycles,omitempty"`
+	PaymentPreferences *PaymentPreferences `json:"payment_preferences,omitempty"`
+	Taxes              *Taxes              `json:"taxes,omitempty"`
+	QuantitySupported  bool                `json:"quantity_supported,omitempty"`
+	CreateTime         string              `json:"create_time,omitempty"`
+	UpdateTime         string              `json:"update_time,omitempty"`
+	Links              []*Link             `json:"links,omitempty"`
+}
+
+type BillingPlanList struct {
+	Plans      []*BillingPlan `json:"plans,omitempty"`
+	TotalItems int            `json:"total_items,omitempty"`
+	TotalPages int            `json:"total_pages,omitempty"`
+	Links      []*Link        `json:"links,omitempty"`
+}
+
+type BillingCycle struct {
+	PricingScheme *PricingScheme `json:"pricing_scheme,omitempty"`
+	Frequency     *Frequency     `json:"frequency,omitempty"`
+	TenureType    string         `json:"tenure_type,omitempty"` // REGULAR、TRIAL
+	Sequence      int            `json:"sequence,omitempty"`
+	TotalCycles   int            `json:"total_cycles"` // 0 为无限循环
+}
+
+type PricingScheme struct {
+	Version      int            `json:"version,omitempty"`
+	FixedPrice   *Amount        `json:"fixed_price,omitempty"`
+	PricingModel string         `json:"pricing_model,omitempty"` // VOLUME、TIERED
+	Tiers        []*PricingTier `json:"tiers,omitempty"`
+	CreateTime   string         `json:"create_time,omitempty"`
+	UpdateTime   string         `json:"update_time,omitempty"`
+}
+
+type PricingTier struct {
+	StartingQuantity string  `json:"starting_quantity,omitempty"`
+	EndingQuantity   string  `json:"ending_quantity,omitempty"`
+	Amount           *Amount `json:"amount,omitempty"`
+}
+
+type Frequency struct {
+	IntervalUnit  string `json:"interval_unit,omitempty"` // DAY、WEEK、MONTH、YEAR
+	IntervalCount int    `json:"interval_count,omitempty"`
+}
+
+type PaymentPreferences struct {
+	AutoBillOutstanding     bool    `json:"auto_bill_outstanding,omitempty"`
+	SetupFee                *Amount `json:"setup_fee,omitempty"`
+	SetupFeeFailureAction   string  `json:"setup_fee_failure_action,omitempty"` // CONTINUE、CANCEL
+	PaymentFailureThreshold int     `json:"payment_failure_threshold,omitempty"`
+}
+
+type Taxes struct {
+	Percentage string `json:"percentage,omitempty"`
+	Inclusive  bool   `json:"inclusive,omitempty"`
+}
+
+type Subscription struct {
+	Id               string       `json:"id,omitempty"`
+	PlanId           string       `json:"plan_id,omitempty"`
+	Status           string       `json:"status,omitempty"` // APPROVAL_PENDING、APPROVED、ACTIVE、SUSPENDED、CANCELLED、EXPIRED
+	StatusChangeNote string       `json:"status_change_note,omitempty"`
+	StatusUpdateTime string       `json:"status_update_time,omitempty"`
+	StartTime        string       `json:"start_time,omitempty"`
+	Quantity         string       `json:"quantity,omitempty"`
+	ShippingAmount   *Amount      `json:"shipping_amount,omitempty"`
+	Subscriber       *Subscriber  `json:"subscriber,omitempty"`
+	BillingInfo      *BillingInfo `json:"billing_info,omitempty"`
+	CustomId         string       `json:"custom_id,omitempty"`
+	PlanOverridden   bool         `json:"plan_overridden,omitempty"`
+	CreateTime       string       `json:"create_time,omitempty"`
+	UpdateTime       string       `json:"update_time,omitempty"`
+	Links            []*Link      `json:"links,omitempty"`
+}
+
+type Subscriber struct {
+	Name            *SubscriberName `json:"name,omitempty"`
+	EmailAddress    string          `json:"email_address,omitempty"`
+	PayerId         string          `json:"payer_id,omitempty"`
+	ShippingAddress *Shipping       `json:"shipping_address,omitempty"`
+}
+
+type SubscriberName struct {
+	GivenName string `json:"given_name,omitempty"`
+	Surname   string `json:"surname,omitempty"`
+}
+
+type BillingInfo struct {
+	OutstandingBalance  *Amount           `json:"outstanding_balance,omitempty"`
+	CycleExecutions     []*CycleExecution `json:"cycle_executions,omitempty"`
+	LastPayment         *LastPayment      `json:"last_payment,omitempty"`
+	NextBillingTime     string            `json:"next_billing_time,omitempty"`
+	FinalPaymentTime    string            `json:"final_payment_time,omitempty"`
+	FailedPaymentsCount int               `json:"failed_payments_count,omitempty"`
+}
+
+type CycleExecution struct {
+	TenureType                  string `json:"tenure_type,omitempty"`
+	Sequence                    int    `json:"sequence,omitempty"`
+	CyclesCompleted             int    `json:"cycles_completed,omitempty"`
+	CyclesRemaining             int    `json:"cycles_remaining,omitempty"`
+	CurrentPricingSchemeVersion int    `json:"current_pricing_scheme_version,omitempty"`
+	TotalCycles                 int    `json:"total_cycles,omitempty"`
+}
+
+type LastPayment struct {
+	Amount *Amount `json:"amount,omitempty"`
+	Time   string  `json:"time,omitempty"`
+}
+
+type SubscriptionRevise struct {
+	PlanId          string    `json:"plan_id,omitempty"`
+	Quantity        string    `json:"quantity,omitempty"`
+	EffectiveTime   string    `json:"effective_time,omitempty"`
+	ShippingAmount  *Amount   `json:"shipping_amount,omitempty"`
+	ShippingAddress *Shipping `json:"shipping_address,omitempty"`
+	PlanOverridden  bool      `json:"plan_overridden,omitempty"`
+	Links           []*Link   `json:"links,omitempty"`
+}
+
+type SubscriptionTransaction struct {
+	Id                  string               `json:"id,omitempty"`
+	Status              string               `json:"status,omitempty"` // COMPLETED、DECLINED、PARTIALLY_REFUNDED、PENDING、REFUNDED
+	PayerEmail          string               `json:"payer_email,omitempty"`
+	PayerName           *SubscriberName      `json:"payer_name,omitempty"`
+	AmountWithBreakdown *AmountWithBreakdown `json:"amount_with_breakdown,omitempty"`
+	Time                string               `json:"time,omitempty"`
+}
+
+type AmountWithBreakdown struct {
+	GrossAmount *Amount `json:"gross_amount,omitempty"`
+	FeeAmount   *Amount `json:"fee_amount,omitempty"`
+	NetAmount   *Amount `json:"net_amount,omitempty"`
+}
+
+type SubscriptionTransactionList struct {
+	Transactions []*SubscriptionTransaction `json:"transactions,omitempty"`
+	TotalItems   int                        `json:"total_items,omitempty"`
+	TotalPages   int                        `json:"total_pages,omitempty"`
+	Links        []*Link                    `json:"links,omitempty"`
+}
+
+type PayoutBatch struct {
+	BatchHeader *PayoutBatchHeader  `json:"batch_header,omitempty"`
+	Items       []*PayoutItemDetail `json:"items,omitempty"`
+	TotalItems  int                 `json:"total_items,omitempty"`
+	TotalPages  int                 `json:"total_pages,omitempty"`
+	Links       []*Link             `json:"links,omitempty"`
+}
+
+type PayoutBatchHeader struct {
+	PayoutBatchId     string             `json:"payout_batch_id,omitempty"`
+	BatchStatus       string             `json:"batch_status,omitempty"` // DENIED、PENDING、PROCESSING、SUCCESS、CANCELED
+	TimeCreated       string             `json:"time_created,omitempty"`
+	TimeCompleted     string             `json:"time_completed,omitempty"`
+	TimeClosed        string             `json:"time_closed,omitempty"`
+	SenderBatchHeader *SenderBatchHeader `json:"sender_batch_header,omitempty"`
+	FundingSource     string             `json:"funding_source,omitempty"`
+	Amount            *PayoutCurrency    `json:"amount,omitempty"`
+	Fees              *PayoutCurrency    `json:"fees,omitempty"`
+}
+
+type SenderBatchHeader struct {
+	SenderBatchId string `json:"sender_batch_id,omitempty"`
+	RecipientType string `json:"recipient_type,omitempty"` // EMAIL、PHONE、PAYPAL_ID
+	EmailSubject  string `json:"email_subject,omitempty"`
+	EmailMessage  string `json:"email_message,omitempty"`
+}
+
+// PayoutCurrency 批量付款 API 的金额，字段名与 Amount 不同
+type PayoutCurrency struct {
+	Currency string `json:"currency"`
+	Value    string `json:"value"`
+}
+
+type PayoutItemDetail struct {
+	PayoutItemId      string          `json:"payout_item_id,omitempty"`
+	TransactionId     string          `json:"transaction_id,omitempty"`
+	ActivityId        string          `json:"activity_id,omitempty"`
+	TransactionStatus string          `json:"transaction_status,omitempty"` // SUCCESS、FAILED、PENDING、UNCLAIMED、RETURNED、ONHOLD、BLOCKED、REFUNDED、REVERSED
+	PayoutItemFee     *PayoutCurrency `json:"payout_item_fee,omitempty"`
+	PayoutBatchId     string          `json:"payout_batch_id,omitempty"`
+	SenderBatchId     string          `json:"sender_batch_id,omitempty"`
+	PayoutItem        *PayoutItem     `json:"payout_item,omitempty"`
+	TimeProcessed     string          `json:"time_processed,omitempty"`
+	Errors            *ErrorResponse  `json:"errors,omitempty"`
+	Links             []*Link         `json:"links,omitempty"`
+}
+
+type PayoutItem struct {
+	RecipientType   string          `json:"recipient_type,omitempty"`
+	Amount          *PayoutCurrency `json:"amount,omitempty"`
+	Note            string          `json:"note,omitempty"`
+	Receiver        string          `json:"receiver,omitempty"`
+	SenderItemId    string          `json:"sender_item_id,omitempty"`
+	RecipientWallet string          `json:"recipient_wallet,omitempty"` // PAYPAL、VENMO
+}
+
+type DisputeList struct {
+	Items []*Dispute `json:"items,omitempty"`
+	Links []*Link    `json:"links,omitempty"`
+}
+
+type Dispute struct {
+	DisputeId             string                 `json:"dispute_id,omitempty"`
+	CreateTime            string                 `json:"create_time,omitempty"`
+	UpdateTime            string                 `json:"update_time,omitempty"`
+	DisputedTransactions  []*DisputedTransaction `json:"disputed_transactions,omitempty"`
+	Reason                string                 `json:"reason,omitempty"` // MERCHANDISE_OR_SERVICE_NOT_RECEIVED、MERCHANDISE_OR_SERVICE_NOT_AS_DESCRIBED、UNAUTHORISED、CREDIT_NOT_PROCESSED、DUPLICATE_TRANSACTION、INCORRECT_AMOUNT、PAYMENT_BY_OTHER_MEANS、CANCELED_RECURRING_BILLING、PROBLEM_WITH_REMITTANCE、OTHER
+	Status                string                 `json:"status,omitempty"` // OPEN、WAITING_FOR_BUYER_RESPONSE、WAITING_FOR_SELLER_RESPONSE、UNDER_REVIEW、RESOLVED、OTHER
+	DisputeState          string                 `json:"dispute_state,omitempty"`
+	DisputeAmount         *Amount                `json:"dispute_amount,omitempty"`
+	DisputeOutcome        *DisputeOutcome        `json:"dispute_outcome,omitempty"`
+	DisputeLifeCycleStage string                 `json:"dispute_life_cycle_stage,omitempty"` // INQUIRY、CHARGEBACK、PRE_ARBITRATION、ARBITRATION
+	DisputeChannel        string                 `json:"dispute_channel,omitempty"`          // INTERNAL、EXTERNAL
+	Messages              []*DisputeMessage      `json:"messages,omitempty"`
+	Evidences             []*Evidence            `json:"evidences,omitempty"`
+	SellerResponseDueDate string                 `json:"seller_response_due_date,omitempty"`
+	BuyerResponseDueDate  string                 `json:"buyer_response_due_date,omitempty"`
+	Offer                 *DisputeOffer          `json:"offer,omitempty"`
+	Links                 []*Link                `json:"links,omitempty"`
+}
+
+type DisputedTransaction struct {
+	BuyerTransactionId  string         `json:"buyer_transaction_id,omitempty"`
+	SellerTransactionId string         `json:"seller_transaction_id,omitempty"`
+	CreateTime          string         `json:"create_time,omitempty"`
+	TransactionStatus   string         `json:"transaction_status,omitempty"`
+	GrossAmount         *Amount        `json:"gross_amount,omitempty"`
+	InvoiceNumber       string         `json:"invoice_number,omitempty"`
+	Custom              string         `json:"custom,omitempty"`
+	Buyer               *DisputeBuyer  `json:"buyer,omitempty"`
+	Seller              *DisputeSeller `json:"seller,omitempty"`
+}
+
+type DisputeBuyer struct {
+	Name string `json:"name,omitempty"`
+}
+
+type DisputeSeller struct {
+	Email      string `json:"email,omitempty"`
+	MerchantId string `json:"merchant_id,omitempty"`
+	Name       string `json:"name,omitempty"`
+}
+
+type DisputeOutcome struct {
+	OutcomeCode    string  `json:"outcome_code,omitempty"` // RESOLVED_BUYER_FAVOUR、RESOLVED_SELLER_FAVOUR、RESOLVED_WITH_PAYOUT、CANCELED_BY_BUYER、ACCEPTED、DENIED、NONE
+	AmountRefunded *Amount `json:"amount_refunded,omitempty"`
+}
+
+type DisputeMessage struct {
+	PostedBy   string `json:"posted_by,omitempty"` // BUYER、SELLER
+	TimePosted string `json:"time_posted,omitempty"`
+	Content    string `json:"content,omitempty"`
+}
+
+type Evidence struct {
+	EvidenceType string        `json:"evidence_type,omitempty"` // PROOF_OF_FULFILLMENT、PROOF_OF_REFUND、PROOF_OF_DELIVERY_SIGNATURE、OTHER 等
+	EvidenceInfo *EvidenceInfo `json:"evidence_info,omitempty"`
+	Documents    []*Document   `json:"documents,omitempty"`
+	Notes        string        `json:"notes,omitempty"`
+	Source       string        `json:"source,omitempty"`
+	Date         string        `json:"date,omitempty"`
+}
+
+type EvidenceInfo struct {
+	TrackingInfo []*TrackingInfo `json:"tracking_info,omitempty"`
+	RefundIds    []*RefundId     `json:"refund_ids,omitempty"`
+}
+
+type TrackingInfo struct {
+	CarrierName      string `json:"carrier_name,omitempty"`
+	CarrierNameOther string `json:"carrier_name_other,omitempty"`
+	TrackingUrl      string `json:"tracking_url,omitempty"`
+	TrackingNumber   string `json:"tracking_number,omitempty"`
+}
+
+type RefundId struct {
+	RefundId string `json:"refund_id,omitempty"`
+}
+
+type Document struct {
+	Name string `json:"name,omitempty"`
+	Url  string `json:"url,omitempty"`
+}
+
+type DisputeOffer struct {
+	BuyerRequestedAmount *Amount `json:"buyer_requested_amount,omitempty"`
+	SellerOfferedAmount  *Amount `json:"seller_offered_amount,omitempty"`
+	OfferType            string  `json:"offer_type,omitempty"` // REFUND、REFUND_WITH_RETURN、REFUND_WITH_REPLACEMENT、REPLACEMENT_WITHOUT_REFUND
+}
+
+type DisputeAction struct {
+	Links []*Link `json:"links,omitempty"`
+}
+
+type InvoiceNumber struct {
+	InvoiceNumber string `json:"invoice_number,omitempty"`
+}
+
+type InvoiceList struct {
+	TotalItems int        `json:"total_items,omitempty"`
+	TotalPages int        `json:"total_pages,omitempty"`
+	Items      []*Invoice `json:"items,omitempty"`
+	Links      []*Link    `json:"links,omitempty"`
+}
+
+type Invoice struct {
+	Id                   string                `json:"id,omitempty"`
+	ParentId             string                `json:"parent_id,omitempty"`
+	Status               string                `json:"status,omitempty"` // DRAFT、SENT、SCHEDULED、PAID、MARKED_AS_PAID、CANCELLED、REFUNDED、PARTIALLY_PAID、PARTIALLY_REFUNDED、MARKED_AS_REFUNDED、UNPAID、PAYMENT_PENDING
+	Detail               *InvoiceDetail        `json:"detail,omitempty"`
+	Invoicer             *Invoicer             `json:"invoicer,omitempty"`
+	PrimaryRecipients    []*InvoiceRecipient   `json:"primary_recipients,omitempty"`
+	AdditionalRecipients []*InvoiceEmail       `json:"additional_recipients,omitempty"`
+	Items                []*InvoiceItem        `json:"items,omitempty"`
+	Configuration        *InvoiceConfiguration `json:"configuration,omitempty"`
+	Amount               *InvoiceAmount        `json:"amount,omitempty"`
+	DueAmount            *Amount               `json:"due_amount,omitempty"`
+	Gratuity             *Amount               `json:"gratuity,omitempty"`
+	Payments             *InvoicePayments      `json:"payments,omitempty"`
+	Refunds              *InvoiceRefunds       `json:"refunds,omitempty"`
+	Links                []*Link               `json:"links,omitempty"`
+}
+
+type InvoiceDetail struct {
+	Reference          string           `json:"reference,omitempty"`
+	CurrencyCode       string           `json:"currency_code,omitempty"`
+	Note               string           `json:"note,omitempty"`
+	TermsAndConditions string           `json:"terms_and_conditions,omitempty"`
+	Memo               string           `json:"memo,omitempty"`
+	InvoiceNumber      string           `json:"invoice_number,omitempty"`
+	InvoiceDate        string           `json:"invoice_date,omitempty"`
+	PaymentTerm        *PaymentTerm     `json:"payment_term,omitempty"`
+	Metadata           *InvoiceMetadata `json:"metadata,omitempty"`
+}
+
+type PaymentTerm struct {
+	TermType string `json:"term_type,omitempty"` // DUE_ON_RECEIPT、DUE_ON_DATE_SPECIFIED、NET_10、NET_15、NET_30、NET_45、NET_60、NET_90、NO_DUE_DATE
+	DueDate  string `json:"due_date,omitempty"`
+}
+
+type InvoiceMetadata struct {
+	CreateTime       string `json:"create_time,omitempty"`
+	CreatedBy        string `json:"created_by,omitempty"`
+	LastUpdateTime   string `json:"last_update_time,omitempty"`
+	LastUpdatedBy    string `json:"last_updated_by,omitempty"`
+	CancelTime       string `json:"cancel_time,omitempty"`
+	CancelledBy      string `json:"cancelled_by,omitempty"`
+	FirstSentTime    string `json:"first_sent_time,omitempty"`
+	LastSentTime     string `json:"last_sent_time,omitempty"`
+	LastSentBy       string `json:"last_sent_by,omitempty"`
+	CreatedByFlow    string `json:"created_by_flow,omitempty"`
+	RecipientViewUrl string `json:"recipient_view_url,omitempty"`
+	InvoicerViewUrl  string `json:"invoicer_view_url,omitempty"`
+}
+
+type Invoicer struct {
+	Name            *InvoiceName    `json:"name,omitempty"`
+	Address         *Address        `json:"address,omitempty"`
+	EmailAddress    string          `json:"email_address,omitempty"`
+	Phones          []*InvoicePhone `json:"phones,omitempty"`
+	Website         string          `json:"website,omitempty"`
+	TaxId           string          `json:"tax_id,omitempty"`
+	Logo            string          `json:"logo_url,omitempty"`
+	AdditionalNotes string          `json:"additional_notes,omitempty"`
+}
+
+type InvoiceName struct {
+	Prefix     string `json:"prefix,omitempty"`
+	GivenName  string `json:"given_name,omitempty"`
+	Surname    string `json:"surname,omitempty"`
+	MiddleName string `json:"middle_name,omitempty"`
+	Suffix     string `json:"suffix,omitempty"`
+	FullName   string `json:"full_name,omitempty"`
+}
+
+type InvoicePhone struct {
+	CountryCode     string `json:"country_code,omitempty"`
+	NationalNumber  string `json:"national_number,omitempty"`
+	ExtensionNumber string `json:"extension_number,omitempty"`
+	PhoneType       string `json:"phone_type,omitempty"` // FAX、HOME、MOBILE、OTHER、PAGER
+}
+
+type InvoiceRecipient struct {
+	BillingInfo  *InvoiceContact `json:"billing_info,omitempty"`
+	ShippingInfo *InvoiceContact `json:"shipping_info,omitempty"`
+}
+
+type InvoiceContact struct {
+	BusinessName   string          `json:"business_name,omitempty"`
+	Name           *InvoiceName    `json:"name,omitempty"`
+	Address        *Address        `json:"address,omitempty"`
+	EmailAddress   string          `json:"email_address,omitempty"`
+	Phones         []*InvoicePhone `json:"phones,omitempty"`
+	AdditionalInfo string          `json:"additional_info,omitempty"`
+	Language       string          `json:"language,omitempty"`
+}
+
+type InvoiceEmail struct {
+	EmailAddress string `json:"email_address,omitempty"`
+}
+
+type InvoiceItem struct {
+	Id            string           `json:"id,omitempty"`
+	Name          string           `json:"name,omitempty"`
+	Description   string           `json:"description,omitempty"`
+	Quantity      string           `json:"quantity,omitempty"`
+	UnitAmount    *Amount          `json:"unit_amount,omitempty"`
+	Tax           *InvoiceTax      `json:"tax,omitempty"`
+	ItemDate      string           `json:"item_date,omitempty"`
+	Discount      *InvoiceDiscount `json:"discount,omitempty"`
+	UnitOfMeasure string           `json:"unit_of_measure,omitempty"` // QUANTITY、HOURS、AMOUNT
+}
+
+type InvoiceTax struct {
+	Name    string  `json:"name,omitempty"`
+	Percent string  `json:"percent,omitempty"`
+	Amount  *Amount `json:"amount,omitempty"`
+}
+
+type InvoiceDiscount struct {
+	Percent string  `json:"percent,omitempty"`
+	Amount  *Amount `json:"amount,omitempty"`
+}
+
+type InvoiceConfiguration struct {
+	TaxCalculatedAfterDiscount bool            `json:"tax_calculated_after_discount,omitempty"`
+	TaxInclusive               bool            `json:"tax_inclusive,omitempty"`
+	AllowTip                   bool            `json:"allow_tip,omitempty"`
+	PartialPayment             *PartialPayment `json:"partial_payment,omitempty"`
+	TemplateId                 string          `json:"template_id,omitempty"`
+}
+
+type PartialPayment struct {
+	AllowPartialPayment bool    `json:"allow_partial_payment,omitempty"`
+	MinimumAmountDue    *Amount `json:"minimum_amount_due,omitempty"`
+}
+
+type InvoiceAmount struct {
+	CurrencyCode string                  `json:"currency_code,omitempty"`
+	Value        string                  `json:"value,omitempty"`
+	Breakdown    *InvoiceAmountBreakdown `json:"breakdown,omitempty"`
+}
+
+type InvoiceAmountBreakdown struct {
+	ItemTotal *Amount               `json:"item_total,omitempty"`
+	Discount  *InvoiceTotalDiscount `json:"discount,omitempty"`
+	TaxTotal  *Amount               `json:"tax_total,omitempty"`
+	Shipping  *InvoiceShipping      `json:"shipping,omitempty"`
+	Custom    *InvoiceCustomAmount  `json:"custom,omitempty"`
+}
+
+type InvoiceTotalDiscount struct {
+	InvoiceDiscount *InvoiceDiscount `json:"invoice_discount,omitempty"`
+	ItemDiscount    *Amount          `json:"item_discount,omitempty"`
+}
+
+type InvoiceShipping struct {
+	Amount *Amount     `json:"amount,omitempty"`
+	Tax    *InvoiceTax `json:"tax,omitempty"`
+}
+
+type InvoiceCustomAmount struct {
+	Label  string  `json:"label,omitempty"`
+	Amount *Amount `json:"amount,omitempty"`
+}
+
+type InvoicePayments struct {
+	PaidAmount   *Amount                 `json:"paid_amount,omitempty"`
+	Transactions []*InvoicePaymentDetail `json:"transactions,omitempty"`
+}
+
+type InvoicePaymentDetail struct {
+	Type         string          `json:"type,omitempty"` // PAYPAL、EXTERNAL
+	PaymentId    string          `json:"payment_id,omitempty"`
+	PaymentDate  string          `json:"payment_date,omitempty"`
+	Method       string          `json:"method,omitempty"` // BANK_TRANSFER、CASH、CHECK、CREDIT_CARD、DEBIT_CARD、PAYPAL、WIRE_TRANSFER、OTHER
+	Note         string          `json:"note,omitempty"`
+	Amount       *Amount         `json:"amount,omitempty"`
+	ShippingInfo *InvoiceContact `json:"shipping_info,omitempty"`
+}
+
+type InvoiceRefunds struct {
+	RefundAmount *Amount                `json:"refund_amount,omitempty"`
+	Transactions []*InvoiceRefundDetail `json:"transactions,omitempty"`
+}
+
+type InvoiceRefundDetail struct {
+	Type       string  `json:"type,omitempty"` // PAYPAL、EXTERNAL
+	RefundId   string  `json:"refund_id,omitempty"`
+	RefundDate string  `json:"refund_date,omitempty"`
+	Amount     *Amount `json:"amount,omitempty"`
+	Method     string  `json:"method,omitempty"`
+}
+
+type InvoicePaymentId struct {
+	PaymentId string `json:"payment_id,omitempty"`
+}
+
+type InvoiceQRCode struct {
+	Image string `json:"image,omitempty"` // Base64 编码的 PNG 图片
+}
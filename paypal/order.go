@@ -31,6 +31,7 @@ func (c *Client) CreateOrder(ctx context.Context, bm gopay.BodyMap) (ppRsp *Crea
 		ppRsp.Error = string(bs)
 		ppRsp.ErrorResponse = new(ErrorResponse)
 		_ = json.Unmarshal(bs, ppRsp.ErrorResponse)
+		return ppRsp, newError(res, bs, ppRsp.ErrorResponse)
 	}
 	return ppRsp, nil
 }
@@ -53,6 +54,7 @@ func (c *Client) UpdateOrder(ctx context.Context, orderId string, patchs []*Patc
 		ppRsp.Error = string(bs)
 		ppRsp.ErrorResponse = new(ErrorResponse)
 		_ = json.Unmarshal(bs, ppRsp.ErrorResponse)
+		return ppRsp, newError(res, bs, ppRsp.ErrorResponse)
 	}
 	return ppRsp, nil
 }
@@ -79,6 +81,7 @@ func (c *Client) OrderDetail(ctx context.Context, orderId string, bm gopay.BodyM
 		ppRsp.Error = string(bs)
 		ppRsp.ErrorResponse = new(ErrorResponse)
 		_ = json.Unmarshal(bs, ppRsp.ErrorResponse)
+		return ppRsp, newError(res, bs, ppRsp.ErrorResponse)
 	}
 	return ppRsp, nil
 }
@@ -105,6 +108,7 @@ func (c *Client) OrderAuthorize(ctx context.Context, orderId string, bm gopay.Bo
 		ppRsp.Error = string(bs)
 		ppRsp.ErrorResponse = new(ErrorResponse)
 		_ = json.Unmarshal(bs, ppRsp.ErrorResponse)
+		return ppRsp, newError(res, bs, ppRsp.ErrorResponse)
 	}
 	return ppRsp, nil
 }
@@ -131,6 +135,7 @@ func (c *Client) OrderCapture(ctx context.Context, orderId string, bm gopay.Body
 		ppRsp.Error = string(bs)
 		ppRsp.ErrorResponse = new(ErrorResponse)
 		_ = json.Unmarshal(bs, ppRsp.ErrorResponse)
+		return ppRsp, newError(res, bs, ppRsp.ErrorResponse)
 	}
 	return ppRsp, nil
 }
@@ -9,6 +9,7 @@ import (
 )
 
 func TestCreateOrder(t *testing.T) {
+	skipIfNoClient(t)
 	var pus []*PurchaseUnit
 	var item = &PurchaseUnit{
 		ReferenceId: util.GetRandomString(16),
@@ -43,6 +44,7 @@ func TestCreateOrder(t *testing.T) {
 }
 
 func TestOrderDetail(t *testing.T) {
+	skipIfNoClient(t)
 	ppRsp, err := client.OrderDetail(ctx, "4X223967G91314611", nil)
 	if err != nil {
 		xlog.Error(err)
@@ -69,6 +71,7 @@ func TestOrderDetail(t *testing.T) {
 }
 
 func TestUpdateOrder(t *testing.T) {
+	skipIfNoClient(t)
 	var ps []*Patch
 	item := &Patch{
 		Op:   "replace",
@@ -105,6 +108,7 @@ func TestUpdateOrder(t *testing.T) {
 }
 
 func TestOrderAuthorize(t *testing.T) {
+	skipIfNoClient(t)
 	ppRsp, err := client.OrderAuthorize(ctx, "4X223967G91314611", nil)
 	if err != nil {
 		xlog.Error(err)
@@ -132,6 +136,7 @@ func TestOrderAuthorize(t *testing.T) {
 }
 
 func TestOrderCapture(t *testing.T) {
+	skipIfNoClient(t)
 	ppRsp, err := client.OrderCapture(ctx, "4X223967G91314611", nil)
 	if err != nil {
 		xlog.Error(err)
@@ -32,6 +32,7 @@ func (c *Client) PaymentAuthorizeDetail(ctx context.Context, authorizationId str
 		ppRsp.Error = string(bs)
 		ppRsp.ErrorResponse = new(ErrorResponse)
 		_ = json.Unmarshal(bs, ppRsp.ErrorResponse)
+		return ppRsp, newError(res, bs, ppRsp.ErrorResponse)
 	}
 	return ppRsp, nil
 }
@@ -58,6 +59,7 @@ func (c *Client) PaymentReauthorize(ctx context.Context, authorizationId string,
 		ppRsp.Error = string(bs)
 		ppRsp.ErrorResponse = new(ErrorResponse)
 		_ = json.Unmarshal(bs, ppRsp.ErrorResponse)
+		return ppRsp, newError(res, bs, ppRsp.ErrorResponse)
 	}
 	return ppRsp, nil
 }
@@ -80,6 +82,7 @@ func (c *Client) PaymentAuthorizeVoid(ctx context.Context, authorizationId strin
 		ppRsp.Error = string(bs)
 		ppRsp.ErrorResponse = new(ErrorResponse)
 		_ = json.Unmarshal(bs, ppRsp.ErrorResponse)
+		return ppRsp, newError(res, bs, ppRsp.ErrorResponse)
 	}
 	return ppRsp, nil
 }
@@ -106,6 +109,7 @@ func (c *Client) PaymentAuthorizeCapture(ctx context.Context, authorizationId st
 		ppRsp.Error = string(bs)
 		ppRsp.ErrorResponse = new(ErrorResponse)
 		_ = json.Unmarshal(bs, ppRsp.ErrorResponse)
+		return ppRsp, newError(res, bs, ppRsp.ErrorResponse)
 	}
 	return ppRsp, nil
 }
@@ -132,6 +136,7 @@ func (c *Client) PaymentCaptureDetail(ctx context.Context, captureId string) (pp
 		ppRsp.Error = string(bs)
 		ppRsp.ErrorResponse = new(ErrorResponse)
 		_ = json.Unmarshal(bs, ppRsp.ErrorResponse)
+		return ppRsp, newError(res, bs, ppRsp.ErrorResponse)
 	}
 	return ppRsp, nil
 }
@@ -158,6 +163,7 @@ func (c *Client) PaymentCaptureRefund(ctx context.Context, captureId string, bm
 		ppRsp.Error = string(bs)
 		ppRsp.ErrorResponse = new(ErrorResponse)
 		_ = json.Unmarshal(bs, ppRsp.ErrorResponse)
+		return ppRsp, newError(res, bs, ppRsp.ErrorResponse)
 	}
 	return ppRsp, nil
 }
@@ -184,6 +190,7 @@ func (c *Client) PaymentRefundDetail(ctx context.Context, refundId string) (ppRs
 		ppRsp.Error = string(bs)
 		ppRsp.ErrorResponse = new(ErrorResponse)
 		_ = json.Unmarshal(bs, ppRsp.ErrorResponse)
+		return ppRsp, newError(res, bs, ppRsp.ErrorResponse)
 	}
 	return ppRsp, nil
 }
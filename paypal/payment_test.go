@@ -8,6 +8,7 @@ import (
 )
 
 func TestPaymentAuthorizeDetail(t *testing.T) {
+	skipIfNoClient(t)
 	ppRsp, err := client.PaymentAuthorizeDetail(ctx, "4X223967G91314611")
 	if err != nil {
 		xlog.Error(err)
@@ -26,6 +27,7 @@ func TestPaymentAuthorizeDetail(t *testing.T) {
 }
 
 func TestPaymentReauthorize(t *testing.T) {
+	skipIfNoClient(t)
 	bm := make(gopay.BodyMap)
 	bm.SetBodyMap("amount", func(bm gopay.BodyMap) {
 		bm.Set("currency_code", "USD").
@@ -52,6 +54,7 @@ func TestPaymentReauthorize(t *testing.T) {
 }
 
 func TestPaymentAuthorizeVoid(t *testing.T) {
+	skipIfNoClient(t)
 	ppRsp, err := client.PaymentAuthorizeVoid(ctx, "4X223967G91314611")
 	if err != nil {
 		xlog.Error(err)
@@ -67,6 +70,7 @@ func TestPaymentAuthorizeVoid(t *testing.T) {
 }
 
 func TestPaymentAuthorizeCapture(t *testing.T) {
+	skipIfNoClient(t)
 	bm := make(gopay.BodyMap)
 	bm.Set("invoice_id", "INVOICE-123").
 		Set("final_capture", true).
@@ -97,6 +101,7 @@ func TestPaymentAuthorizeCapture(t *testing.T) {
 }
 
 func TestPaymentCaptureDetail(t *testing.T) {
+	skipIfNoClient(t)
 	ppRsp, err := client.PaymentCaptureDetail(ctx, "4X223967G91314611")
 	if err != nil {
 		xlog.Error(err)
@@ -115,6 +120,7 @@ func TestPaymentCaptureDetail(t *testing.T) {
 }
 
 func TestPaymentCaptureRefund(t *testing.T) {
+	skipIfNoClient(t)
 	bm := make(gopay.BodyMap)
 	bm.Set("invoice_id", "INVOICE-123").
 		Set("note_to_payer", "Defective product").
@@ -143,6 +149,7 @@ func TestPaymentCaptureRefund(t *testing.T) {
 }
 
 func TestPaymentRefundDetail(t *testing.T) {
+	skipIfNoClient(t)
 	ppRsp, err := client.PaymentRefundDetail(ctx, "4X223967G91314611")
 	if err != nil {
 		xlog.Error(err)
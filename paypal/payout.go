@@ -0,0 +1,125 @@
+package paypal
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/yuanqinguo/gopay"
+)
+
+// 创建批量付款（Create batch payout）
+//	bm：sender_batch_header、items，单批最多 15000 个付款项，sender_batch_id 30 天内不可重复
+//	Code = 0 is success
+//	文档：https://developer.paypal.com/docs/api/payments.payouts-batch/v1/#payouts_post
+func (c *Client) CreatePayoutBatch(ctx context.Context, bm gopay.BodyMap) (ppRsp *CreatePayoutBatchRsp, err error) {
+	if err = bm.CheckEmptyError("sender_batch_header", "items"); err != nil {
+		return nil, err
+	}
+	res, bs, err := c.doPayPalPost(ctx, bm, payoutBatchCreate)
+	if err != nil {
+		return nil, err
+	}
+	ppRsp = &CreatePayoutBatchRsp{Code: Success}
+	if res.StatusCode != http.StatusCreated {
+		ppRsp.Code = res.StatusCode
+		ppRsp.Error = string(bs)
+		ppRsp.ErrorResponse = new(ErrorResponse)
+		_ = json.Unmarshal(bs, ppRsp.ErrorResponse)
+		return ppRsp, newError(res, bs, ppRsp.ErrorResponse)
+	}
+	ppRsp.Response = new(PayoutBatch)
+	if err = json.Unmarshal(bs, ppRsp.Response); err != nil {
+		return nil, fmt.Errorf("json.Unmarshal(%s)：%w", string(bs), err)
+	}
+	return ppRsp, nil
+}
+
+// 批量付款详情（Show payout batch details）
+//	bm：可选参数 page、page_size、fields、total_required，传 nil 使用默认值
+//	Response.TotalPages 大于 page 时，可递增 page 继续查询
+//	Code = 0 is success
+//	文档：https://developer.paypal.com/docs/api/payments.payouts-batch/v1/#payouts_get
+func (c *Client) PayoutBatchDetail(ctx context.Context, payoutBatchId string, bm gopay.BodyMap) (ppRsp *PayoutBatchDetailRsp, err error) {
+	if payoutBatchId == gopay.NULL {
+		return nil, errors.New("payout_batch_id is empty")
+	}
+	uri := fmt.Sprintf(payoutBatchDetail, payoutBatchId)
+	if bm != nil {
+		uri += "?" + bm.EncodeURLParams()
+	}
+	res, bs, err := c.doPayPalGet(ctx, uri)
+	if err != nil {
+		return nil, err
+	}
+	ppRsp = &PayoutBatchDetailRsp{Code: Success}
+	if res.StatusCode != http.StatusOK {
+		ppRsp.Code = res.StatusCode
+		ppRsp.Error = string(bs)
+		ppRsp.ErrorResponse = new(ErrorResponse)
+		_ = json.Unmarshal(bs, ppRsp.ErrorResponse)
+		return ppRsp, newError(res, bs, ppRsp.ErrorResponse)
+	}
+	ppRsp.Response = new(PayoutBatch)
+	if err = json.Unmarshal(bs, ppRsp.Response); err != nil {
+		return nil, fmt.Errorf("json.Unmarshal(%s)：%w", string(bs), err)
+	}
+	return ppRsp, nil
+}
+
+// 付款项详情（Show payout item details）
+//	Code = 0 is success
+//	文档：https://developer.paypal.com/docs/api/payments.payouts-batch/v1/#payouts-item_get
+func (c *Client) PayoutItemDetail(ctx context.Context, payoutItemId string) (ppRsp *PayoutItemDetailRsp, err error) {
+	if payoutItemId == gopay.NULL {
+		return nil, errors.New("payout_item_id is empty")
+	}
+	url := fmt.Sprintf(payoutItemDetail, payoutItemId)
+	res, bs, err := c.doPayPalGet(ctx, url)
+	if err != nil {
+		return nil, err
+	}
+	ppRsp = &PayoutItemDetailRsp{Code: Success}
+	if res.StatusCode != http.StatusOK {
+		ppRsp.Code = res.StatusCode
+		ppRsp.Error = string(bs)
+		ppRsp.ErrorResponse = new(ErrorResponse)
+		_ = json.Unmarshal(bs, ppRsp.ErrorResponse)
+		return ppRsp, newError(res, bs, ppRsp.ErrorResponse)
+	}
+	ppRsp.Response = new(PayoutItemDetail)
+	if err = json.Unmarshal(bs, ppRsp.Response); err != nil {
+		return nil, fmt.Errorf("json.Unmarshal(%s)：%w", string(bs), err)
+	}
+	return ppRsp, nil
+}
+
+// 取消未领取的付款项（Cancel unclaimed payout item）
+//	仅 transaction_status 为 UNCLAIMED 的付款项可取消，取消后金额退回付款账户
+//	Code = 0 is success
+//	文档：https://developer.paypal.com/docs/api/payments.payouts-batch/v1/#payouts-item_cancel
+func (c *Client) CancelUnclaimedPayoutItem(ctx context.Context, payoutItemId string) (ppRsp *CancelPayoutItemRsp, err error) {
+	if payoutItemId == gopay.NULL {
+		return nil, errors.New("payout_item_id is empty")
+	}
+	url := fmt.Sprintf(payoutItemCancel, payoutItemId)
+	res, bs, err := c.doPayPalPost(ctx, nil, url)
+	if err != nil {
+		return nil, err
+	}
+	ppRsp = &CancelPayoutItemRsp{Code: Success}
+	if res.StatusCode != http.StatusOK {
+		ppRsp.Code = res.StatusCode
+		ppRsp.Error = string(bs)
+		ppRsp.ErrorResponse = new(ErrorResponse)
+		_ = json.Unmarshal(bs, ppRsp.ErrorResponse)
+		return ppRsp, newError(res, bs, ppRsp.ErrorResponse)
+	}
+	ppRsp.Response = new(PayoutItemDetail)
+	if err = json.Unmarshal(bs, ppRsp.Response); err != nil {
+		return nil, fmt.Errorf("json.Unmarshal(%s)：%w", string(bs), err)
+	}
+	return ppRsp, nil
+}
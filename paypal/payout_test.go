@@ -0,0 +1,164 @@
+package paypal
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/yuanqinguo/gopay"
+	"github.com/yuanqinguo/gopay/pkg/util"
+	"github.com/yuanqinguo/gopay/pkg/xlog"
+)
+
+func TestCreatePayoutBatch(t *testing.T) {
+	skipIfNoClient(t)
+	items := []*PayoutItem{
+		{
+			RecipientType: "EMAIL",
+			Amount:        &PayoutCurrency{Currency: "USD", Value: "9.87"},
+			Note:          "Thanks for your patronage!",
+			Receiver:      "receiver@example.com",
+			SenderItemId:  util.GetRandomString(16),
+		},
+	}
+	bm := make(gopay.BodyMap)
+	bm.SetBodyMap("sender_batch_header", func(bm gopay.BodyMap) {
+		bm.Set("sender_batch_id", util.GetRandomString(16)).
+			Set("email_subject", "You have a payout!")
+	}).Set("items", items)
+
+	xlog.Debug("bm：", bm.JsonBody())
+
+	ppRsp, err := client.CreatePayoutBatch(ctx, bm)
+	if err != nil {
+		xlog.Error(err)
+		return
+	}
+	if ppRsp.Code != Success {
+		xlog.Debugf("ppRsp.Code: %+v", ppRsp.Code)
+		xlog.Debugf("ppRsp.Error: %+v", ppRsp.Error)
+		xlog.Debugf("ppRsp.ErrorResponse: %+v", ppRsp.ErrorResponse)
+		return
+	}
+	xlog.Debugf("ppRsp.Response.BatchHeader: %+v", ppRsp.Response.BatchHeader)
+}
+
+func TestPayoutBatchDetail(t *testing.T) {
+	skipIfNoClient(t)
+	bm := make(gopay.BodyMap)
+	bm.Set("page", 1).
+		Set("page_size", 100).
+		Set("total_required", true)
+
+	ppRsp, err := client.PayoutBatchDetail(ctx, "FYXMPQTX4JC9N", bm)
+	if err != nil {
+		xlog.Error(err)
+		return
+	}
+	if ppRsp.Code != Success {
+		xlog.Debugf("ppRsp.Code: %+v", ppRsp.Code)
+		xlog.Debugf("ppRsp.Error: %+v", ppRsp.Error)
+		xlog.Debugf("ppRsp.ErrorResponse: %+v", ppRsp.ErrorResponse)
+		return
+	}
+	xlog.Debugf("ppRsp.Response.BatchHeader: %+v", ppRsp.Response.BatchHeader)
+	for _, v := range ppRsp.Response.Items {
+		xlog.Debugf("ppRsp.Response.Item: %+v", v)
+	}
+}
+
+func TestCancelUnclaimedPayoutItem(t *testing.T) {
+	skipIfNoClient(t)
+	ppRsp, err := client.CancelUnclaimedPayoutItem(ctx, "5KUDKLF8SDC7S")
+	if err != nil {
+		xlog.Error(err)
+		return
+	}
+	if ppRsp.Code != Success {
+		xlog.Debugf("ppRsp.Code: %+v", ppRsp.Code)
+		xlog.Debugf("ppRsp.Error: %+v", ppRsp.Error)
+		xlog.Debugf("ppRsp.ErrorResponse: %+v", ppRsp.ErrorResponse)
+		return
+	}
+	xlog.Debugf("ppRsp.Response: %+v", ppRsp.Response)
+}
+
+func TestPayoutOffline(t *testing.T) {
+	c, reqs := fakePayPal(t, map[string]fakeRoute{
+		"POST /v1/payments/payouts": {http.StatusCreated, `{"batch_header":{"payout_batch_id":"5UXD2E8A7EBQJ","batch_status":"PENDING","sender_batch_header":{"sender_batch_id":"Payouts_2018_100007","email_subject":"You have a payout!"}},"links":[{"href":"https://api-m.sandbox.paypal.com/v1/payments/payouts/5UXD2E8A7EBQJ","rel":"self","method":"GET"}]}`},
+		"GET /v1/payments/payouts/5UXD2E8A7EBQJ?page=1&page_size=1&total_required=true": {http.StatusOK, `{"batch_header":{"payout_batch_id":"5UXD2E8A7EBQJ","batch_status":"SUCCESS","amount":{"currency":"USD","value":"19.74"}},"items":[{"payout_item_id":"8AELMXH8UB2P8","transaction_status":"SUCCESS","payout_item":{"receiver":"receiver@example.com","amount":{"currency":"USD","value":"9.87"}}}],"total_items":2,"total_pages":2}`},
+		"GET /v1/payments/payouts/5UXD2E8A7EBQJ?page=2&page_size=1&total_required=true": {http.StatusOK, `{"batch_header":{"payout_batch_id":"5UXD2E8A7EBQJ","batch_status":"SUCCESS"},"items":[{"payout_item_id":"9AELMXH8UB2P9","transaction_status":"UNCLAIMED"}],"total_items":2,"total_pages":2}`},
+		"GET /v1/payments/payouts-item/8AELMXH8UB2P8":                                   {http.StatusOK, `{"payout_item_id":"8AELMXH8UB2P8","transaction_id":"0C413693MN970190K","transaction_status":"SUCCESS","payout_batch_id":"5UXD2E8A7EBQJ"}`},
+		"POST /v1/payments/payouts-item/9AELMXH8UB2P9/cancel":                           {http.StatusOK, `{"payout_item_id":"9AELMXH8UB2P9","transaction_status":"RETURNED","payout_batch_id":"5UXD2E8A7EBQJ"}`},
+		"POST /v1/payments/payouts-item/8AELMXH8UB2P8/cancel":                           {http.StatusBadRequest, `{"name":"ITEM_ALREADY_PROCESSED","message":"Payout item already processed.","debug_id":"a1b2c3d4e5f60"}`},
+		"GET /v1/payments/payouts/NOTFOUND":                                             {http.StatusNotFound, ``},
+		"GET /v1/payments/payouts-item/BADGATEWAY":                                      {http.StatusBadGateway, `<html><body>502 Bad Gateway</body></html>`},
+	})
+
+	bm := make(gopay.BodyMap)
+	bm.SetBodyMap("sender_batch_header", func(bm gopay.BodyMap) {
+		bm.Set("sender_batch_id", "Payouts_2018_100007").
+			Set("email_subject", "You have a payout!")
+	}).Set("items", []*PayoutItem{{RecipientType: "EMAIL", Amount: &PayoutCurrency{Currency: "USD", Value: "9.87"}, Receiver: "receiver@example.com", SenderItemId: "201403140001"}})
+	createRsp, err := c.CreatePayoutBatch(ctx, bm)
+	if err != nil || createRsp.Code != Success || createRsp.Response.BatchHeader.PayoutBatchId != "5UXD2E8A7EBQJ" {
+		t.Fatalf("unexpected response: %+v, %v", createRsp, err)
+	}
+	if header := createRsp.Response.BatchHeader; header.BatchStatus != "PENDING" || header.SenderBatchHeader.SenderBatchId != "Payouts_2018_100007" {
+		t.Fatalf("unexpected batch header: %+v", header)
+	}
+	if body := reqs["POST /v1/payments/payouts"].body; !strings.Contains(body, `"sender_batch_id":"Payouts_2018_100007"`) || !strings.Contains(body, `"receiver":"receiver@example.com"`) {
+		t.Fatalf("unexpected request body: %s", body)
+	}
+
+	// 递增 page 查询全部付款项
+	var itemIds []string
+	bm = make(gopay.BodyMap)
+	bm.Set("page_size", 1).
+		Set("total_required", true)
+	for page := 1; ; page++ {
+		bm.Set("page", page)
+		detailRsp, err := c.PayoutBatchDetail(ctx, "5UXD2E8A7EBQJ", bm)
+		if err != nil || detailRsp.Code != Success {
+			t.Fatalf("unexpected response: %+v, %v", detailRsp, err)
+		}
+		for _, item := range detailRsp.Response.Items {
+			itemIds = append(itemIds, item.PayoutItemId)
+		}
+		if detailRsp.Response.TotalPages <= page {
+			break
+		}
+	}
+	if strings.Join(itemIds, ",") != "8AELMXH8UB2P8,9AELMXH8UB2P9" {
+		t.Fatalf("unexpected payout items: %v", itemIds)
+	}
+
+	itemRsp, err := c.PayoutItemDetail(ctx, "8AELMXH8UB2P8")
+	if err != nil || itemRsp.Code != Success || itemRsp.Response.TransactionId != "0C413693MN970190K" {
+		t.Fatalf("unexpected response: %+v, %v", itemRsp, err)
+	}
+	cancelRsp, err := c.CancelUnclaimedPayoutItem(ctx, "9AELMXH8UB2P9")
+	if err != nil || cancelRsp.Code != Success || cancelRsp.Response.TransactionStatus != "RETURNED" {
+		t.Fatalf("unexpected response: %+v, %v", cancelRsp, err)
+	}
+
+	// 已处理的付款项不可取消
+	cancelRsp, err = c.CancelUnclaimedPayoutItem(ctx, "8AELMXH8UB2P8")
+	e, ok := gopay.AsError(err)
+	if !ok || e.Code != "ITEM_ALREADY_PROCESSED" || e.RequestId != "a1b2c3d4e5f60" || cancelRsp.Code != http.StatusBadRequest {
+		t.Fatalf("unexpected error: %+v, %v", cancelRsp, err)
+	}
+
+	// 错误响应为空或非 JSON 时，同样返回 *gopay.Error；bm 为 nil 时不拼接查询参数
+	detailRsp, err := c.PayoutBatchDetail(ctx, "NOTFOUND", nil)
+	if e, ok = gopay.AsError(err); !ok || e.StatusCode != http.StatusNotFound || detailRsp.Code != http.StatusNotFound {
+		t.Fatalf("unexpected error: %+v, %v", detailRsp, err)
+	}
+	if reqs["GET /v1/payments/payouts/NOTFOUND"].query != "" {
+		t.Fatalf("unexpected query: %s", reqs["GET /v1/payments/payouts/NOTFOUND"].query)
+	}
+	itemRsp, err = c.PayoutItemDetail(ctx, "BADGATEWAY")
+	if e, ok = gopay.AsError(err); !ok || e.StatusCode != http.StatusBadGateway || itemRsp.Code != http.StatusBadGateway {
+		t.Fatalf("unexpected error: %+v, %v", itemRsp, err)
+	}
+}
@@ -0,0 +1,189 @@
+package paypal
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/yuanqinguo/gopay"
+)
+
+// 创建订阅计划（Create plan）
+//	Code = 0 is success
+//	文档：https://developer.paypal.com/docs/api/subscriptions/v1/#plans_create
+func (c *Client) CreateBillingPlan(ctx context.Context, bm gopay.BodyMap) (ppRsp *CreateBillingPlanRsp, err error) {
+	if err = bm.CheckEmptyError("product_id", "name", "billing_cycles", "payment_preferences"); err != nil {
+		return nil, err
+	}
+	res, bs, err := c.doPayPalPost(ctx, bm, planCreate)
+	if err != nil {
+		return nil, err
+	}
+	ppRsp = &CreateBillingPlanRsp{Code: Success}
+	ppRsp.Response = new(BillingPlan)
+	if err = json.Unmarshal(bs, ppRsp.Response); err != nil {
+		return nil, fmt.Errorf("json.Unmarshal(%s)：%w", string(bs), err)
+	}
+	if res.StatusCode != http.StatusCreated {
+		ppRsp.Code = res.StatusCode
+		ppRsp.Error = string(bs)
+		ppRsp.ErrorResponse = new(ErrorResponse)
+		_ = json.Unmarshal(bs, ppRsp.ErrorResponse)
+		return ppRsp, newError(res, bs, ppRsp.ErrorResponse)
+	}
+	return ppRsp, nil
+}
+
+// 订阅计划列表（List plans）
+//	bm：可选参数 product_id、plan_ids、page_size、page、total_required，传 nil 使用默认值
+//	Code = 0 is success
+//	文档：https://developer.paypal.com/docs/api/subscriptions/v1/#plans_list
+func (c *Client) BillingPlanList(ctx context.Context, bm gopay.BodyMap) (ppRsp *BillingPlanListRsp, err error) {
+	uri := planList
+	if bm != nil {
+		uri += "?" + bm.EncodeURLParams()
+	}
+	res, bs, err := c.doPayPalGet(ctx, uri)
+	if err != nil {
+		return nil, err
+	}
+	ppRsp = &BillingPlanListRsp{Code: Success}
+	ppRsp.Response = new(BillingPlanList)
+	if err = json.Unmarshal(bs, ppRsp.Response); err != nil {
+		return nil, fmt.Errorf("json.Unmarshal(%s)：%w", string(bs), err)
+	}
+	if res.StatusCode != http.StatusOK {
+		ppRsp.Code = res.StatusCode
+		ppRsp.Error = string(bs)
+		ppRsp.ErrorResponse = new(ErrorResponse)
+		_ = json.Unmarshal(bs, ppRsp.ErrorResponse)
+		return ppRsp, newError(res, bs, ppRsp.ErrorResponse)
+	}
+	return ppRsp, nil
+}
+
+// 订阅计划详情（Show plan details）
+//	Code = 0 is success
+//	文档：https://developer.paypal.com/docs/api/subscriptions/v1/#plans_get
+func (c *Client) BillingPlanDetail(ctx context.Context, planId string) (ppRsp *BillingPlanDetailRsp, err error) {
+	if planId == gopay.NULL {
+		return nil, errors.New("plan_id is empty")
+	}
+	url := fmt.Sprintf(planDetail, planId)
+	res, bs, err := c.doPayPalGet(ctx, url)
+	if err != nil {
+		return nil, err
+	}
+	ppRsp = &BillingPlanDetailRsp{Code: Success}
+	ppRsp.Response = new(BillingPlan)
+	if err = json.Unmarshal(bs, ppRsp.Response); err != nil {
+		return nil, fmt.Errorf("json.Unmarshal(%s)：%w", string(bs), err)
+	}
+	if res.StatusCode != http.StatusOK {
+		ppRsp.Code = res.StatusCode
+		ppRsp.Error = string(bs)
+		ppRsp.ErrorResponse = new(ErrorResponse)
+		_ = json.Unmarshal(bs, ppRsp.ErrorResponse)
+		return ppRsp, newError(res, bs, ppRsp.ErrorResponse)
+	}
+	return ppRsp, nil
+}
+
+// 更新订阅计划（Update plan）
+//	patchs：可更新 description、payment_preferences、taxes.percentage 等
+//	Code = 0 is success
+//	文档：https://developer.paypal.com/docs/api/subscriptions/v1/#plans_patch
+func (c *Client) UpdateBillingPlan(ctx context.Context, planId string, patchs []*Patch) (ppRsp *EmptyRsp, err error) {
+	if planId == gopay.NULL {
+		return nil, errors.New("plan_id is empty")
+	}
+	url := fmt.Sprintf(planUpdate, planId)
+	res, bs, err := c.doPayPalPatch(ctx, patchs, url)
+	if err != nil {
+		return nil, err
+	}
+	ppRsp = &EmptyRsp{Code: Success}
+	if res.StatusCode != http.StatusNoContent {
+		ppRsp.Code = res.StatusCode
+		ppRsp.Error = string(bs)
+		ppRsp.ErrorResponse = new(ErrorResponse)
+		_ = json.Unmarshal(bs, ppRsp.ErrorResponse)
+		return ppRsp, newError(res, bs, ppRsp.ErrorResponse)
+	}
+	return ppRsp, nil
+}
+
+// 激活订阅计划（Activate plan）
+//	Code = 0 is success
+//	文档：https://developer.paypal.com/docs/api/subscriptions/v1/#plans_activate
+func (c *Client) BillingPlanActivate(ctx context.Context, planId string) (ppRsp *EmptyRsp, err error) {
+	if planId == gopay.NULL {
+		return nil, errors.New("plan_id is empty")
+	}
+	url := fmt.Sprintf(planActivate, planId)
+	res, bs, err := c.doPayPalPost(ctx, nil, url)
+	if err != nil {
+		return nil, err
+	}
+	ppRsp = &EmptyRsp{Code: Success}
+	if res.StatusCode != http.StatusNoContent {
+		ppRsp.Code = res.StatusCode
+		ppRsp.Error = string(bs)
+		ppRsp.ErrorResponse = new(ErrorResponse)
+		_ = json.Unmarshal(bs, ppRsp.ErrorResponse)
+		return ppRsp, newError(res, bs, ppRsp.ErrorResponse)
+	}
+	return ppRsp, nil
+}
+
+// 停用订阅计划（Deactivate plan）
+//	Code = 0 is success
+//	文档：https://developer.paypal.com/docs/api/subscriptions/v1/#plans_deactivate
+func (c *Client) BillingPlanDeactivate(ctx context.Context, planId string) (ppRsp *EmptyRsp, err error) {
+	if planId == gopay.NULL {
+		return nil, errors.New("plan_id is empty")
+	}
+	url := fmt.Sprintf(planDeactivate, planId)
+	res, bs, err := c.doPayPalPost(ctx, nil, url)
+	if err != nil {
+		return nil, err
+	}
+	ppRsp = &EmptyRsp{Code: Success}
+	if res.StatusCode != http.StatusNoContent {
+		ppRsp.Code = res.StatusCode
+		ppRsp.Error = string(bs)
+		ppRsp.ErrorResponse = new(ErrorResponse)
+		_ = json.Unmarshal(bs, ppRsp.ErrorResponse)
+		return ppRsp, newError(res, bs, ppRsp.ErrorResponse)
+	}
+	return ppRsp, nil
+}
+
+// 更新订阅计划价格（Update pricing）
+//	bm：pricing_schemes 为 billing_cycle_sequence、pricing_scheme 的数组，已有订阅将在下个扣款周期使用新价格
+//	Code = 0 is success
+//	文档：https://developer.paypal.com/docs/api/subscriptions/v1/#plans_update-pricing-schemes
+func (c *Client) BillingPlanUpdatePricing(ctx context.Context, planId string, bm gopay.BodyMap) (ppRsp *EmptyRsp, err error) {
+	if planId == gopay.NULL {
+		return nil, errors.New("plan_id is empty")
+	}
+	if err = bm.CheckEmptyError("pricing_schemes"); err != nil {
+		return nil, err
+	}
+	url := fmt.Sprintf(planUpdatePricing, planId)
+	res, bs, err := c.doPayPalPost(ctx, bm, url)
+	if err != nil {
+		return nil, err
+	}
+	ppRsp = &EmptyRsp{Code: Success}
+	if res.StatusCode != http.StatusNoContent {
+		ppRsp.Code = res.StatusCode
+		ppRsp.Error = string(bs)
+		ppRsp.ErrorResponse = new(ErrorResponse)
+		_ = json.Unmarshal(bs, ppRsp.ErrorResponse)
+		return ppRsp, newError(res, bs, ppRsp.ErrorResponse)
+	}
+	return ppRsp, nil
+}
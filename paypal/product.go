@@ -0,0 +1,116 @@
+package paypal
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/yuanqinguo/gopay"
+)
+
+// 创建商品（Create product）
+//	Code = 0 is success
+//	文档：https://developer.paypal.com/docs/api/catalog-products/v1/#products_create
+func (c *Client) CreateProduct(ctx context.Context, bm gopay.BodyMap) (ppRsp *CreateProductRsp, err error) {
+	if err = bm.CheckEmptyError("name", "type"); err != nil {
+		return nil, err
+	}
+	res, bs, err := c.doPayPalPost(ctx, bm, productCreate)
+	if err != nil {
+		return nil, err
+	}
+	ppRsp = &CreateProductRsp{Code: Success}
+	ppRsp.Response = new(Product)
+	if err = json.Unmarshal(bs, ppRsp.Response); err != nil {
+		return nil, fmt.Errorf("json.Unmarshal(%s)：%w", string(bs), err)
+	}
+	if res.StatusCode != http.StatusCreated {
+		ppRsp.Code = res.StatusCode
+		ppRsp.Error = string(bs)
+		ppRsp.ErrorResponse = new(ErrorResponse)
+		_ = json.Unmarshal(bs, ppRsp.ErrorResponse)
+		return ppRsp, newError(res, bs, ppRsp.ErrorResponse)
+	}
+	return ppRsp, nil
+}
+
+// 商品列表（List products）
+//	bm：可选参数 page_size、page、total_required，传 nil 使用默认值
+//	Code = 0 is success
+//	文档：https://developer.paypal.com/docs/api/catalog-products/v1/#products_list
+func (c *Client) ProductList(ctx context.Context, bm gopay.BodyMap) (ppRsp *ProductListRsp, err error) {
+	uri := productList
+	if bm != nil {
+		uri += "?" + bm.EncodeURLParams()
+	}
+	res, bs, err := c.doPayPalGet(ctx, uri)
+	if err != nil {
+		return nil, err
+	}
+	ppRsp = &ProductListRsp{Code: Success}
+	ppRsp.Response = new(ProductList)
+	if err = json.Unmarshal(bs, ppRsp.Response); err != nil {
+		return nil, fmt.Errorf("json.Unmarshal(%s)：%w", string(bs), err)
+	}
+	if res.StatusCode != http.StatusOK {
+		ppRsp.Code = res.StatusCode
+		ppRsp.Error = string(bs)
+		ppRsp.ErrorResponse = new(ErrorResponse)
+		_ = json.Unmarshal(bs, ppRsp.ErrorResponse)
+		return ppRsp, newError(res, bs, ppRsp.ErrorResponse)
+	}
+	return ppRsp, nil
+}
+
+// 商品详情（Show product details）
+//	Code = 0 is success
+//	文档：https://developer.paypal.com/docs/api/catalog-products/v1/#products_get
+func (c *Client) ProductDetail(ctx context.Context, productId string) (ppRsp *ProductDetailRsp, err error) {
+	if productId == gopay.NULL {
+		return nil, errors.New("product_id is empty")
+	}
+	url := fmt.Sprintf(productDetail, productId)
+	res, bs, err := c.doPayPalGet(ctx, url)
+	if err != nil {
+		return nil, err
+	}
+	ppRsp = &ProductDetailRsp{Code: Success}
+	ppRsp.Response = new(Product)
+	if err = json.Unmarshal(bs, ppRsp.Response); err != nil {
+		return nil, fmt.Errorf("json.Unmarshal(%s)：%w", string(bs), err)
+	}
+	if res.StatusCode != http.StatusOK {
+		ppRsp.Code = res.StatusCode
+		ppRsp.Error = string(bs)
+		ppRsp.ErrorResponse = new(ErrorResponse)
+		_ = json.Unmarshal(bs, ppRsp.ErrorResponse)
+		return ppRsp, newError(res, bs, ppRsp.ErrorResponse)
+	}
+	return ppRsp, nil
+}
+
+// 更新商品（Update product）
+//	patchs：可更新 description、category、image_url、home_url
+//	Code = 0 is success
+//	文档：https://developer.paypal.com/docs/api/catalog-products/v1/#products_patch
+func (c *Client) UpdateProduct(ctx context.Context, productId string, patchs []*Patch) (ppRsp *EmptyRsp, err error) {
+	if productId == gopay.NULL {
+		return nil, errors.New("product_id is empty")
+	}
+	url := fmt.Sprintf(productUpdate, productId)
+	res, bs, err := c.doPayPalPatch(ctx, patchs, url)
+	if err != nil {
+		return nil, err
+	}
+	ppRsp = &EmptyRsp{Code: Success}
+	if res.StatusCode != http.StatusNoContent {
+		ppRsp.Code = res.StatusCode
+		ppRsp.Error = string(bs)
+		ppRsp.ErrorResponse = new(ErrorResponse)
+		_ = json.Unmarshal(bs, ppRsp.ErrorResponse)
+		return ppRsp, newError(res, bs, ppRsp.ErrorResponse)
+	}
+	return ppRsp, nil
+}
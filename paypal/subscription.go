@@ -0,0 +1,235 @@
+package paypal
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/yuanqinguo/gopay"
+)
+
+// 创建订阅（Create subscription）
+//	创建成功后，引导用户打开 Response.Links 中 rel 为 approve 的地址完成授权
+//	Code = 0 is success
+//	文档：https://developer.paypal.com/docs/api/subscriptions/v1/#subscriptions_create
+func (c *Client) CreateSubscription(ctx context.Context, bm gopay.BodyMap) (ppRsp *CreateSubscriptionRsp, err error) {
+	if err = bm.CheckEmptyError("plan_id"); err != nil {
+		return nil, err
+	}
+	res, bs, err := c.doPayPalPost(ctx, bm, subscriptionCreate)
+	if err != nil {
+		return nil, err
+	}
+	ppRsp = &CreateSubscriptionRsp{Code: Success}
+	ppRsp.Response = new(Subscription)
+	if err = json.Unmarshal(bs, ppRsp.Response); err != nil {
+		return nil, fmt.Errorf("json.Unmarshal(%s)：%w", string(bs), err)
+	}
+	if res.StatusCode != http.StatusCreated {
+		ppRsp.Code = res.StatusCode
+		ppRsp.Error = string(bs)
+		ppRsp.ErrorResponse = new(ErrorResponse)
+		_ = json.Unmarshal(bs, ppRsp.ErrorResponse)
+		return ppRsp, newError(res, bs, ppRsp.ErrorResponse)
+	}
+	return ppRsp, nil
+}
+
+// 订阅详情（Show subscription details）
+//	Code = 0 is success
+//	文档：https://developer.paypal.com/docs/api/subscriptions/v1/#subscriptions_get
+func (c *Client) SubscriptionDetail(ctx context.Context, subscriptionId string) (ppRsp *SubscriptionDetailRsp, err error) {
+	if subscriptionId == gopay.NULL {
+		return nil, errors.New("subscription_id is empty")
+	}
+	url := fmt.Sprintf(subscriptionDetail, subscriptionId)
+	res, bs, err := c.doPayPalGet(ctx, url)
+	if err != nil {
+		return nil, err
+	}
+	ppRsp = &SubscriptionDetailRsp{Code: Success}
+	ppRsp.Response = new(Subscription)
+	if err = json.Unmarshal(bs, ppRsp.Response); err != nil {
+		return nil, fmt.Errorf("json.Unmarshal(%s)：%w", string(bs), err)
+	}
+	if res.StatusCode != http.StatusOK {
+		ppRsp.Code = res.StatusCode
+		ppRsp.Error = string(bs)
+		ppRsp.ErrorResponse = new(ErrorResponse)
+		_ = json.Unmarshal(bs, ppRsp.ErrorResponse)
+		return ppRsp, newError(res, bs, ppRsp.ErrorResponse)
+	}
+	return ppRsp, nil
+}
+
+// 修改订阅计划或数量（Revise plan or quantity of subscription）
+//	需引导用户打开 Response.Links 中 rel 为 approve 的地址确认修改
+//	Code = 0 is success
+//	文档：https://developer.paypal.com/docs/api/subscriptions/v1/#subscriptions_revise
+func (c *Client) SubscriptionRevise(ctx context.Context, subscriptionId string, bm gopay.BodyMap) (ppRsp *SubscriptionReviseRsp, err error) {
+	if subscriptionId == gopay.NULL {
+		return nil, errors.New("subscription_id is empty")
+	}
+	url := fmt.Sprintf(subscriptionRevise, subscriptionId)
+	res, bs, err := c.doPayPalPost(ctx, bm, url)
+	if err != nil {
+		return nil, err
+	}
+	ppRsp = &SubscriptionReviseRsp{Code: Success}
+	ppRsp.Response = new(SubscriptionRevise)
+	if err = json.Unmarshal(bs, ppRsp.Response); err != nil {
+		return nil, fmt.Errorf("json.Unmarshal(%s)：%w", string(bs), err)
+	}
+	if res.StatusCode != http.StatusOK {
+		ppRsp.Code = res.StatusCode
+		ppRsp.Error = string(bs)
+		ppRsp.ErrorResponse = new(ErrorResponse)
+		_ = json.Unmarshal(bs, ppRsp.ErrorResponse)
+		return ppRsp, newError(res, bs, ppRsp.ErrorResponse)
+	}
+	return ppRsp, nil
+}
+
+// 暂停订阅（Suspend subscription）
+//	bm：reason 暂停原因
+//	Code = 0 is success
+//	文档：https://developer.paypal.com/docs/api/subscriptions/v1/#subscriptions_suspend
+func (c *Client) SubscriptionSuspend(ctx context.Context, subscriptionId string, bm gopay.BodyMap) (ppRsp *EmptyRsp, err error) {
+	if subscriptionId == gopay.NULL {
+		return nil, errors.New("subscription_id is empty")
+	}
+	if err = bm.CheckEmptyError("reason"); err != nil {
+		return nil, err
+	}
+	url := fmt.Sprintf(subscriptionSuspend, subscriptionId)
+	res, bs, err := c.doPayPalPost(ctx, bm, url)
+	if err != nil {
+		return nil, err
+	}
+	ppRsp = &EmptyRsp{Code: Success}
+	if res.StatusCode != http.StatusNoContent {
+		ppRsp.Code = res.StatusCode
+		ppRsp.Error = string(bs)
+		ppRsp.ErrorResponse = new(ErrorResponse)
+		_ = json.Unmarshal(bs, ppRsp.ErrorResponse)
+		return ppRsp, newError(res, bs, ppRsp.ErrorResponse)
+	}
+	return ppRsp, nil
+}
+
+// 取消订阅（Cancel subscription）
+//	bm：reason 取消原因
+//	Code = 0 is success
+//	文档：https://developer.paypal.com/docs/api/subscriptions/v1/#subscriptions_cancel
+func (c *Client) SubscriptionCancel(ctx context.Context, subscriptionId string, bm gopay.BodyMap) (ppRsp *EmptyRsp, err error) {
+	if subscriptionId == gopay.NULL {
+		return nil, errors.New("subscription_id is empty")
+	}
+	if err = bm.CheckEmptyError("reason"); err != nil {
+		return nil, err
+	}
+	url := fmt.Sprintf(subscriptionCancel, subscriptionId)
+	res, bs, err := c.doPayPalPost(ctx, bm, url)
+	if err != nil {
+		return nil, err
+	}
+	ppRsp = &EmptyRsp{Code: Success}
+	if res.StatusCode != http.StatusNoContent {
+		ppRsp.Code = res.StatusCode
+		ppRsp.Error = string(bs)
+		ppRsp.ErrorResponse = new(ErrorResponse)
+		_ = json.Unmarshal(bs, ppRsp.ErrorResponse)
+		return ppRsp, newError(res, bs, ppRsp.ErrorResponse)
+	}
+	return ppRsp, nil
+}
+
+// 激活订阅（Activate subscription）
+//	bm：可选参数 reason 激活原因，可传 nil
+//	Code = 0 is success
+//	文档：https://developer.paypal.com/docs/api/subscriptions/v1/#subscriptions_activate
+func (c *Client) SubscriptionActivate(ctx context.Context, subscriptionId string, bm gopay.BodyMap) (ppRsp *EmptyRsp, err error) {
+	if subscriptionId == gopay.NULL {
+		return nil, errors.New("subscription_id is empty")
+	}
+	url := fmt.Sprintf(subscriptionActivate, subscriptionId)
+	res, bs, err := c.doPayPalPost(ctx, bm, url)
+	if err != nil {
+		return nil, err
+	}
+	ppRsp = &EmptyRsp{Code: Success}
+	if res.StatusCode != http.StatusNoContent {
+		ppRsp.Code = res.StatusCode
+		ppRsp.Error = string(bs)
+		ppRsp.ErrorResponse = new(ErrorResponse)
+		_ = json.Unmarshal(bs, ppRsp.ErrorResponse)
+		return ppRsp, newError(res, bs, ppRsp.ErrorResponse)
+	}
+	return ppRsp, nil
+}
+
+// 扣取订阅欠款（Capture authorized payment on subscription）
+//	bm：note、capture_type（OUTSTANDING_BALANCE）、amount
+//	Code = 0 is success
+//	文档：https://developer.paypal.com/docs/api/subscriptions/v1/#subscriptions_capture
+func (c *Client) SubscriptionCapture(ctx context.Context, subscriptionId string, bm gopay.BodyMap) (ppRsp *SubscriptionCaptureRsp, err error) {
+	if subscriptionId == gopay.NULL {
+		return nil, errors.New("subscription_id is empty")
+	}
+	if err = bm.CheckEmptyError("note", "capture_type", "amount"); err != nil {
+		return nil, err
+	}
+	url := fmt.Sprintf(subscriptionCapture, subscriptionId)
+	res, bs, err := c.doPayPalPost(ctx, bm, url)
+	if err != nil {
+		return nil, err
+	}
+	ppRsp = &SubscriptionCaptureRsp{Code: Success}
+	if len(bs) > 0 {
+		ppRsp.Response = new(SubscriptionTransaction)
+		if err = json.Unmarshal(bs, ppRsp.Response); err != nil {
+			return nil, fmt.Errorf("json.Unmarshal(%s)：%w", string(bs), err)
+		}
+	}
+	if res.StatusCode != http.StatusAccepted {
+		ppRsp.Code = res.StatusCode
+		ppRsp.Error = string(bs)
+		ppRsp.ErrorResponse = new(ErrorResponse)
+		_ = json.Unmarshal(bs, ppRsp.ErrorResponse)
+		return ppRsp, newError(res, bs, ppRsp.ErrorResponse)
+	}
+	return ppRsp, nil
+}
+
+// 订阅交易列表（List transactions for subscription）
+//	bm：start_time、end_time，格式如 2021-09-01T00:00:00Z
+//	Code = 0 is success
+//	文档：https://developer.paypal.com/docs/api/subscriptions/v1/#subscriptions_transactions
+func (c *Client) SubscriptionTransactions(ctx context.Context, subscriptionId string, bm gopay.BodyMap) (ppRsp *SubscriptionTransactionListRsp, err error) {
+	if subscriptionId == gopay.NULL {
+		return nil, errors.New("subscription_id is empty")
+	}
+	if err = bm.CheckEmptyError("start_time", "end_time"); err != nil {
+		return nil, err
+	}
+	url := fmt.Sprintf(subscriptionTransactions, subscriptionId) + "?" + bm.EncodeURLParams()
+	res, bs, err := c.doPayPalGet(ctx, url)
+	if err != nil {
+		return nil, err
+	}
+	ppRsp = &SubscriptionTransactionListRsp{Code: Success}
+	ppRsp.Response = new(SubscriptionTransactionList)
+	if err = json.Unmarshal(bs, ppRsp.Response); err != nil {
+		return nil, fmt.Errorf("json.Unmarshal(%s)：%w", string(bs), err)
+	}
+	if res.StatusCode != http.StatusOK {
+		ppRsp.Code = res.StatusCode
+		ppRsp.Error = string(bs)
+		ppRsp.ErrorResponse = new(ErrorResponse)
+		_ = json.Unmarshal(bs, ppRsp.ErrorResponse)
+		return ppRsp, newError(res, bs, ppRsp.ErrorResponse)
+	}
+	return ppRsp, nil
+}
@@ -0,0 +1,286 @@
+package paypal
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/yuanqinguo/gopay"
+	"github.com/yuanqinguo/gopay/pkg/xlog"
+)
+
+func TestCreateProduct(t *testing.T) {
+	skipIfNoClient(t)
+	bm := make(gopay.BodyMap)
+	bm.Set("name", "Video Streaming Service").
+		Set("type", "SERVICE").
+		Set("category", "SOFTWARE")
+
+	ppRsp, err := client.CreateProduct(ctx, bm)
+	if err != nil {
+		xlog.Error(err)
+		return
+	}
+	if ppRsp.Code != Success {
+		xlog.Debugf("ppRsp.Code: %+v", ppRsp.Code)
+		xlog.Debugf("ppRsp.Error: %+v", ppRsp.Error)
+		xlog.Debugf("ppRsp.ErrorResponse: %+v", ppRsp.ErrorResponse)
+		return
+	}
+	xlog.Debugf("ppRsp.Response: %+v", ppRsp.Response)
+}
+
+func TestCreateBillingPlan(t *testing.T) {
+	skipIfNoClient(t)
+	cycles := []*BillingCycle{
+		{
+			Frequency:  &Frequency{IntervalUnit: "MONTH", IntervalCount: 1},
+			TenureType: "REGULAR",
+			Sequence:   1,
+			PricingScheme: &PricingScheme{
+				FixedPrice: &Amount{CurrencyCode: "USD", Value: "10"},
+			},
+		},
+	}
+	bm := make(gopay.BodyMap)
+	bm.Set("product_id", "PROD-XXCD1234QWER65782").
+		Set("name", "Video Streaming Service Plan").
+		Set("billing_cycles", cycles).
+		Set("payment_preferences", &PaymentPreferences{AutoBillOutstanding: true, PaymentFailureThreshold: 3})
+
+	ppRsp, err := client.CreateBillingPlan(ctx, bm)
+	if err != nil {
+		xlog.Error(err)
+		return
+	}
+	if ppRsp.Code != Success {
+		xlog.Debugf("ppRsp.Code: %+v", ppRsp.Code)
+		xlog.Debugf("ppRsp.Error: %+v", ppRsp.Error)
+		xlog.Debugf("ppRsp.ErrorResponse: %+v", ppRsp.ErrorResponse)
+		return
+	}
+	xlog.Debugf("ppRsp.Response: %+v", ppRsp.Response)
+}
+
+func TestBillingPlanUpdatePricing(t *testing.T) {
+	skipIfNoClient(t)
+	bm := make(gopay.BodyMap)
+	bm.Set("pricing_schemes", []gopay.BodyMap{
+		{
+			"billing_cycle_sequence": 1,
+			"pricing_scheme":         &PricingScheme{FixedPrice: &Amount{CurrencyCode: "USD", Value: "12"}},
+		},
+	})
+
+	ppRsp, err := client.BillingPlanUpdatePricing(ctx, "P-5ML4271244454362WXNWU5NQ", bm)
+	if err != nil {
+		xlog.Error(err)
+		return
+	}
+	if ppRsp.Code != Success {
+		xlog.Debugf("ppRsp.Code: %+v", ppRsp.Code)
+		xlog.Debugf("ppRsp.Error: %+v", ppRsp.Error)
+		xlog.Debugf("ppRsp.ErrorResponse: %+v", ppRsp.ErrorResponse)
+		return
+	}
+}
+
+func TestCreateSubscription(t *testing.T) {
+	skipIfNoClient(t)
+	bm := make(gopay.BodyMap)
+	bm.Set("plan_id", "P-5ML4271244454362WXNWU5NQ").
+		Set("custom_id", "user_10086")
+
+	ppRsp, err := client.CreateSubscription(ctx, bm)
+	if err != nil {
+		xlog.Error(err)
+		return
+	}
+	if ppRsp.Code != Success {
+		xlog.Debugf("ppRsp.Code: %+v", ppRsp.Code)
+		xlog.Debugf("ppRsp.Error: %+v", ppRsp.Error)
+		xlog.Debugf("ppRsp.ErrorResponse: %+v", ppRsp.ErrorResponse)
+		return
+	}
+	xlog.Debugf("ppRsp.Response: %+v", ppRsp.Response)
+	for _, v := range ppRsp.Response.Links {
+		xlog.Debugf("ppRsp.Response.Links: %+v", v)
+	}
+}
+
+func TestSubscriptionCancel(t *testing.T) {
+	skipIfNoClient(t)
+	bm := make(gopay.BodyMap)
+	bm.Set("reason", "Not satisfied with the service")
+
+	ppRsp, err := client.SubscriptionCancel(ctx, "I-BW452GLLEP1G", bm)
+	if err != nil {
+		xlog.Error(err)
+		return
+	}
+	if ppRsp.Code != Success {
+		xlog.Debugf("ppRsp.Code: %+v", ppRsp.Code)
+		xlog.Debugf("ppRsp.Error: %+v", ppRsp.Error)
+		xlog.Debugf("ppRsp.ErrorResponse: %+v", ppRsp.ErrorResponse)
+		return
+	}
+}
+
+func TestSubscriptionTransactions(t *testing.T) {
+	skipIfNoClient(t)
+	bm := make(gopay.BodyMap)
+	bm.Set("start_time", "2021-09-01T00:00:00Z").
+		Set("end_time", "2021-10-01T00:00:00Z")
+
+	ppRsp, err := client.SubscriptionTransactions(ctx, "I-BW452GLLEP1G", bm)
+	if err != nil {
+		xlog.Error(err)
+		return
+	}
+	if ppRsp.Code != Success {
+		xlog.Debugf("ppRsp.Code: %+v", ppRsp.Code)
+		xlog.Debugf("ppRsp.Error: %+v", ppRsp.Error)
+		xlog.Debugf("ppRsp.ErrorResponse: %+v", ppRsp.ErrorResponse)
+		return
+	}
+	for _, v := range ppRsp.Response.Transactions {
+		xlog.Debugf("ppRsp.Response.Transaction: %+v", v)
+	}
+}
+
+func TestProductAndPlanOffline(t *testing.T) {
+	c, reqs := fakePayPal(t, map[string]fakeRoute{
+		"POST /v1/catalogs/products":                                               {http.StatusCreated, `{"id":"PROD-XXCD1234QWER65782","name":"Video Streaming Service","type":"SERVICE","category":"SOFTWARE"}`},
+		"GET /v1/catalogs/products/PROD-XXCD1234QWER65782":                         {http.StatusOK, `{"id":"PROD-XXCD1234QWER65782","name":"Video Streaming Service","type":"SERVICE"}`},
+		"PATCH /v1/catalogs/products/PROD-XXCD1234QWER65782":                       {http.StatusNoContent, ``},
+		"POST /v1/billing/plans":                                                   {http.StatusCreated, `{"id":"P-5ML4271244454362WXNWU5NQ","product_id":"PROD-XXCD1234QWER65782","status":"ACTIVE","billing_cycles":[{"tenure_type":"REGULAR","sequence":1,"total_cycles":0,"pricing_scheme":{"fixed_price":{"currency_code":"USD","value":"10"}}}]}`},
+		"GET /v1/billing/plans/P-5ML4271244454362WXNWU5NQ":                         {http.StatusOK, `{"id":"P-5ML4271244454362WXNWU5NQ","status":"ACTIVE"}`},
+		"POST /v1/billing/plans/P-5ML4271244454362WXNWU5NQ/deactivate":             {http.StatusNoContent, ``},
+		"POST /v1/billing/plans/P-5ML4271244454362WXNWU5NQ/update-pricing-schemes": {http.StatusNoContent, ``},
+		"POST /v1/billing/plans/P-NOT-EXIST/activate":                              {http.StatusNotFound, `{"name":"RESOURCE_NOT_FOUND","message":"The specified resource does not exist.","debug_id":"b1d1f06c7246c"}`},
+	})
+
+	bm := make(gopay.BodyMap)
+	bm.Set("name", "Video Streaming Service").
+		Set("type", "SERVICE").
+		Set("category", "SOFTWARE")
+	productRsp, err := c.CreateProduct(ctx, bm)
+	if err != nil || productRsp.Code != Success || productRsp.Response.Id != "PROD-XXCD1234QWER65782" {
+		t.Fatalf("unexpected response: %+v, %v", productRsp, err)
+	}
+	if body := reqs["POST /v1/catalogs/products"].body; !strings.Contains(body, `"category":"SOFTWARE"`) {
+		t.Fatalf("unexpected request body: %s", body)
+	}
+	detailRsp, err := c.ProductDetail(ctx, "PROD-XXCD1234QWER65782")
+	if err != nil || detailRsp.Code != Success || detailRsp.Response.Type != "SERVICE" {
+		t.Fatalf("unexpected response: %+v, %v", detailRsp, err)
+	}
+	patchs := []*Patch{{Op: "replace", Path: "/description", Value: "Premium video streaming service"}}
+	if emptyRsp, err := c.UpdateProduct(ctx, "PROD-XXCD1234QWER65782", patchs); err != nil || emptyRsp.Code != Success {
+		t.Fatalf("unexpected response: %+v, %v", emptyRsp, err)
+	}
+	if body := reqs["PATCH /v1/catalogs/products/PROD-XXCD1234QWER65782"].body; !strings.HasPrefix(body, `[{"op":"replace","path":"/description"`) {
+		t.Fatalf("unexpected request body: %s", body)
+	}
+
+	bm = make(gopay.BodyMap)
+	bm.Set("product_id", "PROD-XXCD1234QWER65782").
+		Set("name", "Video Streaming Service Plan").
+		Set("billing_cycles", []*BillingCycle{{TenureType: "REGULAR", Sequence: 1, PricingScheme: &PricingScheme{FixedPrice: &Amount{CurrencyCode: "USD", Value: "10"}}}}).
+		Set("payment_preferences", &PaymentPreferences{AutoBillOutstanding: true, PaymentFailureThreshold: 3})
+	planRsp, err := c.CreateBillingPlan(ctx, bm)
+	if err != nil || planRsp.Code != Success || planRsp.Response.Id != "P-5ML4271244454362WXNWU5NQ" {
+		t.Fatalf("unexpected response: %+v, %v", planRsp, err)
+	}
+	if cycles := planRsp.Response.BillingCycles; len(cycles) != 1 || cycles[0].PricingScheme.FixedPrice.Value != "10" {
+		t.Fatalf("unexpected billing cycles: %+v", cycles)
+	}
+	planDetailRsp, err := c.BillingPlanDetail(ctx, "P-5ML4271244454362WXNWU5NQ")
+	if err != nil || planDetailRsp.Code != Success || planDetailRsp.Response.Status != "ACTIVE" {
+		t.Fatalf("unexpected response: %+v, %v", planDetailRsp, err)
+	}
+	if emptyRsp, err := c.BillingPlanDeactivate(ctx, "P-5ML4271244454362WXNWU5NQ"); err != nil || emptyRsp.Code != Success {
+		t.Fatalf("unexpected response: %+v, %v", emptyRsp, err)
+	}
+	bm = make(gopay.BodyMap)
+	bm.Set("pricing_schemes", []gopay.BodyMap{{"billing_cycle_sequence": 1}})
+	if emptyRsp, err := c.BillingPlanUpdatePricing(ctx, "P-5ML4271244454362WXNWU5NQ", bm); err != nil || emptyRsp.Code != Success {
+		t.Fatalf("unexpected response: %+v, %v", emptyRsp, err)
+	}
+
+	// 接口失败
+	emptyRsp, err := c.BillingPlanActivate(ctx, "P-NOT-EXIST")
+	e, ok := gopay.AsError(err)
+	if !ok || e.Code != "RESOURCE_NOT_FOUND" || e.RequestId != "b1d1f06c7246c" || emptyRsp.Code != http.StatusNotFound {
+		t.Fatalf("unexpected error: %+v, %v", emptyRsp, err)
+	}
+}
+
+func TestSubscriptionOffline(t *testing.T) {
+	c, reqs := fakePayPal(t, map[string]fakeRoute{
+		"POST /v1/billing/subscriptions":                            {http.StatusCreated, `{"id":"I-BW452GLLEP1G","plan_id":"P-5ML4271244454362WXNWU5NQ","status":"APPROVAL_PENDING","links":[{"href":"https://www.sandbox.paypal.com/webapps/billing/subscriptions?ba_token=BA-2M539689T3856352J","rel":"approve","method":"GET"}]}`},
+		"GET /v1/billing/subscriptions/I-BW452GLLEP1G":              {http.StatusOK, `{"id":"I-BW452GLLEP1G","status":"ACTIVE","custom_id":"user_10086"}`},
+		"POST /v1/billing/subscriptions/I-BW452GLLEP1G/suspend":     {http.StatusNoContent, ``},
+		"POST /v1/billing/subscriptions/I-BW452GLLEP1G/cancel":      {http.StatusNoContent, ``},
+		"POST /v1/billing/subscriptions/I-BW452GLLEP1G/capture":     {http.StatusAccepted, `{"id":"2UF23736WR5617012","status":"COMPLETED","amount_with_breakdown":{"gross_amount":{"currency_code":"USD","value":"10.00"}}}`},
+		"GET /v1/billing/subscriptions/I-BW452GLLEP1G/transactions": {http.StatusOK, `{"transactions":[{"id":"2UF23736WR5617012","status":"COMPLETED","time":"2021-09-15T10:00:00Z"}],"total_items":1,"total_pages":1}`},
+		"POST /v1/billing/subscriptions/I-NOT-EXIST/activate":       {http.StatusUnprocessableEntity, `{"name":"UNPROCESSABLE_ENTITY","message":"The requested action could not be performed.","debug_id":"f1d2e3c4b5a69"}`},
+	})
+
+	bm := make(gopay.BodyMap)
+	bm.Set("plan_id", "P-5ML4271244454362WXNWU5NQ").
+		Set("custom_id", "user_10086")
+	createRsp, err := c.CreateSubscription(ctx, bm)
+	if err != nil || createRsp.Code != Success || createRsp.Response.Status != "APPROVAL_PENDING" {
+		t.Fatalf("unexpected response: %+v, %v", createRsp, err)
+	}
+	if links := createRsp.Response.Links; len(links) != 1 || links[0].Rel != "approve" {
+		t.Fatalf("unexpected links: %+v", links)
+	}
+	if body := reqs["POST /v1/billing/subscriptions"].body; !strings.Contains(body, `"plan_id":"P-5ML4271244454362WXNWU5NQ"`) {
+		t.Fatalf("unexpected request body: %s", body)
+	}
+	detailRsp, err := c.SubscriptionDetail(ctx, "I-BW452GLLEP1G")
+	if err != nil || detailRsp.Code != Success || detailRsp.Response.CustomId != "user_10086" {
+		t.Fatalf("unexpected response: %+v, %v", detailRsp, err)
+	}
+
+	bm = make(gopay.BodyMap)
+	bm.Set("reason", "Not satisfied with the service")
+	if emptyRsp, err := c.SubscriptionSuspend(ctx, "I-BW452GLLEP1G", bm); err != nil || emptyRsp.Code != Success {
+		t.Fatalf("unexpected response: %+v, %v", emptyRsp, err)
+	}
+	if emptyRsp, err := c.SubscriptionCancel(ctx, "I-BW452GLLEP1G", bm); err != nil || emptyRsp.Code != Success {
+		t.Fatalf("unexpected response: %+v, %v", emptyRsp, err)
+	}
+
+	bm = make(gopay.BodyMap)
+	bm.Set("note", "Charging as the balance reached the limit").
+		Set("capture_type", "OUTSTANDING_BALANCE").
+		Set("amount", &Amount{CurrencyCode: "USD", Value: "10.00"})
+	captureRsp, err := c.SubscriptionCapture(ctx, "I-BW452GLLEP1G", bm)
+	if err != nil || captureRsp.Code != Success || captureRsp.Response.Id != "2UF23736WR5617012" {
+		t.Fatalf("unexpected response: %+v, %v", captureRsp, err)
+	}
+	if captureRsp.Response.AmountWithBreakdown == nil {
+		t.Fatal("amount_with_breakdown not decoded")
+	}
+
+	bm = make(gopay.BodyMap)
+	bm.Set("start_time", "2021-09-01T00:00:00Z").
+		Set("end_time", "2021-10-01T00:00:00Z")
+	txRsp, err := c.SubscriptionTransactions(ctx, "I-BW452GLLEP1G", bm)
+	if err != nil || txRsp.Code != Success || len(txRsp.Response.Transactions) != 1 || txRsp.Response.TotalItems != 1 {
+		t.Fatalf("unexpected response: %+v, %v", txRsp, err)
+	}
+	if query := reqs["GET /v1/billing/subscriptions/I-BW452GLLEP1G/transactions"].query; query != "end_time=2021-10-01T00%3A00%3A00Z&start_time=2021-09-01T00%3A00%3A00Z" {
+		t.Fatalf("unexpected query: %s", query)
+	}
+
+	// 接口失败
+	emptyRsp, err := c.SubscriptionActivate(ctx, "I-NOT-EXIST", nil)
+	e, ok := gopay.AsError(err)
+	if !ok || e.Code != "UNPROCESSABLE_ENTITY" || e.StatusCode != http.StatusUnprocessableEntity || emptyRsp.Code != http.StatusUnprocessableEntity {
+		t.Fatalf("unexpected error: %+v, %v", emptyRsp, err)
+	}
+}
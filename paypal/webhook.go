@@ -0,0 +1,186 @@
+package paypal
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/yuanqinguo/gopay"
+)
+
+// 创建 Webhook（Create webhook）
+//	Code = 0 is success
+//	文档：https://developer.paypal.com/docs/api/webhooks/v1/#webhooks_post
+func (c *Client) CreateWebhook(ctx context.Context, bm gopay.BodyMap) (ppRsp *CreateWebhookRsp, err error) {
+	if err = bm.CheckEmptyError("url", "event_types"); err != nil {
+		return nil, err
+	}
+	res, bs, err := c.doPayPalPost(ctx, bm, webhookCreate)
+	if err != nil {
+		return nil, err
+	}
+	ppRsp = &CreateWebhookRsp{Code: Success}
+	ppRsp.Response = new(Webhook)
+	if err = json.Unmarshal(bs, ppRsp.Response); err != nil {
+		return nil, fmt.Errorf("json.Unmarshal(%s)：%w", string(bs), err)
+	}
+	if res.StatusCode != http.StatusCreated {
+		ppRsp.Code = res.StatusCode
+		ppRsp.Error = string(bs)
+		ppRsp.ErrorResponse = new(ErrorResponse)
+		_ = json.Unmarshal(bs, ppRsp.ErrorResponse)
+		return ppRsp, newError(res, bs, ppRsp.ErrorResponse)
+	}
+	return ppRsp, nil
+}
+
+// Webhook 列表（List webhooks）
+//	bm：可选参数 anchor_type，APPLICATION、ACCOUNT，传 nil 默认 APPLICATION
+//	Code = 0 is success
+//	文档：https://developer.paypal.com/docs/api/webhooks/v1/#webhooks_list
+func (c *Client) WebhookList(ctx context.Context, bm gopay.BodyMap) (ppRsp *WebhookListRsp, err error) {
+	uri := webhookList
+	if bm != nil {
+		uri += "?" + bm.EncodeURLParams()
+	}
+	res, bs, err := c.doPayPalGet(ctx, uri)
+	if err != nil {
+		return nil, err
+	}
+	ppRsp = &WebhookListRsp{Code: Success}
+	ppRsp.Response = new(WebhookList)
+	if err = json.Unmarshal(bs, ppRsp.Response); err != nil {
+		return nil, fmt.Errorf("json.Unmarshal(%s)：%w", string(bs), err)
+	}
+	if res.StatusCode != http.StatusOK {
+		ppRsp.Code = res.StatusCode
+		ppRsp.Error = string(bs)
+		ppRsp.ErrorResponse = new(ErrorResponse)
+		_ = json.Unmarshal(bs, ppRsp.ErrorResponse)
+		return ppRsp, newError(res, bs, ppRsp.ErrorResponse)
+	}
+	return ppRsp, nil
+}
+
+// Webhook 详情（Show webhook details）
+//	Code = 0 is success
+//	文档：https://developer.paypal.com/docs/api/webhooks/v1/#webhooks_get
+func (c *Client) WebhookDetail(ctx context.Context, webhookId string) (ppRsp *WebhookDetailRsp, err error) {
+	if webhookId == gopay.NULL {
+		return nil, errors.New("webhook_id is empty")
+	}
+	url := fmt.Sprintf(webhookDetail, webhookId)
+	res, bs, err := c.doPayPalGet(ctx, url)
+	if err != nil {
+		return nil, err
+	}
+	ppRsp = &WebhookDetailRsp{Code: Success}
+	ppRsp.Response = new(Webhook)
+	if err = json.Unmarshal(bs, ppRsp.Response); err != nil {
+		return nil, fmt.Errorf("json.Unmarshal(%s)：%w", string(bs), err)
+	}
+	if res.StatusCode != http.StatusOK {
+		ppRsp.Code = res.StatusCode
+		ppRsp.Error = string(bs)
+		ppRsp.ErrorResponse = new(ErrorResponse)
+		_ = json.Unmarshal(bs, ppRsp.ErrorResponse)
+		return ppRsp, newError(res, bs, ppRsp.ErrorResponse)
+	}
+	return ppRsp, nil
+}
+
+// 更新 Webhook（Update webhook）
+//	patchs：仅支持 replace 操作，path 为 /url 或 /event_types
+//	Code = 0 is success
+//	文档：https://developer.paypal.com/docs/api/webhooks/v1/#webhooks_update
+func (c *Client) UpdateWebhook(ctx context.Context, webhookId string, patchs []*Patch) (ppRsp *UpdateWebhookRsp, err error) {
+	if webhookId == gopay.NULL {
+		return nil, errors.New("webhook_id is empty")
+	}
+	url := fmt.Sprintf(webhookUpdate, webhookId)
+	res, bs, err := c.doPayPalPatch(ctx, patchs, url)
+	if err != nil {
+		return nil, err
+	}
+	ppRsp = &UpdateWebhookRsp{Code: Success}
+	ppRsp.Response = new(Webhook)
+	if err = json.Unmarshal(bs, ppRsp.Response); err != nil {
+		return nil, fmt.Errorf("json.Unmarshal(%s)：%w", string(bs), err)
+	}
+	if res.StatusCode != http.StatusOK {
+		ppRsp.Code = res.StatusCode
+		ppRsp.Error = string(bs)
+		ppRsp.ErrorResponse = new(ErrorResponse)
+		_ = json.Unmarshal(bs, ppRsp.ErrorResponse)
+		return ppRsp, newError(res, bs, ppRsp.ErrorResponse)
+	}
+	return ppRsp, nil
+}
+
+// 删除 Webhook（Delete webhook）
+//	Code = 0 is success
+//	文档：https://developer.paypal.com/docs/api/webhooks/v1/#webhooks_delete
+func (c *Client) DeleteWebhook(ctx context.Context, webhookId string) (ppRsp *EmptyRsp, err error) {
+	if webhookId == gopay.NULL {
+		return nil, errors.New("webhook_id is empty")
+	}
+	url := fmt.Sprintf(webhookDelete, webhookId)
+	res, bs, err := c.doPayPalDelete(ctx, url)
+	if err != nil {
+		return nil, err
+	}
+	ppRsp = &EmptyRsp{Code: Success}
+	if res.StatusCode != http.StatusNoContent {
+		ppRsp.Code = res.StatusCode
+		ppRsp.Error = string(bs)
+		ppRsp.ErrorResponse = new(ErrorResponse)
+		_ = json.Unmarshal(bs, ppRsp.ErrorResponse)
+		return ppRsp, newError(res, bs, ppRsp.ErrorResponse)
+	}
+	return ppRsp, nil
+}
+
+// 验证 Webhook 签名（Verify webhook signature）
+//	bm：auth_algo、cert_url、transmission_id、transmission_sig、transmission_time 取自通知请求头，webhook_id 为接收通知的 Webhook id，
+//	webhook_event 为通知请求体，可通过 NewVerifyWebhookSignatureBodyMap() 生成
+//	Code = 0 且 Response.VerificationStatus = SUCCESS 时验签成功
+//	文档：https://developer.paypal.com/docs/api/webhooks/v1/#verify-webhook-signature_post
+func (c *Client) VerifyWebhookSignature(ctx context.Context, bm gopay.BodyMap) (ppRsp *VerifyWebhookSignatureRsp, err error) {
+	if err = bm.CheckEmptyError("auth_algo", "cert_url", "transmission_id", "transmission_sig", "transmission_time", "webhook_id", "webhook_event"); err != nil {
+		return nil, err
+	}
+	res, bs, err := c.doPayPalPost(ctx, bm, webhookVerifySignature)
+	if err != nil {
+		return nil, err
+	}
+	ppRsp = &VerifyWebhookSignatureRsp{Code: Success}
+	ppRsp.Response = new(VerifyWebhookSignature)
+	if err = json.Unmarshal(bs, ppRsp.Response); err != nil {
+		return nil, fmt.Errorf("json.Unmarshal(%s)：%w", string(bs), err)
+	}
+	if res.StatusCode != http.StatusOK {
+		ppRsp.Code = res.StatusCode
+		ppRsp.Error = string(bs)
+		ppRsp.ErrorResponse = new(ErrorResponse)
+		_ = json.Unmarshal(bs, ppRsp.ErrorResponse)
+		return ppRsp, newError(res, bs, ppRsp.ErrorResponse)
+	}
+	return ppRsp, nil
+}
+
+// NewVerifyWebhookSignatureBodyMap 通过通知请求头、请求体生成 VerifyWebhookSignature() 的请求参数
+//	webhookId：接收通知的 Webhook id
+//	body：通知请求体原文
+func NewVerifyWebhookSignatureBodyMap(webhookId string, header http.Header, body []byte) (bm gopay.BodyMap) {
+	bm = make(gopay.BodyMap)
+	bm.Set("auth_algo", header.Get(HeaderAuthAlgo)).
+		Set("cert_url", header.Get(HeaderCertUrl)).
+		Set("transmission_id", header.Get(HeaderTransmissionId)).
+		Set("transmission_sig", header.Get(HeaderTransmissionSig)).
+		Set("transmission_time", header.Get(HeaderTransmissionTime)).
+		Set("webhook_id", webhookId).
+		Set("webhook_event", json.RawMessage(body))
+	return bm
+}
@@ -0,0 +1,166 @@
+package paypal
+
+import (
+	"context"
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/base64"
+	"encoding/pem"
+	"hash/crc32"
+	"io/ioutil"
+	"math/big"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+type roundTripFunc func(req *http.Request) (*http.Response, error)
+
+func (f roundTripFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+func TestWebhookVerifier(t *testing.T) {
+	// 模拟 PayPal 根证书及验签证书
+	rootKey, _ := rsa.GenerateKey(rand.Reader, 2048)
+	rootTpl := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "Test Root CA"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+		KeyUsage:              x509.KeyUsageCertSign,
+	}
+	rootDer, _ := x509.CreateCertificate(rand.Reader, rootTpl, rootTpl, &rootKey.PublicKey, rootKey)
+	rootCert, _ := x509.ParseCertificate(rootDer)
+	leafKey, _ := rsa.GenerateKey(rand.Reader, 2048)
+	leafTpl := &x509.Certificate{
+		SerialNumber: big.NewInt(2),
+		Subject:      pkix.Name{CommonName: "messageverificationcerts.sandbox.paypal.com"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+	}
+	leafDer, _ := x509.CreateCertificate(rand.Reader, leafTpl, rootCert, &leafKey.PublicKey, rootKey)
+	leafPem := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: leafDer})
+
+	downloads := 0
+	hc := &http.Client{Transport: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		downloads++
+		return &http.Response{StatusCode: http.StatusOK, Header: make(http.Header), Body: ioutil.NopCloser(strings.NewReader(string(leafPem)))}, nil
+	})}
+	roots := x509.NewCertPool()
+	roots.AddCert(rootCert)
+	verifier := NewWebhookVerifier("1JE4291016473214C").SetHttpClient(hc).SetRootCAs(roots)
+
+	body := []byte(`{"id":"WH-2WR32451HC0233532-67976317FL4543714","create_time":"2021-09-01T10:00:00.000Z","resource_type":"capture","event_type":"PAYMENT.CAPTURE.COMPLETED","summary":"Payment completed for $ 10.99 USD","resource":{"id":"42311647XV020574X","status":"COMPLETED","amount":{"currency_code":"USD","value":"10.99"},"supplementary_data":{"related_ids":{"order_id":"5O190127TN364715T"}}}}`)
+	signHeader := func(transmissionTime string) http.Header {
+		transmissionId := "69cd13f0-d67a-11e5-baa3-778b53f4ae55"
+		h := sha256.Sum256([]byte(transmissionId + "|" + transmissionTime + "|1JE4291016473214C|" + strconv.FormatUint(uint64(crc32.ChecksumIEEE(body)), 10)))
+		sig, _ := rsa.SignPKCS1v15(rand.Reader, leafKey, crypto.SHA256, h[:])
+		header := make(http.Header)
+		header.Set(HeaderTransmissionId, transmissionId)
+		header.Set(HeaderTransmissionTime, transmissionTime)
+		header.Set(HeaderTransmissionSig, base64.StdEncoding.EncodeToString(sig))
+		header.Set(HeaderCertUrl, "https://api.sandbox.paypal.com/v1/notifications/certs/CERT-360caa42-fca2a594-1d93a270")
+		header.Set(HeaderAuthAlgo, "SHA256withRSA")
+		return header
+	}
+	header := signHeader(time.Now().UTC().Format(time.RFC3339))
+
+	for i := 0; i < 2; i++ {
+		if err := verifier.Verify(ctx, header, body); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if downloads != 1 {
+		t.Errorf("expected cert cached, downloads: %d", downloads)
+	}
+	if err := verifier.Verify(ctx, header, append(body, ' ')); err == nil {
+		t.Error("expected verify failed for tampered body")
+	}
+
+	untrusted := header.Clone()
+	untrusted.Set(HeaderCertUrl, "https://example.com/cert.pem")
+	if err := verifier.Verify(ctx, untrusted, body); err == nil {
+		t.Error("expected untrusted cert url error")
+	}
+	if err := NewWebhookVerifier("1JE4291016473214C").SetHttpClient(hc).Verify(ctx, header, body); err == nil {
+		t.Error("expected cert chain verify failed with system roots")
+	}
+
+	// Paypal-Transmission-Time 超出允许的时间差
+	expired := signHeader("2021-09-01T10:00:01Z")
+	if err := verifier.Verify(ctx, expired, body); err == nil || !strings.Contains(err.Error(), "expired") {
+		t.Errorf("expected transmission time expired error, got: %v", err)
+	}
+	if err := NewWebhookVerifier("1JE4291016473214C").SetHttpClient(hc).SetRootCAs(roots).SetMaxAge(0).Verify(ctx, expired, body); err != nil {
+		t.Errorf("expected transmission time not checked, got: %v", err)
+	}
+
+	// 请求体超过限制
+	req := httptest.NewRequest(http.MethodPost, "/paypal/webhook", strings.NewReader(string(body)))
+	req.Header = header
+	if _, err := verifier.SetMaxBodySize(int64(len(body) - 1)).ParseRequest(req); err == nil || !strings.Contains(err.Error(), "too large") {
+		t.Errorf("expected request body too large error, got: %v", err)
+	}
+	req = httptest.NewRequest(http.MethodPost, "/paypal/webhook", strings.NewReader(string(body)))
+	req.Header = header
+	if _, err := verifier.SetMaxBodySize(DefaultWebhookMaxBodySize).ParseRequest(req); err != nil {
+		t.Error(err)
+	}
+
+	event, err := ParseWebhookEvent(body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	capture, err := event.DecodeCapture()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if event.EventType != EventPaymentCaptureCompleted || capture.Id != "42311647XV020574X" || capture.Amount.Value != "10.99" ||
+		capture.SupplementaryData.RelatedIds.OrderId != "5O190127TN364715T" {
+		t.Errorf("unexpected capture: %+v", capture)
+	}
+	if _, err = event.DecodeOrder(); err == nil {
+		t.Error("expected resource_type mismatch error")
+	}
+}
+
+func TestWebhookVerifierDefaultClientVerifiesTLS(t *testing.T) {
+	// 自签名证书的 TLS 服务，默认 http.Client 应拒绝下载
+	hits := 0
+	ts := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hits++
+	}))
+	defer ts.Close()
+
+	tr := http.DefaultTransport.(*http.Transport).Clone()
+	tr.DialContext = func(ctx context.Context, network, _ string) (net.Conn, error) {
+		return (&net.Dialer{}).DialContext(ctx, network, ts.Listener.Addr().String())
+	}
+	defer func(c *http.Client) { webhookCertClient = c }(webhookCertClient)
+	webhookCertClient = &http.Client{Timeout: webhookCertClient.Timeout, Transport: tr}
+
+	header := make(http.Header)
+	header.Set(HeaderTransmissionId, "69cd13f0-d67a-11e5-baa3-778b53f4ae55")
+	header.Set(HeaderTransmissionTime, time.Now().UTC().Format(time.RFC3339))
+	header.Set(HeaderTransmissionSig, "c2ln")
+	header.Set(HeaderCertUrl, "https://api.sandbox.paypal.com/v1/notifications/certs/CERT-360caa42-fca2a594-1d93a270")
+	err := NewWebhookVerifier("1JE4291016473214C").Verify(ctx, header, []byte(`{}`))
+	if err == nil || !strings.Contains(err.Error(), "certificate") {
+		t.Errorf("expected tls certificate verify error, got: %v", err)
+	}
+	if hits != 0 {
+		t.Errorf("expected no request served, hits: %d", hits)
+	}
+}
@@ -0,0 +1,323 @@
+package paypal
+
+import (
+	"context"
+	"crypto"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"hash/crc32"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/yuanqinguo/gopay"
+	"github.com/yuanqinguo/gopay/pkg/xhttp"
+)
+
+// Webhook 事件类型
+const (
+	EventPaymentCaptureCompleted = "PAYMENT.CAPTURE.COMPLETED" // 支付捕获完成
+	EventPaymentCaptureDenied    = "PAYMENT.CAPTURE.DENIED"    // 支付捕获被拒绝
+	EventPaymentCapturePending   = "PAYMENT.CAPTURE.PENDING"   // 支付捕获处理中
+	EventPaymentCaptureRefunded  = "PAYMENT.CAPTURE.REFUNDED"  // 支付捕获已退款
+	EventPaymentCaptureReversed  = "PAYMENT.CAPTURE.REVERSED"  // 支付捕获被撤销（如拒付）
+
+	EventCheckoutOrderApproved  = "CHECKOUT.ORDER.APPROVED"  // 订单已批准
+	EventCheckoutOrderCompleted = "CHECKOUT.ORDER.COMPLETED" // 订单已完成
+	EventCheckoutOrderSaved     = "CHECKOUT.ORDER.SAVED"     // 订单已保存
+	EventCheckoutOrderVoided    = "CHECKOUT.ORDER.VOIDED"    // 订单已作废
+
+	ResourceTypeCapture       = "capture"
+	ResourceTypeRefund        = "refund"
+	ResourceTypeCheckoutOrder = "checkout-order"
+)
+
+const (
+	DefaultWebhookMaxAge      = 10 * time.Minute // 通知 Paypal-Transmission-Time 默认允许的最大时间差
+	DefaultWebhookMaxBodySize = 3 << 20          // ParseRequest() 默认读取的最大请求体，3MB
+)
+
+// webhookCertClient 未设置 http.Client 时，下载 Webhook 证书使用的 http.Client，校验服务端 TLS 证书
+var webhookCertClient = &http.Client{Timeout: 30 * time.Second}
+
+// WebhookEvent Webhook 通知事件
+//	Resource 为事件资源原文，可通过 DecodeCapture()、DecodeRefund()、DecodeOrder() 解析为对应类型的结构体
+type WebhookEvent struct {
+	Id              string          `json:"id,omitempty"`
+	CreateTime      string          `json:"create_time,omitempty"`
+	ResourceType    string          `json:"resource_type,omitempty"`
+	EventVersion    string          `json:"event_version,omitempty"`
+	EventType       string          `json:"event_type,omitempty"`
+	Summary         string          `json:"summary,omitempty"`
+	ResourceVersion string          `json:"resource_version,omitempty"`
+	Resource        json.RawMessage `json:"resource,omitempty"`
+	Links           []*Link         `json:"links,omitempty"`
+}
+
+// CaptureResource PAYMENT.CAPTURE.COMPLETED、DENIED、PENDING 事件的资源
+type CaptureResource struct {
+	PaymentAuthorizeCapture
+	SupplementaryData *SupplementaryData `json:"supplementary_data,omitempty"`
+}
+
+// RefundResource PAYMENT.CAPTURE.REFUNDED、REVERSED 事件的资源
+type RefundResource struct {
+	PaymentCaptureRefund
+	SupplementaryData *SupplementaryData `json:"supplementary_data,omitempty"`
+}
+
+type SupplementaryData struct {
+	RelatedIds *RelatedIds `json:"related_ids,omitempty"`
+}
+
+type RelatedIds struct {
+	OrderId         string `json:"order_id,omitempty"`
+	AuthorizationId string `json:"authorization_id,omitempty"`
+	CaptureId       string `json:"capture_id,omitempty"`
+}
+
+// ParseWebhookEvent 解析 Webhook 通知请求体，未验签，验签请使用 WebhookVerifier 或 client.VerifyWebhookSignature()
+func ParseWebhookEvent(body []byte) (event *WebhookEvent, err error) {
+	event = new(WebhookEvent)
+	if err = json.Unmarshal(body, event); err != nil {
+		return nil, fmt.Errorf("json.Unmarshal(%s)：%w", string(body), err)
+	}
+	if event.Id == gopay.NULL || event.EventType == gopay.NULL {
+		return nil, fmt.Errorf("invalid webhook event: %s", string(body))
+	}
+	return event, nil
+}
+
+// DecodeCapture 解析 PAYMENT.CAPTURE.* 事件中 resource_type 为 capture 的资源
+func (e *WebhookEvent) DecodeCapture() (capture *CaptureResource, err error) {
+	capture = new(CaptureResource)
+	if err = e.decodeResource(ResourceTypeCapture, capture); err != nil {
+		return nil, err
+	}
+	return capture, nil
+}
+
+// DecodeRefund 解析 PAYMENT.CAPTURE.REFUNDED、REVERSED 事件中 resource_type 为 refund 的资源
+func (e *WebhookEvent) DecodeRefund() (refund *RefundResource, err error) {
+	refund = new(RefundResource)
+	if err = e.decodeResource(ResourceTypeRefund, refund); err != nil {
+		return nil, err
+	}
+	return refund, nil
+}
+
+// DecodeOrder 解析 CHECKOUT.ORDER.* 事件中 resource_type 为 checkout-order 的资源
+func (e *WebhookEvent) DecodeOrder() (order *OrderDetail, err error) {
+	order = new(OrderDetail)
+	if err = e.decodeResource(ResourceTypeCheckoutOrder, order); err != nil {
+		return nil, err
+	}
+	return order, nil
+}
+
+func (e *WebhookEvent) decodeResource(resourceType string, ptr interface{}) (err error) {
+	if e.ResourceType != resourceType {
+		return fmt.Errorf("event [%s] resource_type is [%s], not [%s]", e.EventType, e.ResourceType, resourceType)
+	}
+	if err = json.Unmarshal(e.Resource, ptr); err != nil {
+		return fmt.Errorf("json.Unmarshal(%s)：%w", string(e.Resource), err)
+	}
+	return nil
+}
+
+// WebhookVerifier Webhook 通知本地验签
+//	按 PayPal 规则，使用 PAYPAL-CERT-URL 下载的证书验证 PAYPAL-TRANSMISSION-SIG：
+//	签名原文为 transmission_id|transmission_time|webhook_id|crc32(body)，算法为 SHA256withRSA
+//	证书地址仅允许 https://*.paypal.com，证书需通过证书链校验，校验通过后按地址缓存至过期
+//	PAYPAL-TRANSMISSION-TIME 与当前时间相差超过 maxAge 时拒绝通知，防止重放
+type WebhookVerifier struct {
+	webhookId   string
+	hc          *http.Client
+	roots       *x509.CertPool
+	maxAge      time.Duration
+	maxBodySize int64
+	mu          sync.RWMutex
+	certs       map[string]*x509.Certificate
+}
+
+// NewWebhookVerifier 初始化 Webhook 本地验签
+//	webhookId：接收通知的 Webhook id，创建 Webhook 时返回或在开发者后台查看
+func NewWebhookVerifier(webhookId string) (verifier *WebhookVerifier) {
+	return &WebhookVerifier{
+		webhookId:   webhookId,
+		maxAge:      DefaultWebhookMaxAge,
+		maxBodySize: DefaultWebhookMaxBodySize,
+		certs:       make(map[string]*x509.Certificate),
+	}
+}
+
+// SetHttpClient 设置下载证书使用的 http.Client，不设置则使用校验服务端 TLS 证书的默认配置
+//	注意：自定义的 http.Client 不应跳过 TLS 证书校验（InsecureSkipVerify）
+func (v *WebhookVerifier) SetHttpClient(httpClient *http.Client) (verifier *WebhookVerifier) {
+	v.hc = httpClient
+	return v
+}
+
+// SetMaxAge 设置通知 Paypal-Transmission-Time 允许的最大时间差，超出时拒绝通知，默认 DefaultWebhookMaxAge
+//	maxAge 为 0 时不校验
+func (v *WebhookVerifier) SetMaxAge(maxAge time.Duration) (verifier *WebhookVerifier) {
+	v.maxAge = maxAge
+	return v
+}
+
+// SetMaxBodySize 设置 ParseRequest() 读取的最大请求体字节数，超出时返回错误，默认 DefaultWebhookMaxBodySize
+func (v *WebhookVerifier) SetMaxBodySize(maxBodySize int64) (verifier *WebhookVerifier) {
+	if maxBodySize > 0 {
+		v.maxBodySize = maxBodySize
+	}
+	return v
+}
+
+// SetRootCAs 设置校验证书链使用的根证书，不设置则使用系统根证书
+func (v *WebhookVerifier) SetRootCAs(roots *x509.CertPool) (verifier *WebhookVerifier) {
+	v.roots = roots
+	return v
+}
+
+// Verify 验证 Webhook 通知签名
+//	header：通知请求头
+//	body：通知请求体原文
+func (v *WebhookVerifier) Verify(ctx context.Context, header http.Header, body []byte) (err error) {
+	var (
+		transmissionId   = header.Get(HeaderTransmissionId)
+		transmissionTime = header.Get(HeaderTransmissionTime)
+		transmissionSig  = header.Get(HeaderTransmissionSig)
+		certUrl          = header.Get(HeaderCertUrl)
+		authAlgo         = header.Get(HeaderAuthAlgo)
+	)
+	if transmissionId == gopay.NULL || transmissionTime == gopay.NULL || transmissionSig == gopay.NULL || certUrl == gopay.NULL {
+		return errors.New("webhook header Paypal-Transmission-Id, Paypal-Transmission-Time, Paypal-Transmission-Sig, Paypal-Cert-Url cannot be empty")
+	}
+	if authAlgo != gopay.NULL && authAlgo != "SHA256withRSA" {
+		return fmt.Errorf("unsupported webhook auth algo [%s]", authAlgo)
+	}
+	if v.maxAge > 0 {
+		t, err := time.Parse(time.RFC3339, transmissionTime)
+		if err != nil {
+			return fmt.Errorf("invalid %s [%s]", HeaderTransmissionTime, transmissionTime)
+		}
+		if d := time.Since(t); d > v.maxAge || d < -v.maxAge {
+			return fmt.Errorf("webhook %s [%s] expired, max age: %s", HeaderTransmissionTime, transmissionTime, v.maxAge)
+		}
+	}
+	cert, err := v.getCert(ctx, certUrl)
+	if err != nil {
+		return err
+	}
+	pubKey, ok := cert.PublicKey.(*rsa.PublicKey)
+	if !ok {
+		return errors.New("webhook cert public key is not rsa")
+	}
+	sig, err := base64.StdEncoding.DecodeString(transmissionSig)
+	if err != nil {
+		return fmt.Errorf("base64.StdEncoding.DecodeString(%s)：%w", transmissionSig, err)
+	}
+	signData := transmissionId + "|" + transmissionTime + "|" + v.webhookId + "|" + strconv.FormatUint(uint64(crc32.ChecksumIEEE(body)), 10)
+	h := sha256.Sum256([]byte(signData))
+	if err = rsa.VerifyPKCS1v15(pubKey, crypto.SHA256, h[:], sig); err != nil {
+		return fmt.Errorf("webhook sign verify failed: %w", err)
+	}
+	return nil
+}
+
+// ParseRequest 读取通知请求体，验签并解析为 WebhookEvent
+//	请求体超过 maxBodySize 时返回错误
+func (v *WebhookVerifier) ParseRequest(req *http.Request) (event *WebhookEvent, err error) {
+	body, err := ioutil.ReadAll(http.MaxBytesReader(nil, req.Body, v.maxBodySize))
+	if err != nil {
+		return nil, fmt.Errorf("ioutil.ReadAll：%w", err)
+	}
+	if err = v.Verify(req.Context(), req.Header, body); err != nil {
+		return nil, err
+	}
+	return ParseWebhookEvent(body)
+}
+
+func (v *WebhookVerifier) getCert(ctx context.Context, certUrl string) (cert *x509.Certificate, err error) {
+	v.mu.RLock()
+	cert = v.certs[certUrl]
+	v.mu.RUnlock()
+	if cert != nil && time.Now().Before(cert.NotAfter) {
+		return cert, nil
+	}
+	u, err := url.Parse(certUrl)
+	if err != nil {
+		return nil, fmt.Errorf("url.Parse(%s)：%w", certUrl, err)
+	}
+	if u.Scheme != "https" || !isPayPalHost(u.Hostname()) {
+		return nil, fmt.Errorf("untrusted webhook cert url [%s]", certUrl)
+	}
+	hc := v.hc
+	if hc == nil {
+		hc = webhookCertClient
+	}
+	res, bs, errs := xhttp.NewClient().SetHttpClient(hc).Get(certUrl).EndBytesWithContext(ctx)
+	if len(errs) > 0 {
+		return nil, errs[0]
+	}
+	if res.StatusCode != http.StatusOK {
+		return nil, newError(res, bs, nil)
+	}
+	if cert, err = v.parseAndVerifyCert(bs); err != nil {
+		return nil, err
+	}
+	v.mu.Lock()
+	v.certs[certUrl] = cert
+	v.mu.Unlock()
+	return cert, nil
+}
+
+// parseAndVerifyCert 第一个证书为签名证书，其余为中间证书
+func (v *WebhookVerifier) parseAndVerifyCert(bs []byte) (cert *x509.Certificate, err error) {
+	intermediates := x509.NewCertPool()
+	for {
+		var block *pem.Block
+		if block, bs = pem.Decode(bs); block == nil {
+			break
+		}
+		c, err := x509.ParseCertificate(block.Bytes)
+		if err != nil {
+			return nil, fmt.Errorf("x509.ParseCertificate：%w", err)
+		}
+		if cert == nil {
+			cert = c
+			continue
+		}
+		intermediates.AddCert(c)
+	}
+	if cert == nil {
+		return nil, errors.New("webhook cert not found")
+	}
+	if !isPayPalHost(cert.Subject.CommonName) {
+		return nil, fmt.Errorf("untrusted webhook cert subject [%s]", cert.Subject.CommonName)
+	}
+	opts := x509.VerifyOptions{
+		Roots:         v.roots,
+		Intermediates: intermediates,
+		KeyUsages:     []x509.ExtKeyUsage{x509.ExtKeyUsageAny},
+	}
+	if _, err = cert.Verify(opts); err != nil {
+		return nil, fmt.Errorf("webhook cert verify failed: %w", err)
+	}
+	return cert, nil
+}
+
+func isPayPalHost(host string) bool {
+	host = strings.ToLower(host)
+	return host == "paypal.com" || strings.HasSuffix(host, ".paypal.com")
+}
@@ -0,0 +1,94 @@
+package sm2
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+
+	"github.com/emmansun/gmsm/sm2"
+)
+
+// DefaultUID 签名、验签默认使用的用户身份标识（GB/T 35276-2017）
+var DefaultUID = []byte("1234567812345678")
+
+// P256 返回 SM2 推荐曲线 sm2p256v1（GB/T 32918.5-2017）
+//	使用 github.com/emmansun/gmsm 的常量时间实现
+func P256() elliptic.Curve {
+	return sm2.P256()
+}
+
+// PublicKey SM2 公钥
+type PublicKey struct {
+	elliptic.Curve
+	X, Y *big.Int
+}
+
+// PrivateKey SM2 私钥
+type PrivateKey struct {
+	PublicKey
+	D *big.Int
+}
+
+// GenerateKey 生成 SM2 密钥对
+func GenerateKey(random io.Reader) (priv *PrivateKey, err error) {
+	key, err := sm2.GenerateKey(random)
+	if err != nil {
+		return nil, fmt.Errorf("sm2.GenerateKey：%w", err)
+	}
+	priv = &PrivateKey{D: key.D}
+	priv.PublicKey = PublicKey{Curve: P256(), X: key.X, Y: key.Y}
+	return priv, nil
+}
+
+func newPrivateKey(d *big.Int) *PrivateKey {
+	c := P256()
+	priv := &PrivateKey{D: d}
+	priv.PublicKey.Curve = c
+	priv.PublicKey.X, priv.PublicKey.Y = c.ScalarBaseMult(padBytes(d.Bytes()))
+	return priv
+}
+
+// Sign SM2 签名（SM3 摘要），返回 ASN.1 DER 编码的签名
+//	uid：用户身份标识，传 nil 使用 DefaultUID
+func Sign(random io.Reader, priv *PrivateKey, uid, msg []byte) (sig []byte, err error) {
+	if priv == nil || priv.D == nil {
+		return nil, errors.New("sm2: private key is nil")
+	}
+	if uid == nil {
+		uid = DefaultUID
+	}
+	key := &sm2.PrivateKey{PrivateKey: ecdsa.PrivateKey{PublicKey: *priv.PublicKey.toECDSA(), D: priv.D}}
+	if sig, err = key.Sign(random, msg, sm2.NewSM2SignerOption(true, uid)); err != nil {
+		return nil, fmt.Errorf("sm2.Sign：%w", err)
+	}
+	return sig, nil
+}
+
+// Verify SM2 验签（SM3 摘要），sig 为 ASN.1 DER 编码的签名
+//	uid：用户身份标识，传 nil 使用 DefaultUID
+func Verify(pub *PublicKey, uid, msg, sig []byte) bool {
+	if pub == nil || pub.X == nil || pub.Y == nil {
+		return false
+	}
+	if uid == nil {
+		uid = DefaultUID
+	}
+	return sm2.VerifyASN1WithSM2(pub.toECDSA(), uid, msg, sig)
+}
+
+func (pub *PublicKey) toECDSA() *ecdsa.PublicKey {
+	return &ecdsa.PublicKey{Curve: P256(), X: pub.X, Y: pub.Y}
+}
+
+// padBytes 左侧补 0 至 32 字节
+func padBytes(b []byte) []byte {
+	if len(b) >= 32 {
+		return b
+	}
+	out := make([]byte, 32)
+	copy(out[32-len(b):], b)
+	return out
+}
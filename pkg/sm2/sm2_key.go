@@ -0,0 +1,211 @@
+package sm2
+
+import (
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"math/big"
+)
+
+var (
+	oidPublicKeyECDSA = asn1.ObjectIdentifier{1, 2, 840, 10045, 2, 1}
+	oidNamedCurveSM2  = asn1.ObjectIdentifier{1, 2, 156, 10197, 1, 301}
+)
+
+type pkcs8 struct {
+	Version    int
+	Algo       pkix.AlgorithmIdentifier
+	PrivateKey []byte
+}
+
+type ecPrivateKey struct {
+	Version       int
+	PrivateKey    []byte
+	NamedCurveOID asn1.ObjectIdentifier `asn1:"optional,explicit,tag:0"`
+	PublicKey     asn1.BitString        `asn1:"optional,explicit,tag:1"`
+}
+
+type publicKeyInfo struct {
+	Algorithm pkix.AlgorithmIdentifier
+	PublicKey asn1.BitString
+}
+
+type certificate struct {
+	TBSCertificate     tbsCertificate
+	SignatureAlgorithm pkix.AlgorithmIdentifier
+	SignatureValue     asn1.BitString
+}
+
+type tbsCertificate struct {
+	Version            int `asn1:"optional,explicit,default:0,tag:0"`
+	SerialNumber       *big.Int
+	SignatureAlgorithm pkix.AlgorithmIdentifier
+	Issuer             asn1.RawValue
+	Validity           asn1.RawValue
+	Subject            asn1.RawValue
+	PublicKey          publicKeyInfo
+	UniqueId           asn1.BitString   `asn1:"optional,tag:1"`
+	SubjectUniqueId    asn1.BitString   `asn1:"optional,tag:2"`
+	Extensions         []pkix.Extension `asn1:"optional,explicit,tag:3"`
+}
+
+// DecodePrivateKey 解析 PEM 格式的 SM2 私钥，支持 PRIVATE KEY（PKCS#8）、EC PRIVATE KEY（SEC1）
+func DecodePrivateKey(pemContent []byte) (privateKey *PrivateKey, err error) {
+	block, _ := pem.Decode(pemContent)
+	if block == nil {
+		return nil, fmt.Errorf("pem.Decode(%s)：pemContent decode error", pemContent)
+	}
+	switch block.Type {
+	case "PRIVATE KEY":
+		return ParsePKCS8PrivateKey(block.Bytes)
+	case "EC PRIVATE KEY":
+		return ParseECPrivateKey(block.Bytes)
+	default:
+		return nil, fmt.Errorf("unsupported sm2 private key type [%s]", block.Type)
+	}
+}
+
+// DecodePublicKey 解析 PEM 格式的 SM2 公钥，支持 PUBLIC KEY（PKIX）、CERTIFICATE（SM2 证书）
+func DecodePublicKey(pemContent []byte) (publicKey *PublicKey, err error) {
+	block, _ := pem.Decode(pemContent)
+	if block == nil {
+		return nil, fmt.Errorf("pem.Decode(%s)：pemContent decode error", pemContent)
+	}
+	switch block.Type {
+	case "PUBLIC KEY":
+		return ParsePKIXPublicKey(block.Bytes)
+	case "CERTIFICATE":
+		return ParseCertificatePublicKey(block.Bytes)
+	default:
+		return nil, fmt.Errorf("unsupported sm2 public key type [%s]", block.Type)
+	}
+}
+
+// ParsePKCS8PrivateKey 解析 PKCS#8 DER 格式的 SM2 私钥
+func ParsePKCS8PrivateKey(der []byte) (privateKey *PrivateKey, err error) {
+	privKey := new(pkcs8)
+	if _, err = asn1.Unmarshal(der, privKey); err != nil {
+		return nil, fmt.Errorf("asn1.Unmarshal(pkcs8)：%w", err)
+	}
+	if !privKey.Algo.Algorithm.Equal(oidPublicKeyECDSA) {
+		return nil, fmt.Errorf("sm2: unsupported private key algorithm %v", privKey.Algo.Algorithm)
+	}
+	if err = checkNamedCurve(privKey.Algo.Parameters.FullBytes); err != nil {
+		return nil, err
+	}
+	return ParseECPrivateKey(privKey.PrivateKey)
+}
+
+// ParseECPrivateKey 解析 SEC1 DER 格式的 SM2 私钥
+func ParseECPrivateKey(der []byte) (privateKey *PrivateKey, err error) {
+	privKey := new(ecPrivateKey)
+	if _, err = asn1.Unmarshal(der, privKey); err != nil {
+		return nil, fmt.Errorf("asn1.Unmarshal(ecPrivateKey)：%w", err)
+	}
+	if len(privKey.NamedCurveOID) > 0 && !privKey.NamedCurveOID.Equal(oidNamedCurveSM2) {
+		return nil, fmt.Errorf("sm2: unsupported named curve %v", privKey.NamedCurveOID)
+	}
+	d := new(big.Int).SetBytes(privKey.PrivateKey)
+	n := P256().Params().N
+	if d.Sign() <= 0 || d.Cmp(new(big.Int).Sub(n, big.NewInt(1))) >= 0 {
+		return nil, errors.New("sm2: invalid private key")
+	}
+	return newPrivateKey(d), nil
+}
+
+// MarshalPKCS8PrivateKey 将 SM2 私钥编码为 PKCS#8 DER 格式
+func MarshalPKCS8PrivateKey(privateKey *PrivateKey) (der []byte, err error) {
+	ecKey, err := asn1.Marshal(ecPrivateKey{
+		Version:    1,
+		PrivateKey: padBytes(privateKey.D.Bytes()),
+		PublicKey:  asn1.BitString{Bytes: marshalPoint(&privateKey.PublicKey), BitLength: 65 * 8},
+	})
+	if err != nil {
+		return nil, err
+	}
+	params, err := asn1.Marshal(oidNamedCurveSM2)
+	if err != nil {
+		return nil, err
+	}
+	return asn1.Marshal(pkcs8{
+		Algo:       pkix.AlgorithmIdentifier{Algorithm: oidPublicKeyECDSA, Parameters: asn1.RawValue{FullBytes: params}},
+		PrivateKey: ecKey,
+	})
+}
+
+// ParsePKIXPublicKey 解析 PKIX DER 格式的 SM2 公钥
+func ParsePKIXPublicKey(der []byte) (publicKey *PublicKey, err error) {
+	pki := new(publicKeyInfo)
+	if _, err = asn1.Unmarshal(der, pki); err != nil {
+		return nil, fmt.Errorf("asn1.Unmarshal(publicKeyInfo)：%w", err)
+	}
+	return parsePublicKeyInfo(pki)
+}
+
+// ParseCertificatePublicKey 解析 DER 格式的 SM2 证书，返回证书中的公钥，不校验证书签名
+func ParseCertificatePublicKey(der []byte) (publicKey *PublicKey, err error) {
+	cert := new(certificate)
+	if _, err = asn1.Unmarshal(der, cert); err != nil {
+		return nil, fmt.Errorf("asn1.Unmarshal(certificate)：%w", err)
+	}
+	return parsePublicKeyInfo(&cert.TBSCertificate.PublicKey)
+}
+
+// MarshalPKIXPublicKey 将 SM2 公钥编码为 PKIX DER 格式
+func MarshalPKIXPublicKey(publicKey *PublicKey) (der []byte, err error) {
+	params, err := asn1.Marshal(oidNamedCurveSM2)
+	if err != nil {
+		return nil, err
+	}
+	return asn1.Marshal(publicKeyInfo{
+		Algorithm: pkix.AlgorithmIdentifier{Algorithm: oidPublicKeyECDSA, Parameters: asn1.RawValue{FullBytes: params}},
+		PublicKey: asn1.BitString{Bytes: marshalPoint(publicKey), BitLength: 65 * 8},
+	})
+}
+
+func parsePublicKeyInfo(pki *publicKeyInfo) (publicKey *PublicKey, err error) {
+	if !pki.Algorithm.Algorithm.Equal(oidPublicKeyECDSA) {
+		return nil, fmt.Errorf("sm2: unsupported public key algorithm %v", pki.Algorithm.Algorithm)
+	}
+	if err = checkNamedCurve(pki.Algorithm.Parameters.FullBytes); err != nil {
+		return nil, err
+	}
+	return unmarshalPoint(pki.PublicKey.RightAlign())
+}
+
+func checkNamedCurve(params []byte) (err error) {
+	oid := new(asn1.ObjectIdentifier)
+	if _, err = asn1.Unmarshal(params, oid); err != nil {
+		return fmt.Errorf("asn1.Unmarshal(namedCurve)：%w", err)
+	}
+	if !oid.Equal(oidNamedCurveSM2) {
+		return fmt.Errorf("sm2: unsupported named curve %v", *oid)
+	}
+	return nil
+}
+
+// marshalPoint 非压缩格式：04 || X || Y
+func marshalPoint(pub *PublicKey) []byte {
+	out := make([]byte, 1, 65)
+	out[0] = 4
+	out = append(out, padBytes(pub.X.Bytes())...)
+	return append(out, padBytes(pub.Y.Bytes())...)
+}
+
+func unmarshalPoint(data []byte) (pub *PublicKey, err error) {
+	if len(data) != 65 || data[0] != 4 {
+		return nil, errors.New("sm2: invalid public key, only uncompressed point is supported")
+	}
+	c := P256()
+	pub = &PublicKey{
+		Curve: c,
+		X:     new(big.Int).SetBytes(data[1:33]),
+		Y:     new(big.Int).SetBytes(data[33:]),
+	}
+	if !c.IsOnCurve(pub.X, pub.Y) {
+		return nil, errors.New("sm2: invalid public key, point is not on curve")
+	}
+	return pub, nil
+}
@@ -0,0 +1,130 @@
+package sm2
+
+import (
+	"crypto/rand"
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"encoding/pem"
+	"math/big"
+	"strings"
+	"testing"
+)
+
+func hexInt(s string) *big.Int {
+	i, _ := new(big.Int).SetString(s, 16)
+	return i
+}
+
+func TestGBTVector(t *testing.T) {
+	// GB/T 32918.2-2016 附录A 示例（sm2p256v1 曲线）
+	priv := newPrivateKey(hexInt("3945208F7B2144B13F36E38AC6D39F95889393692860B51A42FB81EF4DF7C5B8"))
+	if priv.X.Cmp(hexInt("09F9DF311E5421A150DD7D161E4BC5C672179FAD1833FC076BB08FF356F35020")) != 0 ||
+		priv.Y.Cmp(hexInt("CCEA490CE26775A52DC6EA718CC1AA600AED05FBF35E084A6632F6072DA9AD13")) != 0 {
+		t.Fatalf("unexpected public key: %X, %X", priv.X, priv.Y)
+	}
+	// 随机数 k = 59276E27D506861A16680F3AD9C02DCCEF3CC1FA3CDBE4CE6D54B80DEAC1BC21 时的签名
+	msg := []byte("message digest")
+	sig, _ := asn1.Marshal(struct{ R, S *big.Int }{
+		R: hexInt("F5A03B0648D2C4630EEAC513E1BB81A15944DA3827D5B74143AC7EACEEE720B3"),
+		S: hexInt("B1B6AA29DF212FD8763182BC0D421CA1BB9038FD1F7F42D4840B69C485BBC1AA"),
+	})
+	if !Verify(&priv.PublicKey, nil, msg, sig) {
+		t.Fatal("verify failed")
+	}
+	if Verify(&priv.PublicKey, nil, []byte("message digesT"), sig) {
+		t.Fatal("expected verify failed with modified message")
+	}
+	sig, err := Sign(rand.Reader, priv, nil, msg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !Verify(&priv.PublicKey, nil, msg, sig) {
+		t.Fatal("verify failed")
+	}
+}
+
+func TestSignVerify(t *testing.T) {
+	priv, err := GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	msg := []byte("POST\n/v3/pay/transactions/jsapi\n1554208460\n593BEC0C930BF1AFEB40B4A08C8FB242\n{}\n")
+	sig, err := Sign(rand.Reader, priv, nil, msg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !Verify(&priv.PublicKey, nil, msg, sig) {
+		t.Fatal("verify failed")
+	}
+	if Verify(&priv.PublicKey, []byte("other uid"), msg, sig) {
+		t.Fatal("expected verify failed with other uid")
+	}
+	if Verify(&priv.PublicKey, nil, append(msg, '.'), sig) {
+		t.Fatal("expected verify failed with modified message")
+	}
+}
+
+func TestKeyEncoding(t *testing.T) {
+	priv, err := GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	der, err := MarshalPKCS8PrivateKey(priv)
+	if err != nil {
+		t.Fatal(err)
+	}
+	priv2, err := DecodePrivateKey(pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if priv2.D.Cmp(priv.D) != 0 || priv2.X.Cmp(priv.X) != 0 {
+		t.Fatal("unexpected private key")
+	}
+
+	der, err = MarshalPKIXPublicKey(&priv.PublicKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	pub, err := DecodePublicKey(pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if pub.X.Cmp(priv.X) != 0 || pub.Y.Cmp(priv.Y) != 0 {
+		t.Fatal("unexpected public key")
+	}
+
+	// 证书
+	params, _ := asn1.Marshal(oidNamedCurveSM2)
+	certDer, err := asn1.Marshal(certificate{
+		TBSCertificate: tbsCertificate{
+			Version:            2,
+			SerialNumber:       big.NewInt(1),
+			SignatureAlgorithm: pkix.AlgorithmIdentifier{Algorithm: asn1.ObjectIdentifier{1, 2, 156, 10197, 1, 501}},
+			Issuer:             asn1.RawValue{FullBytes: []byte{0x30, 0x00}},
+			Validity:           asn1.RawValue{FullBytes: []byte{0x30, 0x00}},
+			Subject:            asn1.RawValue{FullBytes: []byte{0x30, 0x00}},
+			PublicKey: publicKeyInfo{
+				Algorithm: pkix.AlgorithmIdentifier{Algorithm: oidPublicKeyECDSA, Parameters: asn1.RawValue{FullBytes: params}},
+				PublicKey: asn1.BitString{Bytes: marshalPoint(&priv.PublicKey), BitLength: 65 * 8},
+			},
+		},
+		SignatureAlgorithm: pkix.AlgorithmIdentifier{Algorithm: asn1.ObjectIdentifier{1, 2, 156, 10197, 1, 501}},
+		SignatureValue:     asn1.BitString{Bytes: []byte{0}, BitLength: 8},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	pub, err = DecodePublicKey(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: certDer}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if pub.X.Cmp(priv.X) != 0 || pub.Y.Cmp(priv.Y) != 0 {
+		t.Fatal("unexpected certificate public key")
+	}
+
+	// RSA 公钥返回错误
+	rsaPub := "-----BEGIN PUBLIC KEY-----\nMFwwDQYJKoZIhvcNAQEBBQADSwAwSAJBAMSh/Kq0tSWiVXQqDpkhC8OFZGSmBCJ5\nJ8Rbj0lGjUMp+uJVU9dWE5ZfnZK1mUDG5KsS0GDpcLAxYgDY1dsX2KECAwEAAQ==\n-----END PUBLIC KEY-----\n"
+	if _, err = DecodePublicKey([]byte(rsaPub)); err == nil || !strings.Contains(err.Error(), "unsupported") {
+		t.Fatalf("expected unsupported error, got: %v", err)
+	}
+}
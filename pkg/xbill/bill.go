@@ -0,0 +1,139 @@
+package xbill
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// 表头中的金额单位后缀，如 订单金额（元）、收入金额（+元）
+var amountSuffixes = []string{"（元）", "（+元）", "（-元）", "(元)", "(+元)", "(-元)"}
+
+// Header 账单表头名称 -> 列序号，去除 BOM 及金额单位后缀，如 订单金额（元）-> 订单金额
+type Header map[string]int
+
+// NewHeader 解析账单表头
+func NewHeader(fields []string) Header {
+	h := make(Header, len(fields))
+	for i, v := range fields {
+		v = strings.TrimPrefix(v, "\ufeff")
+		for _, suffix := range amountSuffixes {
+			v = strings.TrimSuffix(v, suffix)
+		}
+		h[strings.TrimSpace(v)] = i
+	}
+	return h
+}
+
+// Has 是否存在表头 name
+func (h Header) Has(name string) bool {
+	_, ok := h[name]
+	return ok
+}
+
+// Get 获取 name 列的值，不存在时返回空字符串
+func (h Header) Get(fields []string, name string) string {
+	if i, ok := h[name]; ok && i < len(fields) {
+		return fields[i]
+	}
+	return ""
+}
+
+// Amount 获取 name 列的金额（元）并转换为分，为空时返回 0
+func (h Header) Amount(fields []string, name string) (fen int64, err error) {
+	if fen, err = YuanToFen(h.Get(fields, name)); err != nil {
+		return 0, fmt.Errorf("invalid %s：%w", name, err)
+	}
+	return fen, nil
+}
+
+// Count 获取 name 列的笔数，为空时返回 0
+func (h Header) Count(fields []string, name string) (count int64, err error) {
+	v := strings.TrimSpace(h.Get(fields, name))
+	if v == "" {
+		return 0, nil
+	}
+	if count, err = strconv.ParseInt(v, 10, 64); err != nil {
+		return 0, fmt.Errorf("invalid %s [%s]：%w", name, v, err)
+	}
+	return count, nil
+}
+
+// Amounts 批量解析金额字段，m：表头名称 -> 金额（分）指针
+func (h Header) Amounts(fields []string, m map[string]*int64) (err error) {
+	for name, v := range m {
+		if *v, err = h.Amount(fields, name); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Counts 批量解析笔数字段，m：表头名称 -> 笔数指针
+func (h Header) Counts(fields []string, m map[string]*int64) (err error) {
+	for name, v := range m {
+		if *v, err = h.Count(fields, name); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// SplitLine 分割账单行并去除字段首尾空白
+//	明细字段以 ` 开头时，按 ",`" 分割，避免字段内容中的逗号影响解析
+func SplitLine(line string) (fields []string) {
+	if !strings.HasPrefix(line, "`") {
+		fields = strings.Split(line, ",")
+	} else {
+		fields = strings.Split(line[1:], ",`")
+	}
+	for i, v := range fields {
+		fields[i] = strings.TrimSpace(v)
+	}
+	return fields
+}
+
+// YuanToFen 账单金额（元）转换为分，为空时返回 0
+//	按十进制字符串解析，不经过浮点数，避免精度误差；超过两位的小数按四舍五入处理
+func YuanToFen(yuan string) (fen int64, err error) {
+	s := strings.TrimSpace(yuan)
+	if s == "" {
+		return 0, nil
+	}
+	neg := false
+	switch s[0] {
+	case '-':
+		neg, s = true, s[1:]
+	case '+':
+		s = s[1:]
+	}
+	intPart, fracPart := s, ""
+	if i := strings.IndexByte(s, '.'); i != -1 {
+		intPart, fracPart = s[:i], s[i+1:]
+	}
+	if intPart == "" && fracPart == "" || !isDigits(intPart) || !isDigits(fracPart) {
+		return 0, fmt.Errorf("invalid amount [%s]", yuan)
+	}
+	if intPart == "" {
+		intPart = "0"
+	}
+	if fen, err = strconv.ParseInt(intPart+(fracPart + "00")[:2], 10, 64); err != nil {
+		return 0, fmt.Errorf("invalid amount [%s]：%w", yuan, err)
+	}
+	if len(fracPart) > 2 && fracPart[2] >= '5' {
+		fen++
+	}
+	if neg {
+		fen = -fen
+	}
+	return fen, nil
+}
+
+func isDigits(s string) bool {
+	for i := 0; i < len(s); i++ {
+		if s[i] < '0' || s[i] > '9' {
+			return false
+		}
+	}
+	return true
+}
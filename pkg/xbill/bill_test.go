@@ -0,0 +1,55 @@
+package xbill
+
+import "testing"
+
+func TestYuanToFen(t *testing.T) {
+	cases := map[string]int64{
+		"":           0,
+		" 0.00 ":     0,
+		"0.01":       1,
+		"0.29":       29,
+		"1.005":      101,
+		"1.004":      100,
+		"19.9":       1990,
+		"5.":         500,
+		".5":         50,
+		"+10.00":     1000,
+		"-5.00":      -500,
+		"-0.07":      -7,
+		"1234567.89": 123456789,
+		// 浮点数 4.35*100 = 434.99999999999994
+		"4.35": 435,
+	}
+	for yuan, want := range cases {
+		fen, err := YuanToFen(yuan)
+		if err != nil || fen != want {
+			t.Errorf("YuanToFen(%q) = %d, %v, want: %d", yuan, fen, err, want)
+		}
+	}
+	for _, yuan := range []string{".", "-", "1,000.00", "1e2", "abc", "1.2.3", "--1"} {
+		if _, err := YuanToFen(yuan); err == nil {
+			t.Errorf("YuanToFen(%q) expected error", yuan)
+		}
+	}
+}
+
+func TestHeader(t *testing.T) {
+	h := NewHeader(SplitLine("\ufeff交易时间,订单金额（元）,收入金额（+元）,笔数"))
+	fields := SplitLine("`2021-09-01 10:00:00,`0.29,`1,000.00,` 3 ")
+	if !h.Has("订单金额") || !h.Has("收入金额") || !h.Has("交易时间") {
+		t.Fatalf("unexpected header: %v", h)
+	}
+	if fen, err := h.Amount(fields, "订单金额"); err != nil || fen != 29 {
+		t.Fatalf("got: %d, %v", fen, err)
+	}
+	if _, err := h.Amount(fields, "收入金额"); err == nil {
+		t.Fatal("expected invalid amount error")
+	}
+	if fen, err := h.Amount(fields, "退款金额"); err != nil || fen != 0 {
+		t.Fatalf("missing column should be 0, got: %d, %v", fen, err)
+	}
+	var count int64
+	if err := h.Counts(fields, map[string]*int64{"笔数": &count}); err != nil || count != 3 {
+		t.Fatalf("got: %d, %v", count, err)
+	}
+}
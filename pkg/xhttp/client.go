@@ -2,6 +2,7 @@ package xhttp
 
 import (
 	"bytes"
+	"context"
 	"crypto/tls"
 	"encoding/json"
 	"encoding/xml"
@@ -121,6 +122,9 @@ type Client struct {
 
 	jsonByte []byte
 
+	// tlsConfig 发送请求时合并到 HttpClient 的 Transport 中
+	tlsConfig *tls.Config
+
 	Errors []error
 
 	//mu sync.RWMutex
@@ -151,8 +155,20 @@ func (c *Client) SetTransport(transport *http.Transport) (client *Client) {
 	return c
 }
 
+// SetTLSConfig 设置请求的 tls.Config
+//	发送请求时基于 HttpClient 的 *http.Transport 副本设置 TLSClientConfig，保留代理等原有配置，只作用于本次请求
+//	注意：HttpClient.Transport 不是 *http.Transport 时，请求返回错误
 func (c *Client) SetTLSConfig(tlsCfg *tls.Config) (client *Client) {
-	c.Transport = &http.Transport{TLSClientConfig: tlsCfg, DisableKeepAlives: true, Proxy: http.ProxyFromEnvironment}
+	c.tlsConfig = tlsCfg
+	return c
+}
+
+// SetHttpClient 设置发送请求使用的 http.Client，传 nil 时保持默认
+//	注意：SetTransport()、SetTLSConfig()、SetTimeout() 只作用于本次请求，不会修改传入的 http.Client
+func (c *Client) SetHttpClient(httpClient *http.Client) (client *Client) {
+	if httpClient != nil {
+		c.HttpClient = httpClient
+	}
 	return c
 }
 
@@ -279,7 +295,12 @@ func (c *Client) SendString(encodeStr string) (client *Client) {
 }
 
 func (c *Client) EndStruct(v interface{}) (res *http.Response, errs []error) {
-	res, bs, errs := c.EndBytes()
+	return c.EndStructWithContext(context.Background(), v)
+}
+
+// EndStructWithContext 发送请求并解析响应到 v，ctx 的取消与超时会传递到底层的 http 请求
+func (c *Client) EndStructWithContext(ctx context.Context, v interface{}) (res *http.Response, errs []error) {
+	res, bs, errs := c.EndBytesWithContext(ctx)
 	if errs != nil && len(errs) > 0 {
 		c.Errors = append(c.Errors, errs...)
 		return nil, c.Errors
@@ -311,6 +332,11 @@ func (c *Client) EndStruct(v interface{}) (res *http.Response, errs []error) {
 }
 
 func (c *Client) EndBytes() (res *http.Response, bs []byte, errs []error) {
+	return c.EndBytesWithContext(context.Background())
+}
+
+// EndBytesWithContext 发送请求并返回响应内容，ctx 的取消与超时会传递到底层的 http 请求
+func (c *Client) EndBytesWithContext(ctx context.Context) (res *http.Response, bs []byte, errs []error) {
 	if len(c.Errors) > 0 {
 		return nil, nil, c.Errors
 	}
@@ -382,15 +408,12 @@ func (c *Client) EndBytes() (res *http.Response, bs []byte, errs []error) {
 			return nil, errors.New("Only support GET and POST and PUT and DELETE ")
 		}
 
-		req, err := http.NewRequest(c.method, c.url, body)
+		req, err := http.NewRequestWithContext(ctx, c.method, c.url, body)
 		if err != nil {
 			return nil, err
 		}
 		req.Header = c.Header
 		req.Header.Set("Content-Type", c.ContentType)
-		if c.Transport != nil {
-			c.HttpClient.Transport = c.Transport
-		}
 		return req, nil
 	}()
 	if err != nil {
@@ -398,13 +421,15 @@ func (c *Client) EndBytes() (res *http.Response, bs []byte, errs []error) {
 		return nil, nil, c.Errors
 	}
 
-	if c.Timeout != time.Duration(0) {
-		c.HttpClient.Timeout = c.Timeout
-	}
 	if c.Host != "" {
 		req.Host = c.Host
 	}
-	res, err = c.HttpClient.Do(req)
+	hc, err := c.httpClient()
+	if err != nil {
+		c.Errors = append(c.Errors, err)
+		return nil, nil, c.Errors
+	}
+	res, err = hc.Do(req)
 	if err != nil {
 		c.Errors = append(c.Errors, err)
 		return nil, nil, c.Errors
@@ -418,6 +443,62 @@ func (c *Client) EndBytes() (res *http.Response, bs []byte, errs []error) {
 	return res, bs, nil
 }
 
+// httpClient 返回本次请求使用的 http.Client，需要调整 Transport 或 Timeout 时使用 HttpClient 的浅拷贝，避免修改共享的 http.Client
+//	SetTLSConfig() 设置的证书只作用于本次请求，生成的 Transport 不保持连接，避免每次请求遗留空闲连接
+//	需要复用证书连接时，请使用 NewTLSHttpClient() 生成并缓存 http.Client
+func (c *Client) httpClient() (hc *http.Client, err error) {
+	if c.Transport == nil && c.tlsConfig == nil && c.Timeout == time.Duration(0) {
+		return c.HttpClient, nil
+	}
+	client := *c.HttpClient
+	if c.Transport != nil {
+		client.Transport = c.Transport
+	}
+	if c.tlsConfig != nil {
+		transport, err := tlsTransport(client.Transport, c.tlsConfig)
+		if err != nil {
+			return nil, err
+		}
+		transport.DisableKeepAlives = true
+		client.Transport = transport
+	}
+	if c.Timeout != time.Duration(0) {
+		client.Timeout = c.Timeout
+	}
+	return &client, nil
+}
+
+// NewTLSHttpClient 基于 httpClient 生成设置了 tlsCfg 的 http.Client，不会修改传入的 http.Client
+//	httpClient 为 nil 时使用默认配置；httpClient.Transport 为 nil 时基于 http.DefaultTransport
+//	生成的 http.Client 保持连接，应生成一次后缓存复用
+//	httpClient.Transport 不是 *http.Transport 时无法设置证书，返回错误
+func NewTLSHttpClient(httpClient *http.Client, tlsCfg *tls.Config) (hc *http.Client, err error) {
+	if httpClient == nil {
+		httpClient = &http.Client{Timeout: 60 * time.Second, Transport: &http.Transport{Proxy: http.ProxyFromEnvironment}}
+	}
+	client := *httpClient
+	transport, err := tlsTransport(client.Transport, tlsCfg)
+	if err != nil {
+		return nil, err
+	}
+	client.Transport = transport
+	return &client, nil
+}
+
+// tlsTransport 返回设置了 tlsCfg 的 Transport 副本，保留代理等原有配置
+func tlsTransport(rt http.RoundTripper, tlsCfg *tls.Config) (transport *http.Transport, err error) {
+	if rt == nil {
+		rt = http.DefaultTransport
+	}
+	t, ok := rt.(*http.Transport)
+	if !ok {
+		return nil, fmt.Errorf("xhttp: cannot set tls config on custom http.RoundTripper %T, use *http.Transport instead", rt)
+	}
+	transport = t.Clone()
+	transport.TLSClientConfig = tlsCfg
+	return transport, nil
+}
+
 func FormatURLParam(body map[string]interface{}) (urlParam string) {
 	var (
 		buf  strings.Builder
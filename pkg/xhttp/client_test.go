@@ -1,7 +1,12 @@
 package xhttp
 
 import (
+	"context"
+	"crypto/tls"
+	"errors"
 	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
 	"testing"
 	"time"
 
@@ -36,6 +41,97 @@ func TestHttpGet(t *testing.T) {
 	//xlog.Debug(rsp)
 }
 
+func TestHttpContextTimeout(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-r.Context().Done():
+		case <-time.After(2 * time.Second):
+		}
+	}))
+	defer ts.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	_, _, errs := NewClient().Get(ts.URL).EndBytesWithContext(ctx)
+	if len(errs) == 0 {
+		t.Fatal("expected error when context deadline exceeded")
+	}
+	if !errors.Is(errs[0], context.DeadlineExceeded) {
+		t.Fatalf("expected context.DeadlineExceeded, got: %v", errs[0])
+	}
+}
+
+type countRoundTripper struct {
+	count int
+}
+
+func (c *countRoundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
+	c.count++
+	return http.DefaultTransport.RoundTrip(req)
+}
+
+func TestHttpSetHttpClient(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("ok"))
+	}))
+	defer ts.Close()
+
+	rt := new(countRoundTripper)
+	hc := &http.Client{Transport: rt}
+	_, bs, errs := NewClient().SetHttpClient(hc).SetTimeout(5 * time.Second).Get(ts.URL).EndBytes()
+	if len(errs) > 0 {
+		t.Fatal(errs[0])
+	}
+	if string(bs) != "ok" || rt.count != 1 {
+		t.Fatalf("expected request through injected http.Client, got body: %s, count: %d", string(bs), rt.count)
+	}
+	if hc.Timeout != 0 {
+		t.Fatalf("injected http.Client should not be modified, got timeout: %v", hc.Timeout)
+	}
+}
+
+func TestHttpTLSConfig(t *testing.T) {
+	ts := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("ok"))
+	}))
+	defer ts.Close()
+
+	tlsCfg := &tls.Config{InsecureSkipVerify: true}
+	base := &http.Client{Transport: &http.Transport{}}
+	hc, err := NewTLSHttpClient(base, tlsCfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if base.Transport.(*http.Transport).TLSClientConfig == tlsCfg {
+		t.Fatal("injected http.Client should not be modified")
+	}
+	if transport := hc.Transport.(*http.Transport); transport.TLSClientConfig != tlsCfg || transport.DisableKeepAlives {
+		t.Fatalf("unexpected transport: %+v", transport)
+	}
+	_, bs, errs := NewClient().SetHttpClient(hc).Get(ts.URL).EndBytes()
+	if len(errs) > 0 || string(bs) != "ok" {
+		t.Fatalf("got body: %s, errs: %v", string(bs), errs)
+	}
+
+	// 单次请求的证书不保持连接
+	_, bs, errs = NewClient().SetHttpClient(base).SetTLSConfig(tlsCfg).Get(ts.URL).EndBytes()
+	if len(errs) > 0 || string(bs) != "ok" {
+		t.Fatalf("got body: %s, errs: %v", string(bs), errs)
+	}
+
+	// 自定义 RoundTripper 无法设置证书
+	custom := &http.Client{Transport: new(countRoundTripper)}
+	if _, err = NewTLSHttpClient(custom, tlsCfg); err == nil {
+		t.Fatal("expected custom RoundTripper error")
+	}
+	if _, _, errs = NewClient().SetHttpClient(custom).SetTLSConfig(tlsCfg).Get(ts.URL).EndBytes(); len(errs) == 0 {
+		t.Fatal("expected custom RoundTripper error")
+	}
+	if custom.Transport.(*countRoundTripper).count != 0 {
+		t.Fatal("request should not be sent without tls config")
+	}
+}
+
 func TestHttpUploadFile(t *testing.T) {
 	fileContent, err := ioutil.ReadFile("../../logo.png")
 	if err != nil {
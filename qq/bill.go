@@ -0,0 +1,167 @@
+package qq
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/yuanqinguo/gopay/pkg/util"
+	"github.com/yuanqinguo/gopay/pkg/xbill"
+)
+
+// Bill 对账单解析结果，支持 bill_type 为 ALL、SUCCESS、REFUND 的账单
+type Bill struct {
+	Rows    []*BillRow
+	Summary *BillSummary
+}
+
+// BillRow 对账单明细行
+//	金额单位为分，Refund 不为 nil 时为退款行
+type BillRow struct {
+	TradeTime     string      // 交易时间
+	MchId         string      // 商户号
+	SubMchId      string      // 子商户号
+	DeviceInfo    string      // 设备号
+	TransactionId string      // QQ钱包订单号
+	OutTradeNo    string      // 商户订单号
+	Openid        string      // 用户标识
+	TradeType     string      // 交易类型
+	TradeState    string      // 交易状态
+	BankType      string      // 付款银行
+	FeeType       string      // 货币种类
+	TotalFee      int64       // 总金额
+	CouponFee     int64       // 代金券或立减优惠金额
+	Body          string      // 商品名称
+	Attach        string      // 商户数据包
+	Fee           int64       // 手续费
+	Rate          string      // 费率
+	Refund        *BillRefund // 退款信息
+}
+
+// BillRefund 对账单退款信息
+type BillRefund struct {
+	RefundApplyTime   string // 退款申请时间（仅 REFUND 账单）
+	RefundSuccessTime string // 退款成功时间（仅 REFUND 账单）
+	RefundId          string // QQ钱包退款单号
+	OutRefundNo       string // 商户退款单号
+	RefundFee         int64  // 退款金额
+	CouponRefundFee   int64  // 代金券或立减优惠退款金额
+	RefundType        string // 退款类型
+	RefundStatus      string // 退款状态
+}
+
+// BillSummary 对账单汇总，金额单位为分
+type BillSummary struct {
+	TotalCount      int64 // 总交易单数
+	TotalFee        int64 // 总交易额
+	RefundFee       int64 // 总退款金额
+	CouponRefundFee int64 // 总代金券或立减优惠退款金额
+	Fee             int64 // 手续费总金额
+}
+
+// ParseBill 解析对账单 client.StatementDown() 返回的账单内容
+//	账单为 XML 错误信息时，返回 *gopay.Error
+func ParseBill(data []byte) (bill *Bill, err error) {
+	if bytes.HasPrefix(bytes.TrimSpace(data), []byte("<xml>")) {
+		if err = bizErrCheck(data); err != nil {
+			return nil, err
+		}
+		return nil, fmt.Errorf("unexpected bill data: %s", string(data))
+	}
+	var lines [][]string
+	for _, line := range strings.Split(strings.TrimPrefix(string(data), "\ufeff"), "\n") {
+		if line = strings.TrimRight(line, "\r"); line != util.NULL {
+			lines = append(lines, xbill.SplitLine(line))
+		}
+	}
+	if len(lines) == 0 {
+		return nil, errors.New("empty bill")
+	}
+	bill = new(Bill)
+	header, summaryHeader := xbill.NewHeader(lines[0]), xbill.Header(nil)
+	for i, line := range lines[1:] {
+		switch {
+		case line[0] == "总交易单数":
+			summaryHeader = xbill.NewHeader(line)
+		case summaryHeader != nil:
+			if bill.Summary, err = parseBillSummary(summaryHeader, line); err != nil {
+				return nil, err
+			}
+		default:
+			row, err := parseBillRow(header, line)
+			if err != nil {
+				return nil, fmt.Errorf("line %d: %w", i+2, err)
+			}
+			bill.Rows = append(bill.Rows, row)
+		}
+	}
+	return bill, nil
+}
+
+func parseBillRow(h xbill.Header, fields []string) (row *BillRow, err error) {
+	row = &BillRow{
+		TradeTime:     h.Get(fields, "交易时间"),
+		MchId:         h.Get(fields, "商户号"),
+		SubMchId:      h.Get(fields, "子商户号"),
+		DeviceInfo:    h.Get(fields, "设备号"),
+		TransactionId: h.Get(fields, "QQ钱包订单号"),
+		OutTradeNo:    h.Get(fields, "商户订单号"),
+		Openid:        h.Get(fields, "用户标识"),
+		TradeType:     h.Get(fields, "交易类型"),
+		TradeState:    h.Get(fields, "交易状态"),
+		BankType:      h.Get(fields, "付款银行"),
+		FeeType:       h.Get(fields, "货币种类"),
+		Body:          h.Get(fields, "商品名称"),
+		Attach:        h.Get(fields, "商户数据包"),
+		Rate:          h.Get(fields, "费率"),
+	}
+	if row.TotalFee, err = h.Amount(fields, "总金额"); err != nil {
+		return nil, err
+	}
+	if row.CouponFee, err = h.Amount(fields, "代金券或立减优惠金额"); err != nil {
+		return nil, err
+	}
+	if row.Fee, err = h.Amount(fields, "手续费"); err != nil {
+		return nil, err
+	}
+	if refundId := h.Get(fields, "QQ钱包退款单号"); refundId != util.NULL && refundId != "0" {
+		refund := &BillRefund{
+			RefundApplyTime:   h.Get(fields, "退款申请时间"),
+			RefundSuccessTime: h.Get(fields, "退款成功时间"),
+			RefundId:          refundId,
+			OutRefundNo:       h.Get(fields, "商户退款单号"),
+			RefundType:        h.Get(fields, "退款类型"),
+			RefundStatus:      h.Get(fields, "退款状态"),
+		}
+		if refund.RefundFee, err = h.Amount(fields, "退款金额"); err != nil {
+			return nil, err
+		}
+		if refund.CouponRefundFee, err = h.Amount(fields, "代金券或立减优惠退款金额"); err != nil {
+			return nil, err
+		}
+		row.Refund = refund
+	}
+	return row, nil
+}
+
+func parseBillSummary(h xbill.Header, fields []string) (summary *BillSummary, err error) {
+	summary = new(BillSummary)
+	if summary.TotalCount, err = strconv.ParseInt(strings.TrimSpace(h.Get(fields, "总交易单数")), 10, 64); err != nil {
+		return nil, fmt.Errorf("invalid 总交易单数：%w", err)
+	}
+	if summary.TotalFee, err = h.Amount(fields, "总交易额"); err != nil {
+		return nil, err
+	}
+	if summary.RefundFee, err = h.Amount(fields, "总退款金额"); err != nil {
+		return nil, err
+	}
+	if summary.CouponRefundFee, err = h.Amount(fields, "总代金券或立减优惠退款金额"); err != nil {
+		return nil, err
+	}
+	if summary.Fee, err = h.Amount(fields, "手续费总金额"); err != nil {
+		return nil, err
+	}
+	return summary, nil
+}
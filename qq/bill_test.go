@@ -0,0 +1,33 @@
+package qq
+
+import (
+	"testing"
+
+	"github.com/yuanqinguo/gopay"
+)
+
+func TestParseBill(t *testing.T) {
+	data := "交易时间,商户号,子商户号,设备号,QQ钱包订单号,商户订单号,用户标识,交易类型,交易状态,付款银行,货币种类,总金额,代金券或立减优惠金额,QQ钱包退款单号,商户退款单号,退款金额,代金券或立减优惠退款金额,退款类型,退款状态,商品名称,商户数据包,手续费,费率\r\n" +
+		"`2021-03-01 10:00:00,`1368139502,`,`,`1011368139502202103011111,`order_1,`openid_1,`NATIVE,`SUCCESS,`BALANCE,`CNY,`1.00,`0.00,`0,`,`0.00,`0.00,`,`,`商品,`,`0.01,`0.60%\r\n" +
+		"`2021-03-01 11:00:00,`1368139502,`,`,`1011368139502202103011111,`order_1,`openid_1,`NATIVE,`REFUND,`BALANCE,`CNY,`0.00,`0.00,`1101368139502202103011111,`refund_1,`0.50,`0.00,`ORIGINAL,`SUCCESS,`商品,`,`0.00,`0.60%\r\n" +
+		"总交易单数,总交易额,总退款金额,总代金券或立减优惠退款金额,手续费总金额\r\n" +
+		"`2,`1.00,`0.50,`0.00,`0.01\r\n"
+	bill, err := ParseBill([]byte(data))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(bill.Rows) != 2 || bill.Rows[0].Refund != nil || bill.Rows[0].TotalFee != 100 || bill.Rows[0].Fee != 1 {
+		t.Fatalf("unexpected rows: %+v", bill.Rows)
+	}
+	if r := bill.Rows[1].Refund; r == nil || r.RefundFee != 50 || r.OutRefundNo != "refund_1" {
+		t.Fatalf("unexpected refund: %+v", r)
+	}
+	if bill.Summary == nil || bill.Summary.TotalCount != 2 || bill.Summary.TotalFee != 100 || bill.Summary.RefundFee != 50 {
+		t.Fatalf("unexpected summary: %+v", bill.Summary)
+	}
+
+	_, err = ParseBill([]byte("<xml><return_code><![CDATA[FAIL]]></return_code><retcode><![CDATA[66227005]]></retcode><retmsg><![CDATA[bill not exist]]></retmsg></xml>"))
+	if gopayErr, ok := gopay.AsError(err); !ok || gopayErr.Code != "66227005" {
+		t.Fatalf("expected *gopay.Error, got: %v", err)
+	}
+}
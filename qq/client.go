@@ -5,6 +5,7 @@ import (
 	"encoding/xml"
 	"errors"
 	"fmt"
+	"net/http"
 	"strings"
 	"sync"
 
@@ -19,7 +20,9 @@ type Client struct {
 	ApiKey      string
 	IsProd      bool
 	DebugSwitch gopay.DebugSwitch
-	certificate *tls.Certificate
+	tlsConfig   *tls.Config
+	hc          *http.Client
+	certHc      *http.Client
 	mu          sync.RWMutex
 }
 
@@ -58,7 +61,7 @@ func (q *Client) MicroPay(bm gopay.BodyMap) (qqRsp *MicroPayResponse, err error)
 	if err = xml.Unmarshal(bs, qqRsp); err != nil {
 		return nil, fmt.Errorf("xml.Unmarshal(%s)：%w", string(bs), err)
 	}
-	return qqRsp, nil
+	return qqRsp, bizErrCheck(bs)
 }
 
 // 撤销订单
@@ -76,7 +79,7 @@ func (q *Client) Reverse(bm gopay.BodyMap) (qqRsp *ReverseResponse, err error) {
 	if err = xml.Unmarshal(bs, qqRsp); err != nil {
 		return nil, fmt.Errorf("xml.Unmarshal(%s)：%w", string(bs), err)
 	}
-	return qqRsp, nil
+	return qqRsp, bizErrCheck(bs)
 }
 
 // 统一下单
@@ -94,7 +97,7 @@ func (q *Client) UnifiedOrder(bm gopay.BodyMap) (qqRsp *UnifiedOrderResponse, er
 	if err = xml.Unmarshal(bs, qqRsp); err != nil {
 		return nil, fmt.Errorf("xml.Unmarshal(%s)：%w", string(bs), err)
 	}
-	return qqRsp, nil
+	return qqRsp, bizErrCheck(bs)
 }
 
 // 订单查询
@@ -115,7 +118,7 @@ func (q *Client) OrderQuery(bm gopay.BodyMap) (qqRsp *OrderQueryResponse, err er
 	if err = xml.Unmarshal(bs, qqRsp); err != nil {
 		return nil, fmt.Errorf("xml.Unmarshal(%s)：%w", string(bs), err)
 	}
-	return qqRsp, nil
+	return qqRsp, bizErrCheck(bs)
 }
 
 // 关闭订单
@@ -133,7 +136,7 @@ func (q *Client) CloseOrder(bm gopay.BodyMap) (qqRsp *CloseOrderResponse, err er
 	if err = xml.Unmarshal(bs, qqRsp); err != nil {
 		return nil, fmt.Errorf("xml.Unmarshal(%s)：%w", string(bs), err)
 	}
-	return qqRsp, nil
+	return qqRsp, bizErrCheck(bs)
 }
 
 // 申请退款
@@ -162,7 +165,7 @@ func (q *Client) Refund(bm gopay.BodyMap, certFilePath, keyFilePath, pkcs12FileP
 	if err = xml.Unmarshal(bs, qqRsp); err != nil {
 		return nil, fmt.Errorf("xml.Unmarshal(%s)：%w", string(bs), err)
 	}
-	return qqRsp, nil
+	return qqRsp, bizErrCheck(bs)
 }
 
 // 退款查询
@@ -183,7 +186,7 @@ func (q *Client) RefundQuery(bm gopay.BodyMap) (qqRsp *RefundQueryResponse, err
 	if err = xml.Unmarshal(bs, qqRsp); err != nil {
 		return nil, fmt.Errorf("xml.Unmarshal(%s)：%w", string(bs), err)
 	}
-	return qqRsp, nil
+	return qqRsp, bizErrCheck(bs)
 }
 
 // 交易账单
@@ -237,9 +240,9 @@ func (q *Client) doQQ(bm gopay.BodyMap, url string, tlsConfig *tls.Config) (bs [
 		bm.Set("sign", sign)
 	}
 
-	httpClient := xhttp.NewClient()
-	if tlsConfig != nil {
-		httpClient.SetTLSConfig(tlsConfig)
+	httpClient, err := q.newHttpClient(tlsConfig)
+	if err != nil {
+		return nil, err
 	}
 	if q.DebugSwitch == gopay.DebugOn {
 		xlog.Debugf("QQ_Request: %s", bm.JsonBody())
@@ -252,10 +255,10 @@ func (q *Client) doQQ(bm gopay.BodyMap, url string, tlsConfig *tls.Config) (bs [
 		xlog.Debugf("QQ_Response: %s%d %s%s", xlog.Red, res.StatusCode, xlog.Reset, string(bs))
 	}
 	if res.StatusCode != 200 {
-		return nil, fmt.Errorf("HTTP Request Error, StatusCode = %d", res.StatusCode)
+		return nil, httpError(res, bs)
 	}
 	if strings.Contains(string(bs), "HTML") {
-		return nil, errors.New(string(bs))
+		return nil, httpError(res, bs)
 	}
 	return bs, nil
 }
@@ -274,7 +277,7 @@ func (q *Client) doQQGet(bm gopay.BodyMap, url, signType string) (bs []byte, err
 	}
 	param := bm.EncodeURLParams()
 	url = url + "?" + param
-	res, bs, errs := xhttp.NewClient().Get(url).EndBytes()
+	res, bs, errs := xhttp.NewClient().SetHttpClient(q.hc).Get(url).EndBytes()
 	if len(errs) > 0 {
 		return nil, errs[0]
 	}
@@ -282,10 +285,10 @@ func (q *Client) doQQGet(bm gopay.BodyMap, url, signType string) (bs []byte, err
 		xlog.Debugf("QQ_Response: %s%d %s%s", xlog.Red, res.StatusCode, xlog.Reset, string(bs))
 	}
 	if res.StatusCode != 200 {
-		return nil, fmt.Errorf("HTTP Request Error, StatusCode = %d", res.StatusCode)
+		return nil, httpError(res, bs)
 	}
 	if strings.Contains(string(bs), "HTML") || strings.Contains(string(bs), "html") {
-		return nil, errors.New(string(bs))
+		return nil, httpError(res, bs)
 	}
 	return bs, nil
 }
@@ -300,9 +303,9 @@ func (q *Client) doQQRed(bm gopay.BodyMap, url string, tlsConfig *tls.Config) (b
 		bm.Set("sign", sign)
 	}
 
-	httpClient := xhttp.NewClient()
-	if tlsConfig != nil {
-		httpClient.SetTLSConfig(tlsConfig)
+	httpClient, err := q.newHttpClient(tlsConfig)
+	if err != nil {
+		return nil, err
 	}
 	if q.DebugSwitch == gopay.DebugOn {
 		xlog.Debugf("QQ_Request: %s", bm.JsonBody())
@@ -315,10 +318,54 @@ func (q *Client) doQQRed(bm gopay.BodyMap, url string, tlsConfig *tls.Config) (b
 		xlog.Debugf("QQ_Response: %s%d %s%s", xlog.Red, res.StatusCode, xlog.Reset, string(bs))
 	}
 	if res.StatusCode != 200 {
-		return nil, fmt.Errorf("HTTP Request Error, StatusCode = %d", res.StatusCode)
+		return nil, httpError(res, bs)
 	}
 	if strings.Contains(string(bs), "HTML") {
-		return nil, errors.New(string(bs))
+		return nil, httpError(res, bs)
 	}
 	return bs, nil
 }
+
+// httpError QQ http 状态码错误
+func httpError(res *http.Response, bs []byte) error {
+	e := gopay.NewError(gopay.ProviderQQ, res.StatusCode, util.NULL, util.NULL)
+	e.Body = string(bs)
+	return e
+}
+
+// bizErrCheck 检查QQ返回的 return_code、result_code
+//	return_code 为 FAIL 时，Code 为 retcode（为空时为 FAIL），Message 为 return_msg 或 retmsg
+//	result_code 为 FAIL 时，Code 为 err_code，Message 为 err_code_des，err_code 为 SYSTEMERROR 时，可重试
+func bizErrCheck(bs []byte) (err error) {
+	rsp := new(struct {
+		ReturnCode string `xml:"return_code"`
+		ReturnMsg  string `xml:"return_msg"`
+		RetCode    string `xml:"retcode"`
+		RetMsg     string `xml:"retmsg"`
+		ResultCode string `xml:"result_code"`
+		ErrCode    string `xml:"err_code"`
+		ErrCodeDes string `xml:"err_code_des"`
+	})
+	if xml.Unmarshal(bs, rsp) != nil {
+		return nil
+	}
+	var e *gopay.Error
+	switch {
+	case rsp.ReturnCode == gopay.FAIL:
+		code, msg := rsp.RetCode, rsp.ReturnMsg
+		if code == util.NULL {
+			code = rsp.ReturnCode
+		}
+		if msg == util.NULL {
+			msg = rsp.RetMsg
+		}
+		e = gopay.NewError(gopay.ProviderQQ, http.StatusOK, code, msg)
+	case rsp.ResultCode == gopay.FAIL:
+		e = gopay.NewError(gopay.ProviderQQ, http.StatusOK, rsp.ErrCode, rsp.ErrCodeDes)
+		e.Retryable = rsp.ErrCode == "SYSTEMERROR"
+	default:
+		return nil
+	}
+	e.Body = string(bs)
+	return e
+}
@@ -12,13 +12,56 @@ import (
 	"fmt"
 	"hash"
 	"io/ioutil"
+	"net/http"
 	"strings"
 
 	"github.com/yuanqinguo/gopay"
 	"github.com/yuanqinguo/gopay/pkg/util"
+	"github.com/yuanqinguo/gopay/pkg/xhttp"
 	"golang.org/x/crypto/pkcs12"
 )
 
+// 设置自定义的 http.Client，client 的所有请求（包括需要证书的请求）均通过此 http.Client 发出
+//	可用于代理、连接复用、链路追踪、单元测试替身等场景，不设置则每次请求使用默认配置
+//	注意：需要证书的请求，会基于 http.Client 的 *http.Transport 副本设置证书，并缓存复用；如 Transport 非 *http.Transport，需要证书的请求返回错误
+func (q *Client) SetHttpClient(httpClient *http.Client) (client *Client) {
+	q.mu.Lock()
+	q.hc = httpClient
+	q.resetCertHttpClient()
+	q.mu.Unlock()
+	return q
+}
+
+// newHttpClient 生成本次请求使用的 xhttp.Client，tlsConfig 不为 nil 时为需要证书的请求
+//	使用 client 证书时，证书 http.Client 只生成一次并缓存复用；单次请求传入的证书只作用于本次请求
+func (q *Client) newHttpClient(tlsConfig *tls.Config) (httpClient *xhttp.Client, err error) {
+	httpClient = xhttp.NewClient()
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	switch {
+	case tlsConfig == nil:
+		httpClient.SetHttpClient(q.hc)
+	case tlsConfig == q.tlsConfig:
+		if q.certHc == nil {
+			if q.certHc, err = xhttp.NewTLSHttpClient(q.hc, tlsConfig); err != nil {
+				return nil, err
+			}
+		}
+		httpClient.SetHttpClient(q.certHc)
+	default:
+		httpClient.SetHttpClient(q.hc).SetTLSConfig(tlsConfig)
+	}
+	return httpClient, nil
+}
+
+// resetCertHttpClient 证书或 http.Client 变更时，释放缓存的证书 http.Client，调用方需持有写锁
+func (q *Client) resetCertHttpClient() {
+	if q.certHc != nil {
+		q.certHc.CloseIdleConnections()
+		q.certHc = nil
+	}
+}
+
 // 添加QQ证书 Path 路径
 //	certFilePath：apiclient_cert.pem 路径
 //	keyFilePath：apiclient_key.pem 路径
@@ -33,7 +76,8 @@ func (q *Client) AddCertFilePath(certFilePath, keyFilePath, pkcs12FilePath inter
 		return
 	}
 	q.mu.Lock()
-	q.certificate = &config.Certificates[0]
+	q.tlsConfig = config
+	q.resetCertHttpClient()
 	q.mu.Unlock()
 	return nil
 }
@@ -112,12 +156,8 @@ func (q *Client) addCertConfig(certFile, keyFile, pkcs12File interface{}) (tlsCo
 	if certFile == nil && keyFile == nil && pkcs12File == nil {
 		q.mu.RLock()
 		defer q.mu.RUnlock()
-		if q.certificate != nil {
-			tlsConfig = &tls.Config{
-				Certificates:       []tls.Certificate{*q.certificate},
-				InsecureSkipVerify: true,
-			}
-			return tlsConfig, nil
+		if q.tlsConfig != nil {
+			return q.tlsConfig, nil
 		}
 		return nil, errors.New("cert parse failed")
 	}
@@ -36,6 +36,13 @@ func ParseNotifyToBodyMap(req *http.Request) (bm gopay.BodyMap, err error) {
 	return
 }
 
+// NotifyDedupeKey 获取异步通知去重使用的 key（transaction_id），配合 gopay.NotifyDeduper 使用
+//	bm：ParseNotifyToBodyMap() 解析的异步通知参数
+//	返回格式：qq:pay:{transaction_id}，transaction_id 为空时返回空字符串，不做去重
+func NotifyDedupeKey(bm gopay.BodyMap) (key string) {
+	return gopay.NotifyDedupeKey(gopay.ProviderQQ, "pay", bm.GetString("transaction_id"))
+}
+
 // Deprecated
 // 推荐使用 ParseNotifyToBodyMap
 func ParseNotify(req *http.Request) (notifyReq *NotifyRequest, err error) {
@@ -37,7 +37,7 @@ func (q *Client) SendCashRed(bm gopay.BodyMap, certFilePath, keyFilePath, pkcs12
 	if err = xml.Unmarshal(bs, qqRsp); err != nil {
 		return nil, fmt.Errorf("xml.Unmarshal(%s)：%w", string(bs), err)
 	}
-	return qqRsp, nil
+	return qqRsp, bizErrCheck(bs)
 }
 
 // DownloadRedListFile 对账单下载
@@ -70,5 +70,5 @@ func (q *Client) QueryRedInfo(bm gopay.BodyMap) (qqRsp *QueryRedInfoResponse, er
 	if err = xml.Unmarshal(bs, qqRsp); err != nil {
 		return nil, fmt.Errorf("xml.Unmarshal(%s)：%w", string(bs), err)
 	}
-	return qqRsp, nil
+	return qqRsp, bizErrCheck(bs)
 }
@@ -0,0 +1,148 @@
+package reconcile
+
+import (
+	"time"
+
+	"github.com/yuanqinguo/gopay/pkg/util"
+)
+
+// 交易状态
+const (
+	StatusSuccess = "SUCCESS" // 支付成功
+	StatusRefund  = "REFUND"  // 退款
+)
+
+// Record 对账交易记录，各平台账单明细及本地订单统一转换为 Record 后对账
+//	金额单位为分
+type Record struct {
+	Provider    string    // 支付平台，如 gopay.ProviderAlipay
+	TradeNo     string    // 平台交易号，如支付宝交易号、微信订单号
+	OutTradeNo  string    // 商户订单号
+	OutRefundNo string    // 商户退款单号（支付宝为退款请求号），仅退款记录
+	Amount      int64     // 交易金额，退款记录为退款金额，均为正数
+	Fee         int64     // 手续费，退款退回的手续费为负数
+	Status      string    // 交易状态，StatusSuccess 或 StatusRefund
+	Time        time.Time // 交易完成时间，账单时间按北京时间解析
+}
+
+// Key 对账匹配 key：provider + status + out_trade_no + out_refund_no
+func (r *Record) Key() string {
+	return r.Provider + "|" + r.Status + "|" + r.OutTradeNo + "|" + r.OutRefundNo
+}
+
+// Iterator 对账记录迭代器，用于逐条读取本地订单，避免一次性加载
+//	示例：
+//	for it.Next() {
+//		record := it.Record()
+//	}
+//	if err := it.Err(); err != nil {}
+type Iterator interface {
+	Next() bool
+	Record() *Record
+	Err() error
+}
+
+// NewSliceIterator 将 []*Record 包装为 Iterator
+func NewSliceIterator(records []*Record) Iterator {
+	return &sliceIterator{records: records, i: -1}
+}
+
+type sliceIterator struct {
+	records []*Record
+	i       int
+}
+
+func (it *sliceIterator) Next() bool {
+	it.i++
+	return it.i < len(it.records)
+}
+
+func (it *sliceIterator) Record() *Record {
+	return it.records[it.i]
+}
+
+func (it *sliceIterator) Err() error {
+	return nil
+}
+
+// Mismatch 金额不一致的记录
+type Mismatch struct {
+	Bill  *Record // 平台账单记录
+	Local *Record // 本地记录
+}
+
+// Result 对账结果
+type Result struct {
+	Matched    int         // 一致的记录数
+	Missing    []*Record   // 平台账单中有、本地缺失的记录
+	Extra      []*Record   // 本地有、平台账单中没有的记录
+	Mismatched []*Mismatch // 金额不一致的记录
+}
+
+// OK 对账是否全部一致
+func (r *Result) OK() bool {
+	return len(r.Missing) == 0 && len(r.Extra) == 0 && len(r.Mismatched) == 0
+}
+
+// Diff 平台账单记录与本地记录对账
+//	bill：平台账单记录迭代器，可通过 NewSliceIterator() 包装多个平台、多个账单的记录，如 AlipayRecords()、WechatV3Records() 的返回值
+//	local：本地订单记录迭代器，如从订单库中逐条读取当日的支付、退款记录
+//	注意：bill 的全部记录会读入内存建立索引，local 逐条读取不保留（Extra、Mismatched 除外），记录较多的一方应作为 local
+//	按 Record.Key() 匹配，匹配后比较 Amount；Missing、Extra 按 bill、local 的原始顺序返回
+func Diff(bill, local Iterator) (result *Result, err error) {
+	var (
+		records []*Record
+		index   = make(map[string][]*Record)
+	)
+	for bill.Next() {
+		r := bill.Record()
+		records = append(records, r)
+		index[r.Key()] = append(index[r.Key()], r)
+	}
+	if err = bill.Err(); err != nil {
+		return nil, err
+	}
+	matched := make(map[*Record]bool, len(records))
+	result = new(Result)
+	for local.Next() {
+		l := local.Record()
+		key := l.Key()
+		rs := index[key]
+		if len(rs) == 0 {
+			result.Extra = append(result.Extra, l)
+			continue
+		}
+		b := rs[0]
+		if len(rs) == 1 {
+			delete(index, key)
+		} else {
+			index[key] = rs[1:]
+		}
+		matched[b] = true
+		if b.Amount != l.Amount {
+			result.Mismatched = append(result.Mismatched, &Mismatch{Bill: b, Local: l})
+			continue
+		}
+		result.Matched++
+	}
+	if err = local.Err(); err != nil {
+		return nil, err
+	}
+	for _, r := range records {
+		if !matched[r] {
+			result.Missing = append(result.Missing, r)
+		}
+	}
+	return result, nil
+}
+
+// parseTime 解析账单时间，格式 yyyy-MM-dd HH:mm:ss，北京时间，解析失败返回零值
+func parseTime(s string) time.Time {
+	t, err := time.ParseInLocation(util.TimeLayout, s, cst)
+	if err != nil {
+		return time.Time{}
+	}
+	return t
+}
+
+var cst = time.FixedZone("CST", 8*3600)
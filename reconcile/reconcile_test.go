@@ -0,0 +1,94 @@
+package reconcile
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/yuanqinguo/gopay"
+	"github.com/yuanqinguo/gopay/alipay"
+	"github.com/yuanqinguo/gopay/qq"
+	"github.com/yuanqinguo/gopay/wechat"
+)
+
+func TestDiff(t *testing.T) {
+	bill := []*Record{
+		{Provider: gopay.ProviderWechat, OutTradeNo: "order_1", Amount: 100, Status: StatusSuccess},
+		{Provider: gopay.ProviderWechat, OutTradeNo: "order_1", OutRefundNo: "refund_1", Amount: 50, Status: StatusRefund},
+		{Provider: gopay.ProviderAlipay, OutTradeNo: "order_2", Amount: 200, Status: StatusSuccess},
+		{Provider: gopay.ProviderAlipay, OutTradeNo: "order_3", Amount: 300, Status: StatusSuccess},
+	}
+	local := []*Record{
+		{Provider: gopay.ProviderWechat, OutTradeNo: "order_1", Amount: 100, Status: StatusSuccess},
+		{Provider: gopay.ProviderWechat, OutTradeNo: "order_1", OutRefundNo: "refund_1", Amount: 50, Status: StatusRefund},
+		{Provider: gopay.ProviderAlipay, OutTradeNo: "order_2", Amount: 201, Status: StatusSuccess},
+		{Provider: gopay.ProviderQQ, OutTradeNo: "order_4", Amount: 400, Status: StatusSuccess},
+	}
+	result, err := Diff(NewSliceIterator(bill), NewSliceIterator(local))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.OK() || result.Matched != 2 {
+		t.Fatalf("unexpected result: %+v", result)
+	}
+	if len(result.Missing) != 1 || result.Missing[0].OutTradeNo != "order_3" {
+		t.Errorf("unexpected missing: %+v", result.Missing)
+	}
+	if len(result.Extra) != 1 || result.Extra[0].OutTradeNo != "order_4" {
+		t.Errorf("unexpected extra: %+v", result.Extra)
+	}
+	if len(result.Mismatched) != 1 || result.Mismatched[0].Bill.Amount != 200 || result.Mismatched[0].Local.Amount != 201 {
+		t.Errorf("unexpected mismatched: %+v", result.Mismatched)
+	}
+
+	result, err = Diff(NewSliceIterator(bill), NewSliceIterator(bill))
+	if err != nil || !result.OK() || result.Matched != len(bill) {
+		t.Errorf("expected all matched, got: %+v, %v", result, err)
+	}
+
+	wantErr := errors.New("db error")
+	if _, err = Diff(NewSliceIterator(bill), &errIterator{err: wantErr}); err != wantErr {
+		t.Errorf("expected iterator error, got: %v", err)
+	}
+	if _, err = Diff(&errIterator{err: wantErr}, NewSliceIterator(bill)); err != wantErr {
+		t.Errorf("expected bill iterator error, got: %v", err)
+	}
+}
+
+func TestFromBill(t *testing.T) {
+	records := FromAlipayBill(&alipay.Bill{TradeRows: []*alipay.TradeBillRow{
+		{TradeNo: "2021090122001411111111111111", OutTradeNo: "order_1", BizType: "交易", FinishTime: "2021-09-01 10:00:05", TotalAmount: 1001, ServiceFee: -6},
+		{TradeNo: "2021090122001411111111111111", OutTradeNo: "order_1", BizType: "退款", TotalAmount: 1001, ReceiptAmount: -500, ServiceFee: 3, OutRequestNo: "refund_1"},
+	}})
+	if len(records) != 2 || records[0].Amount != 1001 || records[0].Fee != 6 || records[0].Time.IsZero() {
+		t.Fatalf("unexpected alipay records: %+v", records)
+	}
+	if r := records[1]; r.Status != StatusRefund || r.Amount != 500 || r.Fee != -3 || r.OutRefundNo != "refund_1" {
+		t.Errorf("unexpected alipay refund: %+v", r)
+	}
+
+	records = FromWechatBill(&wechat.Bill{Rows: []*wechat.BillRow{
+		{TransactionId: "4200000001", OutTradeNo: "order_1", TradeState: "SUCCESS", SettlementTotalFee: 90, CouponFee: 10},
+		{TransactionId: "4200000001", OutTradeNo: "order_1", TradeState: "REFUND", Refund: &wechat.BillRefund{OutRefundNo: "refund_1", RefundFee: 50}},
+		{TransactionId: "4200000002", OutTradeNo: "order_2", TradeState: "REVOKED", TotalFee: 100},
+	}})
+	if len(records) != 2 || records[0].Amount != 100 || records[1].Status != StatusRefund || records[1].Amount != 50 {
+		t.Errorf("unexpected wechat records: %+v", records)
+	}
+
+	// 退款记录的时间为退款成功时间
+	records = FromQQBill(&qq.Bill{Rows: []*qq.BillRow{
+		{TransactionId: "1011368139502202103011111", OutTradeNo: "order_1", TradeState: "REFUND", TradeTime: "2021-03-01 10:00:00",
+			Refund: &qq.BillRefund{OutRefundNo: "refund_1", RefundFee: 50, RefundSuccessTime: "2021-03-02 11:00:00"}},
+	}})
+	if len(records) != 1 || records[0].Status != StatusRefund || !records[0].Time.Equal(parseTime("2021-03-02 11:00:00")) {
+		t.Errorf("unexpected qq records: %+v", records)
+	}
+}
+
+type errIterator struct {
+	err error
+}
+
+func (it *errIterator) Next() bool      { return false }
+func (it *errIterator) Record() *Record { return nil }
+func (it *errIterator) Err() error      { return it.err }
@@ -0,0 +1,198 @@
+package reconcile
+
+import (
+	"context"
+	"errors"
+
+	"github.com/yuanqinguo/gopay"
+	"github.com/yuanqinguo/gopay/alipay"
+	"github.com/yuanqinguo/gopay/pkg/util"
+	"github.com/yuanqinguo/gopay/qq"
+	"github.com/yuanqinguo/gopay/wechat"
+	wechatv3 "github.com/yuanqinguo/gopay/wechat/v3"
+)
+
+// AlipayRecords 下载支付宝业务账单并转换为对账记录
+//	bm：bill_date 为日账单 yyyy-MM-dd，bill_type 固定为 trade
+func AlipayRecords(ctx context.Context, client *alipay.Client, bm gopay.BodyMap) (records []*Record, err error) {
+	bm.Set("bill_type", alipay.BillTypeTrade)
+	bill, err := client.DataBillDownload(ctx, bm)
+	if err != nil {
+		return nil, err
+	}
+	return FromAlipayBill(bill), nil
+}
+
+// WechatRecords 下载微信对账单并转换为对账记录
+//	bm：同 client.DownloadBill()，bill_type 为空时默认 ALL
+func WechatRecords(client *wechat.Client, bm gopay.BodyMap) (records []*Record, err error) {
+	if bm.GetString("bill_type") == util.NULL {
+		bm.Set("bill_type", "ALL")
+	}
+	rsp, err := client.DownloadBill(bm)
+	if err != nil {
+		return nil, err
+	}
+	bill, err := wechat.ParseBill([]byte(rsp))
+	if err != nil {
+		return nil, err
+	}
+	return FromWechatBill(bill), nil
+}
+
+// WechatV3Records 申请并下载微信v3交易账单，逐行转换为对账记录，并校验账单 hash
+//	bm：同 client.V3BillTradeBill()，bill_type 为空时默认 ALL
+func WechatV3Records(ctx context.Context, client *wechatv3.ClientV3, bm gopay.BodyMap) (records []*Record, err error) {
+	if bm.GetString("bill_type") == util.NULL {
+		bm.Set("bill_type", "ALL")
+	}
+	rsp, err := client.V3BillTradeBill(bm)
+	if err != nil {
+		return nil, err
+	}
+	if rsp.Response == nil || rsp.Response.DownloadUrl == util.NULL {
+		return nil, errors.New("download_url is empty")
+	}
+	body, err := client.V3BillDownLoadBillStream(ctx, rsp.Response.DownloadUrl)
+	if err != nil {
+		return nil, err
+	}
+	defer body.Close()
+	it, err := wechatv3.NewTradeBillIterator(body, rsp.Response, bm.GetString("tar_type"))
+	if err != nil {
+		return nil, err
+	}
+	return FromWechatV3Bill(it)
+}
+
+// QQRecords 下载QQ对账单并转换为对账记录
+//	bm：同 client.StatementDown()，bill_type 为空时默认 ALL
+func QQRecords(client *qq.Client, bm gopay.BodyMap) (records []*Record, err error) {
+	if bm.GetString("bill_type") == util.NULL {
+		bm.Set("bill_type", "ALL")
+	}
+	rsp, err := client.StatementDown(bm)
+	if err != nil {
+		return nil, err
+	}
+	bill, err := qq.ParseBill([]byte(rsp))
+	if err != nil {
+		return nil, err
+	}
+	return FromQQBill(bill), nil
+}
+
+// FromAlipayBill 支付宝业务账单明细转换为对账记录
+//	业务类型为 交易、退款 以外的明细忽略；服务费扣除为负数，转换后 Fee 为正数
+//	退款明细的订单金额为原订单金额，退款金额取商家实收（负数），转换后 Amount 为正数
+func FromAlipayBill(bill *alipay.Bill) (records []*Record) {
+	for _, row := range bill.TradeRows {
+		r := &Record{
+			Provider:   gopay.ProviderAlipay,
+			TradeNo:    row.TradeNo,
+			OutTradeNo: row.OutTradeNo,
+			Fee:        -row.ServiceFee,
+			Time:       parseTime(row.FinishTime),
+		}
+		switch row.BizType {
+		case "交易":
+			r.Status = StatusSuccess
+			r.Amount = row.TotalAmount
+		case "退款":
+			r.Status = StatusRefund
+			r.Amount = -row.ReceiptAmount
+			r.OutRefundNo = row.OutRequestNo
+		default:
+			continue
+		}
+		records = append(records, r)
+	}
+	return records
+}
+
+// FromWechatBill 微信对账单明细转换为对账记录，撤销等非成功、非退款明细忽略
+func FromWechatBill(bill *wechat.Bill) (records []*Record) {
+	for _, row := range bill.Rows {
+		if r := wechatRecord(gopay.ProviderWechat, row); r != nil {
+			records = append(records, r)
+		}
+	}
+	return records
+}
+
+// FromWechatV3Bill 逐行读取微信v3交易账单并转换为对账记录，撤销等非成功、非退款明细忽略
+func FromWechatV3Bill(it *wechatv3.TradeBillIterator) (records []*Record, err error) {
+	for it.Next() {
+		if r := wechatRecord(gopay.ProviderWechatV3, it.Row()); r != nil {
+			records = append(records, r)
+		}
+	}
+	if err = it.Err(); err != nil {
+		return nil, err
+	}
+	return records, nil
+}
+
+// wechatRecord 微信v2、v3交易账单明细转换为对账记录，非成功、非退款明细返回 nil
+func wechatRecord(provider string, row *wechat.BillRow) (r *Record) {
+	r = &Record{
+		Provider:   provider,
+		TradeNo:    row.TransactionId,
+		OutTradeNo: row.OutTradeNo,
+		Fee:        row.Fee,
+		Time:       parseTime(row.TradeTime),
+	}
+	switch {
+	case row.Refund != nil:
+		r.Status = StatusRefund
+		r.Amount = row.Refund.RefundFee
+		r.OutRefundNo = row.Refund.OutRefundNo
+		if row.Refund.RefundSuccessTime != util.NULL {
+			r.Time = parseTime(row.Refund.RefundSuccessTime)
+		}
+	case row.TradeState == StatusSuccess:
+		r.Status = StatusSuccess
+		r.Amount = wechatAmount(row.TotalFee, row.SettlementTotalFee, row.CouponFee)
+	default:
+		return nil
+	}
+	return r
+}
+
+// FromQQBill QQ对账单明细转换为对账记录，非成功、非退款明细忽略
+//	退款记录的时间为退款成功时间（仅 REFUND 账单有此列），无此列时为交易时间
+func FromQQBill(bill *qq.Bill) (records []*Record) {
+	for _, row := range bill.Rows {
+		r := &Record{
+			Provider:   gopay.ProviderQQ,
+			TradeNo:    row.TransactionId,
+			OutTradeNo: row.OutTradeNo,
+			Fee:        row.Fee,
+			Time:       parseTime(row.TradeTime),
+		}
+		switch {
+		case row.Refund != nil:
+			r.Status = StatusRefund
+			r.Amount = row.Refund.RefundFee
+			r.OutRefundNo = row.Refund.OutRefundNo
+			if row.Refund.RefundSuccessTime != util.NULL {
+				r.Time = parseTime(row.Refund.RefundSuccessTime)
+			}
+		case row.TradeState == StatusSuccess:
+			r.Status = StatusSuccess
+			r.Amount = row.TotalFee
+		default:
+			continue
+		}
+		records = append(records, r)
+	}
+	return records
+}
+
+// wechatAmount 订单金额，旧版账单无 订单金额 列时，为应结订单金额 + 代金券金额
+func wechatAmount(totalFee, settlementTotalFee, couponFee int64) int64 {
+	if totalFee != 0 {
+		return totalFee
+	}
+	return settlementTotalFee + couponFee
+}
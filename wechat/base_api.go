@@ -31,7 +31,7 @@ func (w *Client) UnifiedOrder(bm gopay.BodyMap) (wxRsp *UnifiedOrderResponse, er
 	if err = xml.Unmarshal(bs, wxRsp); err != nil {
 		return nil, fmt.Errorf("xml.Unmarshal(%s)：%w", string(bs), err)
 	}
-	return wxRsp, nil
+	return wxRsp, bizErrCheck(bs)
 }
 
 // 提交付款码支付
@@ -55,7 +55,7 @@ func (w *Client) Micropay(bm gopay.BodyMap) (wxRsp *MicropayResponse, err error)
 	if err = xml.Unmarshal(bs, wxRsp); err != nil {
 		return nil, fmt.Errorf("xml.Unmarshal(%s)：%w", string(bs), err)
 	}
-	return wxRsp, nil
+	return wxRsp, bizErrCheck(bs)
 }
 
 // 查询订单
@@ -85,7 +85,7 @@ func (w *Client) QueryOrder(bm gopay.BodyMap) (wxRsp *QueryOrderResponse, resBm
 	if err = xml.Unmarshal(bs, &resBm); err != nil {
 		return nil, nil, fmt.Errorf("xml.UnmarshalBodyMap(%s)：%w", string(bs), err)
 	}
-	return wxRsp, resBm, nil
+	return wxRsp, resBm, bizErrCheck(bs)
 }
 
 // 关闭订单
@@ -108,7 +108,7 @@ func (w *Client) CloseOrder(bm gopay.BodyMap) (wxRsp *CloseOrderResponse, err er
 	if err = xml.Unmarshal(bs, wxRsp); err != nil {
 		return nil, fmt.Errorf("xml.Unmarshal(%s)：%w", string(bs), err)
 	}
-	return wxRsp, nil
+	return wxRsp, bizErrCheck(bs)
 }
 
 // 申请退款
@@ -145,7 +145,7 @@ func (w *Client) Refund(bm gopay.BodyMap) (wxRsp *RefundResponse, resBm gopay.Bo
 	if err = xml.Unmarshal(bs, &resBm); err != nil {
 		return nil, nil, fmt.Errorf("xml.UnmarshalBodyMap(%s)：%w", string(bs), err)
 	}
-	return wxRsp, resBm, nil
+	return wxRsp, resBm, bizErrCheck(bs)
 }
 
 // 查询退款
@@ -175,7 +175,7 @@ func (w *Client) QueryRefund(bm gopay.BodyMap) (wxRsp *QueryRefundResponse, resB
 	if err = xml.Unmarshal(bs, &resBm); err != nil {
 		return nil, nil, fmt.Errorf("xml.UnmarshalBodyMap(%s)：%w", string(bs), err)
 	}
-	return wxRsp, resBm, nil
+	return wxRsp, resBm, bizErrCheck(bs)
 }
 
 // 撤销订单
@@ -205,5 +205,5 @@ func (w *Client) Reverse(bm gopay.BodyMap) (wxRsp *ReverseResponse, err error) {
 	if err = xml.Unmarshal(bs, wxRsp); err != nil {
 		return nil, fmt.Errorf("xml.Unmarshal(%s)：%w", string(bs), err)
 	}
-	return wxRsp, nil
+	return wxRsp, bizErrCheck(bs)
 }
@@ -0,0 +1,318 @@
+package wechat
+
+import (
+	"bytes"
+	"compress/gzip"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"strings"
+
+	"github.com/yuanqinguo/gopay/pkg/util"
+	"github.com/yuanqinguo/gopay/pkg/xbill"
+)
+
+// Bill 交易账单解析结果，支持 bill_type 为 ALL、SUCCESS、REFUND、RECHARGE_REFUND 的账单
+type Bill struct {
+	Rows    []*BillRow
+	Summary *BillSummary
+}
+
+// BillRow 交易账单明细行
+//	金额单位为分，Refund 不为 nil 时为退款行
+type BillRow struct {
+	TradeTime          string      // 交易时间
+	Appid              string      // 公众账号ID
+	MchId              string      // 商户号
+	SubMchId           string      // 特约商户号
+	DeviceInfo         string      // 设备号
+	TransactionId      string      // 微信订单号
+	OutTradeNo         string      // 商户订单号
+	Openid             string      // 用户标识
+	TradeType          string      // 交易类型
+	TradeState         string      // 交易状态
+	BankType           string      // 付款银行
+	FeeType            string      // 货币种类
+	SettlementTotalFee int64       // 应结订单金额
+	CouponFee          int64       // 代金券金额
+	Body               string      // 商品名称
+	Attach             string      // 商户数据包
+	Fee                int64       // 手续费
+	Rate               string      // 费率
+	TotalFee           int64       // 订单金额
+	RateRemark         string      // 费率备注
+	Refund             *BillRefund // 退款信息
+}
+
+// BillRefund 交易账单退款信息
+type BillRefund struct {
+	RefundApplyTime   string // 退款申请时间（仅 REFUND、RECHARGE_REFUND 账单）
+	RefundSuccessTime string // 退款成功时间（仅 REFUND、RECHARGE_REFUND 账单）
+	RefundId          string // 微信退款单号
+	OutRefundNo       string // 商户退款单号
+	RefundFee         int64  // 退款金额
+	CouponRefundFee   int64  // 充值券退款金额
+	RefundType        string // 退款类型
+	RefundStatus      string // 退款状态
+	ApplyRefundFee    int64  // 申请退款金额
+}
+
+// BillSummary 交易账单汇总，金额单位为分
+type BillSummary struct {
+	TotalCount         int64 // 总交易单数
+	SettlementTotalFee int64 // 应结订单总金额
+	RefundFee          int64 // 退款总金额
+	CouponRefundFee    int64 // 充值券退款总金额
+	Fee                int64 // 手续费总金额
+	TotalFee           int64 // 订单总金额
+	ApplyRefundFee     int64 // 申请退款总金额
+}
+
+// FundFlowBill 资金账单解析结果，支持 account_type 为 Basic、Operation、Fees 的账单
+type FundFlowBill struct {
+	Rows    []*FundFlowBillRow
+	Summary *FundFlowBillSummary
+}
+
+// FundFlowBillRow 资金账单明细行，金额单位为分
+type FundFlowBillRow struct {
+	BillingTime   string // 记账时间
+	TransactionId string // 微信支付业务单号
+	FundFlowId    string // 资金流水单号
+	BizName       string // 业务名称
+	BizType       string // 业务类型
+	IncomeType    string // 收支类型：收入、支出
+	Amount        int64  // 收支金额
+	Balance       int64  // 账户结余
+	Applicant     string // 资金变更提交申请人
+	Remark        string // 备注
+	VoucherNo     string // 业务凭证号
+}
+
+// FundFlowBillSummary 资金账单汇总，金额单位为分
+type FundFlowBillSummary struct {
+	TotalCount    int64 // 资金流水总笔数
+	IncomeCount   int64 // 收入笔数
+	IncomeAmount  int64 // 收入金额
+	ExpenseCount  int64 // 支出笔数
+	ExpenseAmount int64 // 支出金额
+}
+
+// ParseBill 解析下载对账单 client.DownloadBill() 返回的账单内容
+//	账单为 XML 错误信息时，返回 *gopay.Error；tar_type 为 GZIP 时，自动解压
+func ParseBill(data []byte) (bill *Bill, err error) {
+	lines, err := billLines(data)
+	if err != nil {
+		return nil, err
+	}
+	bill = new(Bill)
+	header, summaryHeader := xbill.NewHeader(lines[0]), xbill.Header(nil)
+	for i, line := range lines[1:] {
+		switch {
+		case line[0] == "总交易单数":
+			summaryHeader = xbill.NewHeader(line)
+		case summaryHeader != nil:
+			if bill.Summary, err = ParseBillSummary(summaryHeader, line); err != nil {
+				return nil, err
+			}
+		default:
+			row, err := ParseBillRow(header, line)
+			if err != nil {
+				return nil, fmt.Errorf("line %d: %w", i+2, err)
+			}
+			bill.Rows = append(bill.Rows, row)
+		}
+	}
+	return bill, nil
+}
+
+// ParseFundFlowBill 解析下载资金账单 client.DownloadFundFlow() 返回的账单内容
+//	账单为 XML 错误信息时，返回 *gopay.Error；tar_type 为 GZIP 时，自动解压
+func ParseFundFlowBill(data []byte) (bill *FundFlowBill, err error) {
+	lines, err := billLines(data)
+	if err != nil {
+		return nil, err
+	}
+	bill = new(FundFlowBill)
+	header, summaryHeader := xbill.NewHeader(lines[0]), xbill.Header(nil)
+	for i, line := range lines[1:] {
+		switch {
+		case line[0] == "资金流水总笔数":
+			summaryHeader = xbill.NewHeader(line)
+		case summaryHeader != nil:
+			if bill.Summary, err = ParseFundFlowBillSummary(summaryHeader, line); err != nil {
+				return nil, err
+			}
+		default:
+			row, err := ParseFundFlowBillRow(header, line)
+			if err != nil {
+				return nil, fmt.Errorf("line %d: %w", i+2, err)
+			}
+			bill.Rows = append(bill.Rows, row)
+		}
+	}
+	return bill, nil
+}
+
+// billLines 检查 XML 错误信息、GZIP 解压，并按行分割账单，明细字段以 ` 开头
+func billLines(data []byte) (lines [][]string, err error) {
+	if err = billErrCheck(data); err != nil {
+		return nil, err
+	}
+	if len(data) > 2 && data[0] == 0x1f && data[1] == 0x8b {
+		gz, err := gzip.NewReader(bytes.NewReader(data))
+		if err != nil {
+			return nil, fmt.Errorf("gzip.NewReader：%w", err)
+		}
+		if data, err = ioutil.ReadAll(gz); err != nil {
+			return nil, fmt.Errorf("gzip read：%w", err)
+		}
+	}
+	for _, line := range strings.Split(strings.TrimPrefix(string(data), "\ufeff"), "\n") {
+		if line = strings.TrimRight(line, "\r"); line != util.NULL {
+			lines = append(lines, xbill.SplitLine(line))
+		}
+	}
+	if len(lines) == 0 {
+		return nil, errors.New("empty bill")
+	}
+	return lines, nil
+}
+
+// billErrCheck 下载账单失败时，微信返回 XML 格式的错误信息
+func billErrCheck(data []byte) (err error) {
+	if !bytes.HasPrefix(bytes.TrimSpace(data), []byte("<xml>")) {
+		return nil
+	}
+	if err = bizErrCheck(data); err != nil {
+		return err
+	}
+	return fmt.Errorf("unexpected bill data: %s", string(data))
+}
+
+// ParseBillRow 解析交易账单的一行明细，h 为明细表头
+//	V3 交易账单与本账单格式相同，wechat/v3 逐行解析账单时复用
+func ParseBillRow(h xbill.Header, fields []string) (row *BillRow, err error) {
+	row = &BillRow{
+		TradeTime:     h.Get(fields, "交易时间"),
+		Appid:         h.Get(fields, "公众账号ID"),
+		MchId:         h.Get(fields, "商户号"),
+		SubMchId:      h.Get(fields, "特约商户号"),
+		DeviceInfo:    h.Get(fields, "设备号"),
+		TransactionId: h.Get(fields, "微信订单号"),
+		OutTradeNo:    h.Get(fields, "商户订单号"),
+		Openid:        h.Get(fields, "用户标识"),
+		TradeType:     h.Get(fields, "交易类型"),
+		TradeState:    h.Get(fields, "交易状态"),
+		BankType:      h.Get(fields, "付款银行"),
+		FeeType:       h.Get(fields, "货币种类"),
+		Body:          h.Get(fields, "商品名称"),
+		Attach:        h.Get(fields, "商户数据包"),
+		Rate:          h.Get(fields, "费率"),
+		RateRemark:    h.Get(fields, "费率备注"),
+	}
+	if row.SettlementTotalFee, err = h.Amount(fields, "应结订单金额"); err != nil {
+		return nil, err
+	}
+	if row.CouponFee, err = h.Amount(fields, "代金券金额"); err != nil {
+		return nil, err
+	}
+	if row.Fee, err = h.Amount(fields, "手续费"); err != nil {
+		return nil, err
+	}
+	if row.TotalFee, err = h.Amount(fields, "订单金额"); err != nil {
+		return nil, err
+	}
+	if refundId := h.Get(fields, "微信退款单号"); refundId != util.NULL && refundId != "0" {
+		refund := &BillRefund{
+			RefundApplyTime:   h.Get(fields, "退款申请时间"),
+			RefundSuccessTime: h.Get(fields, "退款成功时间"),
+			RefundId:          refundId,
+			OutRefundNo:       h.Get(fields, "商户退款单号"),
+			RefundType:        h.Get(fields, "退款类型"),
+			RefundStatus:      h.Get(fields, "退款状态"),
+		}
+		if refund.RefundFee, err = h.Amount(fields, "退款金额"); err != nil {
+			return nil, err
+		}
+		if refund.CouponRefundFee, err = h.Amount(fields, "充值券退款金额"); err != nil {
+			return nil, err
+		}
+		if refund.ApplyRefundFee, err = h.Amount(fields, "申请退款金额"); err != nil {
+			return nil, err
+		}
+		row.Refund = refund
+	}
+	return row, nil
+}
+
+// ParseBillSummary 解析交易账单的汇总行，h 为汇总表头
+func ParseBillSummary(h xbill.Header, fields []string) (summary *BillSummary, err error) {
+	summary = new(BillSummary)
+	if summary.TotalCount, err = h.Count(fields, "总交易单数"); err != nil {
+		return nil, err
+	}
+	if summary.SettlementTotalFee, err = h.Amount(fields, "应结订单总金额"); err != nil {
+		return nil, err
+	}
+	if summary.RefundFee, err = h.Amount(fields, "退款总金额"); err != nil {
+		return nil, err
+	}
+	if summary.CouponRefundFee, err = h.Amount(fields, "充值券退款总金额"); err != nil {
+		return nil, err
+	}
+	if summary.Fee, err = h.Amount(fields, "手续费总金额"); err != nil {
+		return nil, err
+	}
+	if summary.TotalFee, err = h.Amount(fields, "订单总金额"); err != nil {
+		return nil, err
+	}
+	if summary.ApplyRefundFee, err = h.Amount(fields, "申请退款总金额"); err != nil {
+		return nil, err
+	}
+	return summary, nil
+}
+
+// ParseFundFlowBillRow 解析资金账单的一行明细，h 为明细表头
+//	V3 资金账单与本账单格式相同，wechat/v3 逐行解析账单时复用
+func ParseFundFlowBillRow(h xbill.Header, fields []string) (row *FundFlowBillRow, err error) {
+	row = &FundFlowBillRow{
+		BillingTime:   h.Get(fields, "记账时间"),
+		TransactionId: h.Get(fields, "微信支付业务单号"),
+		FundFlowId:    h.Get(fields, "资金流水单号"),
+		BizName:       h.Get(fields, "业务名称"),
+		BizType:       h.Get(fields, "业务类型"),
+		IncomeType:    h.Get(fields, "收支类型"),
+		Applicant:     h.Get(fields, "资金变更提交申请人"),
+		Remark:        h.Get(fields, "备注"),
+		VoucherNo:     h.Get(fields, "业务凭证号"),
+	}
+	if row.Amount, err = h.Amount(fields, "收支金额"); err != nil {
+		return nil, err
+	}
+	if row.Balance, err = h.Amount(fields, "账户结余"); err != nil {
+		return nil, err
+	}
+	return row, nil
+}
+
+// ParseFundFlowBillSummary 解析资金账单的汇总行，h 为汇总表头
+func ParseFundFlowBillSummary(h xbill.Header, fields []string) (summary *FundFlowBillSummary, err error) {
+	summary = new(FundFlowBillSummary)
+	if summary.TotalCount, err = h.Count(fields, "资金流水总笔数"); err != nil {
+		return nil, err
+	}
+	if summary.IncomeCount, err = h.Count(fields, "收入笔数"); err != nil {
+		return nil, err
+	}
+	if summary.IncomeAmount, err = h.Amount(fields, "收入金额"); err != nil {
+		return nil, err
+	}
+	if summary.ExpenseCount, err = h.Count(fields, "支出笔数"); err != nil {
+		return nil, err
+	}
+	if summary.ExpenseAmount, err = h.Amount(fields, "支出金额"); err != nil {
+		return nil, err
+	}
+	return summary, nil
+}
@@ -0,0 +1,55 @@
+package wechat
+
+import (
+	"bytes"
+	"compress/gzip"
+	"testing"
+
+	"github.com/yuanqinguo/gopay"
+)
+
+func TestParseBill(t *testing.T) {
+	data := "交易时间,公众账号ID,商户号,特约商户号,设备号,微信订单号,商户订单号,用户标识,交易类型,交易状态,付款银行,货币种类,应结订单金额,代金券金额,退款申请时间,退款成功时间,微信退款单号,商户退款单号,退款金额,充值券退款金额,退款类型,退款状态,商品名称,商户数据包,手续费,费率,订单金额,申请退款金额,费率备注\r\n" +
+		"`2021-03-01 11:00:00,`wx2421b1c4370ec43b,`10000100,`0,`,`4200000001,`order_1,`oUpF8uMuAJO_M2pxb1Q9zNjWeS6o,`JSAPI,`REFUND,`OTHERS,`CNY,`0.00,`0.00,`2021-03-01 10:59:00,`2021-03-01 11:00:00,`50000001,`refund_1,`0.50,`0.00,`ORIGINAL,`SUCCESS,`商品,`,`-0.00300,`0.60%,`1.00,`0.50,`\r\n" +
+		"总交易单数,应结订单总金额,退款总金额,充值券退款总金额,手续费总金额,订单总金额,申请退款总金额\r\n" +
+		"`1,`0.00,`0.50,`0.00,`0.00,`1.00,`0.50\r\n"
+	var gz bytes.Buffer
+	w := gzip.NewWriter(&gz)
+	w.Write([]byte(data))
+	w.Close()
+
+	for _, v := range [][]byte{[]byte(data), gz.Bytes()} {
+		bill, err := ParseBill(v)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(bill.Rows) != 1 || bill.Rows[0].Refund == nil || bill.Rows[0].Refund.RefundFee != 50 || bill.Rows[0].Refund.RefundSuccessTime != "2021-03-01 11:00:00" {
+			t.Fatalf("unexpected rows: %+v", bill.Rows)
+		}
+		if bill.Summary == nil || bill.Summary.TotalCount != 1 || bill.Summary.RefundFee != 50 {
+			t.Fatalf("unexpected summary: %+v", bill.Summary)
+		}
+	}
+
+	_, err := ParseBill([]byte("<xml><return_code><![CDATA[FAIL]]></return_code><return_msg><![CDATA[No Bill Exist]]></return_msg><error_code><![CDATA[20002]]></error_code></xml>"))
+	if gopayErr, ok := gopay.AsError(err); !ok || gopayErr.Code != "20002" || gopayErr.Message != "No Bill Exist" {
+		t.Fatalf("expected *gopay.Error, got: %v", err)
+	}
+}
+
+func TestParseFundFlowBill(t *testing.T) {
+	data := "记账时间,微信支付业务单号,资金流水单号,业务名称,业务类型,收支类型,收支金额（元）,账户结余（元）,资金变更提交申请人,备注,业务凭证号\r\n" +
+		"`2021-03-01 10:00:00,`4200000001,`fund_1,`交易,`交易,`收入,`1.00,`1.00,`system,`,`order_1\r\n" +
+		"资金流水总笔数,收入笔数,收入金额,支出笔数,支出金额\r\n" +
+		"`1,`1,`1.00,`0,`0.00\r\n"
+	bill, err := ParseFundFlowBill([]byte(data))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(bill.Rows) != 1 || bill.Rows[0].Amount != 100 || bill.Rows[0].VoucherNo != "order_1" {
+		t.Fatalf("unexpected rows: %+v", bill.Rows)
+	}
+	if bill.Summary == nil || bill.Summary.IncomeCount != 1 || bill.Summary.IncomeAmount != 100 {
+		t.Fatalf("unexpected summary: %+v", bill.Summary)
+	}
+}
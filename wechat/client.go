@@ -5,6 +5,7 @@ import (
 	"encoding/xml"
 	"errors"
 	"fmt"
+	"net/http"
 	"strings"
 	"sync"
 
@@ -21,7 +22,9 @@ type Client struct {
 	BaseURL     string
 	IsProd      bool
 	DebugSwitch gopay.DebugSwitch
-	certificate *tls.Certificate
+	tlsConfig   *tls.Config
+	hc          *http.Client
+	certHc      *http.Client
 	mu          sync.RWMutex
 }
 
@@ -64,7 +67,7 @@ func (w *Client) AuthCodeToOpenId(bm gopay.BodyMap) (wxRsp *AuthCodeToOpenIdResp
 	if err = xml.Unmarshal(bs, wxRsp); err != nil {
 		return nil, fmt.Errorf("xml.Unmarshal(%s)：%w", string(bs), err)
 	}
-	return wxRsp, nil
+	return wxRsp, bizErrCheck(bs)
 }
 
 // 下载对账单
@@ -87,7 +90,7 @@ func (w *Client) DownloadBill(bm gopay.BodyMap) (wxRsp string, err error) {
 	if err != nil {
 		return util.NULL, err
 	}
-	return string(bs), nil
+	return string(bs), billErrCheck(bs)
 }
 
 // 下载资金账单（正式）
@@ -112,8 +115,7 @@ func (w *Client) DownloadFundFlow(bm gopay.BodyMap) (wxRsp string, err error) {
 	if err != nil {
 		return util.NULL, err
 	}
-	wxRsp = string(bs)
-	return
+	return string(bs), billErrCheck(bs)
 }
 
 // 交易保障
@@ -141,7 +143,7 @@ func (w *Client) Report(bm gopay.BodyMap) (wxRsp *ReportResponse, err error) {
 	if err = xml.Unmarshal(bs, wxRsp); err != nil {
 		return nil, fmt.Errorf("xml.Unmarshal(%s)：%w", string(bs), err)
 	}
-	return wxRsp, nil
+	return wxRsp, bizErrCheck(bs)
 }
 
 // 拉取订单评价数据（正式）
@@ -173,7 +175,7 @@ func (w *Client) doSanBoxPost(bm gopay.BodyMap, path string) (bs []byte, err err
 
 	if bm.GetString("sign") == util.NULL {
 		bm.Set("sign_type", SignType_MD5)
-		sign, err := getSignBoxSign(w.MchId, w.ApiKey, bm)
+		sign, err := getSignBoxSign(w.hc, w.MchId, w.ApiKey, bm)
 		if err != nil {
 			return nil, err
 		}
@@ -187,7 +189,7 @@ func (w *Client) doSanBoxPost(bm gopay.BodyMap, path string) (bs []byte, err err
 	if w.DebugSwitch == gopay.DebugOn {
 		xlog.Debugf("Wechat_Request: %s", req)
 	}
-	res, bs, errs := xhttp.NewClient().Type(xhttp.TypeXML).Post(url).SendString(req).EndBytes()
+	res, bs, errs := xhttp.NewClient().SetHttpClient(w.hc).Type(xhttp.TypeXML).Post(url).SendString(req).EndBytes()
 	if len(errs) > 0 {
 		return nil, errs[0]
 	}
@@ -195,10 +197,10 @@ func (w *Client) doSanBoxPost(bm gopay.BodyMap, path string) (bs []byte, err err
 		xlog.Debugf("Wechat_Response: %s%d %s%s", xlog.Red, res.StatusCode, xlog.Reset, string(bs))
 	}
 	if res.StatusCode != 200 {
-		return nil, fmt.Errorf("HTTP Request Error, StatusCode = %d", res.StatusCode)
+		return nil, httpError(res, bs)
 	}
 	if strings.Contains(string(bs), "HTML") || strings.Contains(string(bs), "html") {
-		return nil, errors.New(string(bs))
+		return nil, httpError(res, bs)
 	}
 	return bs, nil
 }
@@ -217,9 +219,12 @@ func (w *Client) doProdPost(bm gopay.BodyMap, path string, tlsConfig *tls.Config
 		bm.Set("sign", sign)
 	}
 
-	httpClient := xhttp.NewClient()
-	if w.IsProd && tlsConfig != nil {
-		httpClient.SetTLSConfig(tlsConfig)
+	if !w.IsProd {
+		tlsConfig = nil
+	}
+	httpClient, err := w.newHttpClient(tlsConfig)
+	if err != nil {
+		return nil, err
 	}
 	if w.BaseURL != util.NULL {
 		url = w.BaseURL + path
@@ -236,19 +241,22 @@ func (w *Client) doProdPost(bm gopay.BodyMap, path string, tlsConfig *tls.Config
 		xlog.Debugf("Wechat_Response: %s%d %s%s", xlog.Red, res.StatusCode, xlog.Reset, string(bs))
 	}
 	if res.StatusCode != 200 {
-		return nil, fmt.Errorf("HTTP Request Error, StatusCode = %d", res.StatusCode)
+		return nil, httpError(res, bs)
 	}
 	if strings.Contains(string(bs), "HTML") || strings.Contains(string(bs), "html") {
-		return nil, errors.New(string(bs))
+		return nil, httpError(res, bs)
 	}
 	return bs, nil
 }
 
 func (w *Client) doProdPostPure(bm gopay.BodyMap, path string, tlsConfig *tls.Config) (bs []byte, err error) {
 	var url = baseUrlCh + path
-	httpClient := xhttp.NewClient()
-	if w.IsProd && tlsConfig != nil {
-		httpClient.SetTLSConfig(tlsConfig)
+	if !w.IsProd {
+		tlsConfig = nil
+	}
+	httpClient, err := w.newHttpClient(tlsConfig)
+	if err != nil {
+		return nil, err
 	}
 	if w.BaseURL != util.NULL {
 		url = w.BaseURL + path
@@ -265,10 +273,10 @@ func (w *Client) doProdPostPure(bm gopay.BodyMap, path string, tlsConfig *tls.Co
 		xlog.Debugf("Wechat_Response: %s%d %s%s", xlog.Red, res.StatusCode, xlog.Reset, string(bs))
 	}
 	if res.StatusCode != 200 {
-		return nil, fmt.Errorf("HTTP Request Error, StatusCode = %d", res.StatusCode)
+		return nil, httpError(res, bs)
 	}
 	if strings.Contains(string(bs), "HTML") || strings.Contains(string(bs), "html") {
-		return nil, errors.New(string(bs))
+		return nil, httpError(res, bs)
 	}
 	return bs, nil
 }
@@ -294,7 +302,7 @@ func (w *Client) doProdGet(bm gopay.BodyMap, path, signType string) (bs []byte,
 	}
 	param := bm.EncodeURLParams()
 	url = url + "?" + param
-	res, bs, errs := xhttp.NewClient().Get(url).EndBytes()
+	res, bs, errs := xhttp.NewClient().SetHttpClient(w.hc).Get(url).EndBytes()
 	if len(errs) > 0 {
 		return nil, errs[0]
 	}
@@ -302,10 +310,53 @@ func (w *Client) doProdGet(bm gopay.BodyMap, path, signType string) (bs []byte,
 		xlog.Debugf("Wechat_Response: %s%d %s%s", xlog.Red, res.StatusCode, xlog.Reset, string(bs))
 	}
 	if res.StatusCode != 200 {
-		return nil, fmt.Errorf("HTTP Request Error, StatusCode = %d", res.StatusCode)
+		return nil, httpError(res, bs)
 	}
 	if strings.Contains(string(bs), "HTML") || strings.Contains(string(bs), "html") {
-		return nil, errors.New(string(bs))
+		return nil, httpError(res, bs)
 	}
 	return bs, nil
 }
+
+// httpError 微信 http 状态码错误
+func httpError(res *http.Response, bs []byte) error {
+	e := gopay.NewError(gopay.ProviderWechat, res.StatusCode, util.NULL, util.NULL)
+	e.Body = string(bs)
+	return e
+}
+
+// bizErrCheck 检查微信返回的 return_code、result_code
+//	return_code 为 FAIL 时，Code 为 error_code（为空时为 FAIL），Message 为 return_msg
+//	result_code 为 FAIL 时，Code 为 err_code，Message 为 err_code_des，err_code 为 SYSTEMERROR 等系统错误时，可重试
+func bizErrCheck(bs []byte) (err error) {
+	rsp := new(struct {
+		ReturnCode string `xml:"return_code"`
+		ReturnMsg  string `xml:"return_msg"`
+		ResultCode string `xml:"result_code"`
+		ErrCode    string `xml:"err_code"`
+		ErrCodeDes string `xml:"err_code_des"`
+		ErrorCode  string `xml:"error_code"`
+	})
+	if xml.Unmarshal(bs, rsp) != nil {
+		return nil
+	}
+	var e *gopay.Error
+	switch {
+	case rsp.ReturnCode == gopay.FAIL:
+		code := rsp.ErrorCode
+		if code == util.NULL {
+			code = rsp.ReturnCode
+		}
+		e = gopay.NewError(gopay.ProviderWechat, http.StatusOK, code, rsp.ReturnMsg)
+	case rsp.ResultCode == gopay.FAIL:
+		e = gopay.NewError(gopay.ProviderWechat, http.StatusOK, rsp.ErrCode, rsp.ErrCodeDes)
+		switch rsp.ErrCode {
+		case "SYSTEMERROR", "BANKERROR", "FREQ_LIMIT", "FREQUENCY_LIMITED":
+			e.Retryable = true
+		}
+	default:
+		return nil
+	}
+	e.Body = string(bs)
+	return e
+}
@@ -1,7 +1,10 @@
 package wechat
 
 import (
+	"net/http"
+	"net/http/httptest"
 	"os"
+	"strings"
 	"testing"
 
 	"github.com/yuanqinguo/gopay"
@@ -130,3 +133,32 @@ func TestClient_BatchQueryComment(t *testing.T) {
 	}
 	xlog.Debug("wxRsp：", wxRsp)
 }
+
+func TestBizErrCheck(t *testing.T) {
+	if err := bizErrCheck([]byte(`<xml><return_code><![CDATA[SUCCESS]]></return_code><result_code><![CDATA[SUCCESS]]></result_code></xml>`)); err != nil {
+		t.Fatal(err)
+	}
+	err := bizErrCheck([]byte(`<xml><return_code><![CDATA[SUCCESS]]></return_code><result_code><![CDATA[FAIL]]></result_code><err_code><![CDATA[SYSTEMERROR]]></err_code><err_code_des><![CDATA[系统超时]]></err_code_des></xml>`))
+	gopayErr, ok := gopay.AsError(err)
+	if !ok || gopayErr.Provider != gopay.ProviderWechat || gopayErr.Code != "SYSTEMERROR" || !gopayErr.Retryable {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	err = bizErrCheck([]byte(`<xml><return_code><![CDATA[FAIL]]></return_code><return_msg><![CDATA[签名错误]]></return_msg></xml>`))
+	if gopayErr, ok = gopay.AsError(err); !ok || gopayErr.Code != gopay.FAIL || gopayErr.Message != "签名错误" || gopayErr.Retryable {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestHtmlResponseError(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte("<html><body>502 Bad Gateway</body></html>"))
+	}))
+	defer srv.Close()
+	c := NewClient(appId, mchId, apiKey, true)
+	c.BaseURL = srv.URL
+	_, err := c.doProdPost(make(gopay.BodyMap), "/pay/orderquery", nil)
+	gopayErr, ok := gopay.AsError(err)
+	if !ok || gopayErr.Provider != gopay.ProviderWechat || gopayErr.StatusCode != http.StatusOK || !strings.Contains(gopayErr.Body, "502 Bad Gateway") {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
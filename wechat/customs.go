@@ -23,7 +23,7 @@ func (w *Client) CustomsDeclareOrder(bm gopay.BodyMap) (wxRsp *CustomsDeclareOrd
 	if err = xml.Unmarshal(bs, wxRsp); err != nil {
 		return nil, fmt.Errorf("xml.Unmarshal(%s)：%w", string(bs), err)
 	}
-	return wxRsp, nil
+	return wxRsp, bizErrCheck(bs)
 }
 
 // 订单附加信息查询（正式环境）
@@ -42,7 +42,7 @@ func (w *Client) CustomsDeclareQuery(bm gopay.BodyMap) (wxRsp *CustomsDeclareQue
 	if err = xml.Unmarshal(bs, wxRsp); err != nil {
 		return nil, fmt.Errorf("xml.Unmarshal(%s)：%w", string(bs), err)
 	}
-	return wxRsp, nil
+	return wxRsp, bizErrCheck(bs)
 }
 
 // 订单附加信息重推（正式环境）
@@ -61,5 +61,5 @@ func (w *Client) CustomsReDeclareOrder(bm gopay.BodyMap) (wxRsp *CustomsReDeclar
 	if err = xml.Unmarshal(bs, wxRsp); err != nil {
 		return nil, fmt.Errorf("xml.Unmarshal(%s)：%w", string(bs), err)
 	}
-	return wxRsp, nil
+	return wxRsp, bizErrCheck(bs)
 }
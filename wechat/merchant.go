@@ -31,7 +31,11 @@ func (w *Client) Transfer(bm gopay.BodyMap) (wxRsp *TransfersResponse, err error
 	}
 	bm.Set("sign", getReleaseSign(w.ApiKey, SignType_MD5, bm))
 
-	httpClient := xhttp.NewClient().SetTLSConfig(tlsConfig).Type(xhttp.TypeXML)
+	httpClient, err := w.newHttpClient(tlsConfig)
+	if err != nil {
+		return nil, err
+	}
+	httpClient.Type(xhttp.TypeXML)
 	if w.BaseURL != util.NULL {
 		w.mu.RLock()
 		url = w.BaseURL + transfers
@@ -49,13 +53,13 @@ func (w *Client) Transfer(bm gopay.BodyMap) (wxRsp *TransfersResponse, err error
 		xlog.Debugf("Wechat_Response: %s%d %s%s", xlog.Red, res.StatusCode, xlog.Reset, string(bs))
 	}
 	if res.StatusCode != 200 {
-		return nil, fmt.Errorf("HTTP Request Error, StatusCode = %d", res.StatusCode)
+		return nil, httpError(res, bs)
 	}
 	wxRsp = new(TransfersResponse)
 	if err = xml.Unmarshal(bs, wxRsp); err != nil {
 		return nil, fmt.Errorf("xml.Unmarshal(%s)：%w", string(bs), err)
 	}
-	return wxRsp, nil
+	return wxRsp, bizErrCheck(bs)
 }
 
 // 查询企业付款
@@ -77,7 +81,11 @@ func (w *Client) GetTransferInfo(bm gopay.BodyMap) (wxRsp *TransfersInfoResponse
 	}
 	bm.Set("sign", getReleaseSign(w.ApiKey, SignType_MD5, bm))
 
-	httpClient := xhttp.NewClient().SetTLSConfig(tlsConfig).Type(xhttp.TypeXML)
+	httpClient, err := w.newHttpClient(tlsConfig)
+	if err != nil {
+		return nil, err
+	}
+	httpClient.Type(xhttp.TypeXML)
 	if w.BaseURL != util.NULL {
 		w.mu.RLock()
 		url = w.BaseURL + getTransferInfo
@@ -95,13 +103,13 @@ func (w *Client) GetTransferInfo(bm gopay.BodyMap) (wxRsp *TransfersInfoResponse
 		xlog.Debugf("Wechat_Response: %s%d %s%s", xlog.Red, res.StatusCode, xlog.Reset, string(bs))
 	}
 	if res.StatusCode != 200 {
-		return nil, fmt.Errorf("HTTP Request Error, StatusCode = %d", res.StatusCode)
+		return nil, httpError(res, bs)
 	}
 	wxRsp = new(TransfersInfoResponse)
 	if err = xml.Unmarshal(bs, wxRsp); err != nil {
 		return nil, fmt.Errorf("xml.Unmarshal(%s)：%w", string(bs), err)
 	}
-	return wxRsp, nil
+	return wxRsp, bizErrCheck(bs)
 }
 
 // 企业付款到银行卡API（正式）
@@ -125,7 +133,11 @@ func (w *Client) PayBank(bm gopay.BodyMap) (wxRsp *PayBankResponse, err error) {
 	}
 	bm.Set("sign", getReleaseSign(w.ApiKey, SignType_MD5, bm))
 
-	httpClient := xhttp.NewClient().SetTLSConfig(tlsConfig).Type(xhttp.TypeXML)
+	httpClient, err := w.newHttpClient(tlsConfig)
+	if err != nil {
+		return nil, err
+	}
+	httpClient.Type(xhttp.TypeXML)
 	if w.BaseURL != util.NULL {
 		w.mu.RLock()
 		url = w.BaseURL + payBank
@@ -143,13 +155,13 @@ func (w *Client) PayBank(bm gopay.BodyMap) (wxRsp *PayBankResponse, err error) {
 		xlog.Debugf("Wechat_Response: %s%d %s%s", xlog.Red, res.StatusCode, xlog.Reset, string(bs))
 	}
 	if res.StatusCode != 200 {
-		return nil, fmt.Errorf("HTTP Request Error, StatusCode = %d", res.StatusCode)
+		return nil, httpError(res, bs)
 	}
 	wxRsp = new(PayBankResponse)
 	if err = xml.Unmarshal(bs, wxRsp); err != nil {
 		return nil, fmt.Errorf("xml.Unmarshal(%s)：%w", string(bs), err)
 	}
-	return wxRsp, nil
+	return wxRsp, bizErrCheck(bs)
 }
 
 // 查询企业付款到银行卡API（正式）
@@ -169,7 +181,11 @@ func (w *Client) QueryBank(bm gopay.BodyMap) (wxRsp *QueryBankResponse, err erro
 	}
 	bm.Set("sign", getReleaseSign(w.ApiKey, SignType_MD5, bm))
 
-	httpClient := xhttp.NewClient().SetTLSConfig(tlsConfig).Type(xhttp.TypeXML)
+	httpClient, err := w.newHttpClient(tlsConfig)
+	if err != nil {
+		return nil, err
+	}
+	httpClient.Type(xhttp.TypeXML)
 	if w.BaseURL != util.NULL {
 		w.mu.RLock()
 		url = w.BaseURL + queryBank
@@ -187,13 +203,13 @@ func (w *Client) QueryBank(bm gopay.BodyMap) (wxRsp *QueryBankResponse, err erro
 		xlog.Debugf("Wechat_Response: %s%d %s%s", xlog.Red, res.StatusCode, xlog.Reset, string(bs))
 	}
 	if res.StatusCode != 200 {
-		return nil, fmt.Errorf("HTTP Request Error, StatusCode = %d", res.StatusCode)
+		return nil, httpError(res, bs)
 	}
 	wxRsp = new(QueryBankResponse)
 	if err = xml.Unmarshal(bs, wxRsp); err != nil {
 		return nil, fmt.Errorf("xml.Unmarshal(%s)：%w", string(bs), err)
 	}
-	return wxRsp, nil
+	return wxRsp, bizErrCheck(bs)
 }
 
 // 获取RSA加密公钥API（正式）
@@ -213,7 +229,11 @@ func (w *Client) GetRSAPublicKey(bm gopay.BodyMap) (wxRsp *RSAPublicKeyResponse,
 	}
 	bm.Set("sign", getReleaseSign(w.ApiKey, bm.GetString("sign_type"), bm))
 
-	httpClient := xhttp.NewClient().SetTLSConfig(tlsConfig).Type(xhttp.TypeXML)
+	httpClient, err := w.newHttpClient(tlsConfig)
+	if err != nil {
+		return nil, err
+	}
+	httpClient.Type(xhttp.TypeXML)
 	req := GenerateXml(bm)
 	if w.DebugSwitch == gopay.DebugOn {
 		xlog.Debugf("Wechat_Request: %s", req)
@@ -226,13 +246,13 @@ func (w *Client) GetRSAPublicKey(bm gopay.BodyMap) (wxRsp *RSAPublicKeyResponse,
 		xlog.Debugf("Wechat_Response: %s%d %s%s", xlog.Red, res.StatusCode, xlog.Reset, string(bs))
 	}
 	if res.StatusCode != 200 {
-		return nil, fmt.Errorf("HTTP Request Error, StatusCode = %d", res.StatusCode)
+		return nil, httpError(res, bs)
 	}
 	wxRsp = new(RSAPublicKeyResponse)
 	if err = xml.Unmarshal(bs, wxRsp); err != nil {
 		return nil, fmt.Errorf("xml.Unmarshal(%s)：%w", string(bs), err)
 	}
-	return wxRsp, nil
+	return wxRsp, bizErrCheck(bs)
 }
 
 // 请求单次分账
@@ -276,7 +296,7 @@ func (w *Client) profitSharing(bm gopay.BodyMap, uri string) (wxRsp *ProfitShari
 	if err = xml.Unmarshal(bs, wxRsp); err != nil {
 		return nil, fmt.Errorf("xml.Unmarshal(%s)：%w", string(bs), err)
 	}
-	return wxRsp, nil
+	return wxRsp, bizErrCheck(bs)
 }
 
 // 查询分账结果
@@ -302,7 +322,7 @@ func (w *Client) ProfitSharingQuery(bm gopay.BodyMap) (wxRsp *ProfitSharingQuery
 	if err = xml.Unmarshal(bs, wxRsp); err != nil {
 		return nil, fmt.Errorf("xml.Unmarshal(%s)：%w", string(bs), err)
 	}
-	return wxRsp, nil
+	return wxRsp, bizErrCheck(bs)
 }
 
 // 添加分账接收方
@@ -323,7 +343,7 @@ func (w *Client) ProfitSharingAddReceiver(bm gopay.BodyMap) (wxRsp *ProfitSharin
 	if err = xml.Unmarshal(bs, wxRsp); err != nil {
 		return nil, fmt.Errorf("xml.Unmarshal(%s)：%w", string(bs), err)
 	}
-	return wxRsp, nil
+	return wxRsp, bizErrCheck(bs)
 }
 
 // 删除分账接收方
@@ -344,7 +364,7 @@ func (w *Client) ProfitSharingRemoveReceiver(bm gopay.BodyMap) (wxRsp *ProfitSha
 	if err = xml.Unmarshal(bs, wxRsp); err != nil {
 		return nil, fmt.Errorf("xml.Unmarshal(%s)：%w", string(bs), err)
 	}
-	return wxRsp, nil
+	return wxRsp, bizErrCheck(bs)
 }
 
 // 完结分账
@@ -372,7 +392,7 @@ func (w *Client) ProfitSharingFinish(bm gopay.BodyMap) (wxRsp *ProfitSharingResp
 	if err = xml.Unmarshal(bs, wxRsp); err != nil {
 		return nil, fmt.Errorf("xml.Unmarshal(%s)：%w", string(bs), err)
 	}
-	return wxRsp, nil
+	return wxRsp, bizErrCheck(bs)
 }
 
 // 分账回退
@@ -405,7 +425,7 @@ func (w *Client) ProfitSharingReturn(bm gopay.BodyMap) (wxRsp *ProfitSharingRetu
 	if err = xml.Unmarshal(bs, wxRsp); err != nil {
 		return nil, fmt.Errorf("xml.Unmarshal(%s)：%w", string(bs), err)
 	}
-	return wxRsp, nil
+	return wxRsp, bizErrCheck(bs)
 }
 
 // 回退结果查询
@@ -431,5 +451,5 @@ func (w *Client) ProfitSharingReturnQuery(bm gopay.BodyMap) (wxRsp *ProfitSharin
 	if err = xml.Unmarshal(bs, wxRsp); err != nil {
 		return nil, fmt.Errorf("xml.Unmarshal(%s)：%w", string(bs), err)
 	}
-	return wxRsp, nil
+	return wxRsp, bizErrCheck(bs)
 }
@@ -12,6 +12,7 @@ import (
 	"fmt"
 	"hash"
 	"io/ioutil"
+	"net/http"
 	"strings"
 
 	"github.com/yuanqinguo/gopay"
@@ -43,6 +44,47 @@ func (w *Client) SetCountry(country Country) (client *Client) {
 	return w
 }
 
+// 设置自定义的 http.Client，client 的所有请求（包括需要证书的请求）均通过此 http.Client 发出
+//	可用于代理、连接复用、链路追踪、单元测试替身等场景，不设置则每次请求使用默认配置
+//	注意：需要证书的请求，会基于 http.Client 的 *http.Transport 副本设置证书，并缓存复用；如 Transport 非 *http.Transport，需要证书的请求返回错误
+func (w *Client) SetHttpClient(httpClient *http.Client) (client *Client) {
+	w.mu.Lock()
+	w.hc = httpClient
+	w.resetCertHttpClient()
+	w.mu.Unlock()
+	return w
+}
+
+// newHttpClient 生成本次请求使用的 xhttp.Client，tlsConfig 不为 nil 时为需要证书的请求
+//	使用 client 证书时，证书 http.Client 只生成一次并缓存复用；自定义的 tlsConfig 只作用于本次请求
+func (w *Client) newHttpClient(tlsConfig *tls.Config) (httpClient *xhttp.Client, err error) {
+	httpClient = xhttp.NewClient()
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	switch {
+	case tlsConfig == nil:
+		httpClient.SetHttpClient(w.hc)
+	case tlsConfig == w.tlsConfig:
+		if w.certHc == nil {
+			if w.certHc, err = xhttp.NewTLSHttpClient(w.hc, tlsConfig); err != nil {
+				return nil, err
+			}
+		}
+		httpClient.SetHttpClient(w.certHc)
+	default:
+		httpClient.SetHttpClient(w.hc).SetTLSConfig(tlsConfig)
+	}
+	return httpClient, nil
+}
+
+// resetCertHttpClient 证书或 http.Client 变更时，释放缓存的证书 http.Client，调用方需持有写锁
+func (w *Client) resetCertHttpClient() {
+	if w.certHc != nil {
+		w.certHc.CloseIdleConnections()
+		w.certHc = nil
+	}
+}
+
 // Deprecated
 // 推荐使用 AddCertPemFileContent() 或 AddCertPemFilePath() 或 AddCertPkcs12FileContent() 或 AddCertPkcs12FilePath()
 // 添加微信证书路径或内容[]byte
@@ -88,7 +130,8 @@ func (w *Client) addCertFileContentOrPath(certFile, keyFile, pkcs12File interfac
 		return
 	}
 	w.mu.Lock()
-	w.certificate = &config.Certificates[0]
+	w.tlsConfig = config
+	w.resetCertHttpClient()
 	w.mu.Unlock()
 	return
 }
@@ -97,12 +140,8 @@ func (w *Client) addCertConfig(certFile, keyFile, pkcs12File interface{}) (tlsCo
 	if certFile == nil && keyFile == nil && pkcs12File == nil {
 		w.mu.RLock()
 		defer w.mu.RUnlock()
-		if w.certificate != nil {
-			tlsConfig = &tls.Config{
-				Certificates:       []tls.Certificate{*w.certificate},
-				InsecureSkipVerify: true,
-			}
-			return tlsConfig, nil
+		if w.tlsConfig != nil {
+			return w.tlsConfig, nil
 		}
 		return nil, errors.New("cert parse failed or nil")
 	}
@@ -209,12 +248,12 @@ func getReleaseSign(apiKey string, signType string, bm gopay.BodyMap) (sign stri
 }
 
 // 获取微信支付沙箱环境Sign值
-func getSignBoxSign(mchId, apiKey string, bm gopay.BodyMap) (sign string, err error) {
+func getSignBoxSign(hc *http.Client, mchId, apiKey string, bm gopay.BodyMap) (sign string, err error) {
 	var (
 		sandBoxApiKey string
 		h             hash.Hash
 	)
-	if sandBoxApiKey, err = getSanBoxKey(mchId, util.GetRandomString(32), apiKey, SignType_MD5); err != nil {
+	if sandBoxApiKey, err = getSanBoxKey(hc, mchId, util.GetRandomString(32), apiKey, SignType_MD5); err != nil {
 		return
 	}
 	h = md5.New()
@@ -224,26 +263,26 @@ func getSignBoxSign(mchId, apiKey string, bm gopay.BodyMap) (sign string, err er
 }
 
 // 从微信提供的接口获取：SandboxSignKey
-func getSanBoxKey(mchId, nonceStr, apiKey, signType string) (key string, err error) {
+func getSanBoxKey(hc *http.Client, mchId, nonceStr, apiKey, signType string) (key string, err error) {
 	bm := make(gopay.BodyMap)
 	bm.Set("mch_id", mchId)
 	bm.Set("nonce_str", nonceStr)
 	// 沙箱环境：获取沙箱环境ApiKey
-	if key, err = getSanBoxSignKey(mchId, nonceStr, getReleaseSign(apiKey, signType, bm)); err != nil {
+	if key, err = getSanBoxSignKey(hc, mchId, nonceStr, getReleaseSign(apiKey, signType, bm)); err != nil {
 		return
 	}
 	return
 }
 
 // 从微信提供的接口获取：SandboxSignKey
-func getSanBoxSignKey(mchId, nonceStr, sign string) (key string, err error) {
+func getSanBoxSignKey(hc *http.Client, mchId, nonceStr, sign string) (key string, err error) {
 	reqs := make(gopay.BodyMap)
 	reqs.Set("mch_id", mchId)
 	reqs.Set("nonce_str", nonceStr)
 	reqs.Set("sign", sign)
 
 	keyResponse := new(getSignKeyResponse)
-	_, errs := xhttp.NewClient().Type(xhttp.TypeXML).Post(sandboxGetSignKey).SendString(GenerateXml(reqs)).EndStruct(keyResponse)
+	_, errs := xhttp.NewClient().SetHttpClient(hc).Type(xhttp.TypeXML).Post(sandboxGetSignKey).SendString(GenerateXml(reqs)).EndStruct(keyResponse)
 	if len(errs) > 0 {
 		return util.NULL, errs[0]
 	}
@@ -43,6 +43,18 @@ func ParseNotifyToBodyMap(req *http.Request) (bm gopay.BodyMap, err error) {
 	return
 }
 
+// NotifyDedupeKey 获取异步通知去重使用的 key，配合 gopay.NotifyDeduper 使用
+//	bm：ParseNotifyToBodyMap() 解析的支付通知或退款通知参数
+//	支付通知返回 wechat:pay:{transaction_id}，退款通知返回 wechat:refund:{req_info 的摘要}
+//	唯一标识为空时返回空字符串，不做去重
+func NotifyDedupeKey(bm gopay.BodyMap) (key string) {
+	if reqInfo := bm.GetString("req_info"); reqInfo != util.NULL {
+		h := md5.Sum([]byte(reqInfo))
+		return gopay.NotifyDedupeKey(gopay.ProviderWechat, "refund", hex.EncodeToString(h[:]))
+	}
+	return gopay.NotifyDedupeKey(gopay.ProviderWechat, "pay", bm.GetString("transaction_id"))
+}
+
 // Deprecated
 // 推荐使用 ParseNotifyToBodyMap
 func ParseNotify(req *http.Request) (notifyReq *NotifyRequest, err error) {
@@ -46,7 +46,7 @@ func (w *Client) SendCashRed(bm gopay.BodyMap) (wxRsp *SendCashRedResponse, err
 	if err = xml.Unmarshal(bs, wxRsp); err != nil {
 		return nil, fmt.Errorf("xml.Unmarshal(%s)：%w", string(bs), err)
 	}
-	return wxRsp, nil
+	return wxRsp, bizErrCheck(bs)
 }
 
 // 发放现金裂变红包
@@ -83,7 +83,7 @@ func (w *Client) SendGroupCashRed(bm gopay.BodyMap) (wxRsp *SendCashRedResponse,
 	if err = xml.Unmarshal(bs, wxRsp); err != nil {
 		return nil, fmt.Errorf("xml.Unmarshal(%s)：%w", string(bs), err)
 	}
-	return wxRsp, nil
+	return wxRsp, bizErrCheck(bs)
 }
 
 // 发放小程序红包
@@ -120,7 +120,7 @@ func (w *Client) SendAppletRed(bm gopay.BodyMap) (wxRsp *SendAppletRedResponse,
 	if err = xml.Unmarshal(bs, wxRsp); err != nil {
 		return nil, fmt.Errorf("xml.Unmarshal(%s)：%w", string(bs), err)
 	}
-	return wxRsp, nil
+	return wxRsp, bizErrCheck(bs)
 }
 
 // 查询红包记录
@@ -157,5 +157,5 @@ func (w *Client) QueryRedRecord(bm gopay.BodyMap) (wxRsp *QueryRedRecordResponse
 	if err = xml.Unmarshal(bs, wxRsp); err != nil {
 		return nil, fmt.Errorf("xml.Unmarshal(%s)：%w", string(bs), err)
 	}
-	return wxRsp, nil
+	return wxRsp, bizErrCheck(bs)
 }
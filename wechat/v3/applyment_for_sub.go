@@ -36,7 +36,7 @@ func (c *ClientV3) V3Apply4SubSubmit(bm gopay.BodyMap) (*Apply4SubSubmitRsp, err
 	if res.StatusCode != http.StatusOK {
 		wxResp.Code = res.StatusCode
 		wxResp.Error = string(bs)
-		return wxResp, nil
+		return wxResp, newError(res, bs)
 	}
 	return wxResp, c.verifySyncSign(si)
 }
@@ -63,7 +63,7 @@ func (c *ClientV3) V3Apply4SubQueryByBusinessCode(businessCode string) (*Apply4S
 	if res.StatusCode != http.StatusOK {
 		wxRsp.Code = res.StatusCode
 		wxRsp.Error = string(bs)
-		return wxRsp, nil
+		return wxRsp, newError(res, bs)
 	}
 	return wxRsp, c.verifySyncSign(si)
 }
@@ -90,7 +90,7 @@ func (c *ClientV3) V3Apply4SubQueryByApplyId(applyId string) (*Apply4SubQueryRsp
 	if res.StatusCode != http.StatusOK {
 		wxRsp.Code = res.StatusCode
 		wxRsp.Error = string(bs)
-		return wxRsp, nil
+		return wxRsp, newError(res, bs)
 	}
 	return wxRsp, c.verifySyncSign(si)
 }
@@ -118,7 +118,7 @@ func (c *ClientV3) V3Apply4SubModifySettlement(bm gopay.BodyMap) (*EmptyRsp, err
 	if res.StatusCode != http.StatusNoContent {
 		wxResp.Code = res.StatusCode
 		wxResp.Error = string(bs)
-		return wxResp, nil
+		return wxResp, newError(res, bs)
 	}
 	return wxResp, c.verifySyncSign(si)
 }
@@ -145,7 +145,7 @@ func (c *ClientV3) V3Apply4SubQuerySettlement(subMchId string) (*Apply4SubQueryS
 	if res.StatusCode != http.StatusOK {
 		wxRsp.Code = res.StatusCode
 		wxRsp.Error = string(bs)
-		return wxRsp, nil
+		return wxRsp, newError(res, bs)
 	}
 	return wxRsp, c.verifySyncSign(si)
 }
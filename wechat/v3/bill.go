@@ -42,7 +42,7 @@ func (c *ClientV3) V3BillTradeBill(bm gopay.BodyMap) (wxRsp *BillRsp, err error)
 	if res.StatusCode != http.StatusOK {
 		wxRsp.Code = res.StatusCode
 		wxRsp.Error = string(bs)
-		return wxRsp, nil
+		return wxRsp, newError(res, bs)
 	}
 	return wxRsp, c.verifySyncSign(si)
 }
@@ -77,7 +77,7 @@ func (c *ClientV3) V3BillFundFlowBill(bm gopay.BodyMap) (wxRsp *BillRsp, err err
 	if res.StatusCode != http.StatusOK {
 		wxRsp.Code = res.StatusCode
 		wxRsp.Error = string(bs)
-		return wxRsp, nil
+		return wxRsp, newError(res, bs)
 	}
 	return wxRsp, c.verifySyncSign(si)
 }
@@ -117,7 +117,7 @@ func (c *ClientV3) V3BillEcommerceFundFlowBill(bm gopay.BodyMap) (wxRsp *Ecommer
 	if res.StatusCode != http.StatusOK {
 		wxRsp.Code = res.StatusCode
 		wxRsp.Error = string(bs)
-		return wxRsp, nil
+		return wxRsp, newError(res, bs)
 	}
 	return wxRsp, c.verifySyncSign(si)
 }
@@ -151,7 +151,7 @@ func (c *ClientV3) V3BillSubFundFlowBill(bm gopay.BodyMap) (wxRsp *BillRsp, err
 	if res.StatusCode != http.StatusOK {
 		wxRsp.Code = res.StatusCode
 		wxRsp.Error = string(bs)
-		return wxRsp, nil
+		return wxRsp, newError(res, bs)
 	}
 	return wxRsp, c.verifySyncSign(si)
 }
@@ -177,7 +177,7 @@ func (c *ClientV3) V3BillDownLoadBill(downloadUrl string) (fileBytes []byte, err
 		return nil, err
 	}
 	if res.StatusCode != http.StatusOK {
-		return nil, errors.New(string(bs))
+		return nil, newError(res, bs)
 	}
 	return bs, nil
 }
@@ -0,0 +1,285 @@
+package wechat
+
+import (
+	"bufio"
+	"compress/gzip"
+	"context"
+	"crypto/sha1"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"hash"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/yuanqinguo/gopay"
+	"github.com/yuanqinguo/gopay/pkg/util"
+	"github.com/yuanqinguo/gopay/pkg/xbill"
+	"github.com/yuanqinguo/gopay/pkg/xlog"
+	wechatv2 "github.com/yuanqinguo/gopay/wechat"
+)
+
+const (
+	BillHashTypeSHA1 = "SHA1"
+	BillTarTypeGZIP  = "GZIP"
+)
+
+// 账单校验失败，账单文件不完整或被篡改，已读取的数据不可用
+var ErrBillHashMismatch = errors.New("bill hash mismatch")
+
+// 交易账单、资金账单的明细及汇总，与 V2 下载对账单、下载资金账单的格式相同，金额单位为分
+type (
+	TradeBillRow        = wechatv2.BillRow
+	TradeBillRefund     = wechatv2.BillRefund
+	TradeBillSummary    = wechatv2.BillSummary
+	FundFlowBillRow     = wechatv2.FundFlowBillRow
+	FundFlowBillSummary = wechatv2.FundFlowBillSummary
+)
+
+// billStreamTimeout 未设置 http.Client 时，流式下载账单的超时时间，包括读取 body 的时间
+const billStreamTimeout = 10 * time.Minute
+
+// billStreamClient 未设置 http.Client 时，流式下载账单使用的 http.Client
+var billStreamClient = &http.Client{Timeout: billStreamTimeout}
+
+// 下载账单API（流式）
+//	与 V3BillDownLoadBill() 相同，但不读取账单内容到内存，返回的 body 需调用方 Close
+//	可配合 NewTradeBillIterator()、NewFundFlowBillIterator() 逐行解析大账单文件
+//	ctx：控制请求及读取 body 的超时、取消；未通过 client.SetHttpClient() 设置 http.Client 时，最长 10 分钟
+//	商户文档：https://pay.weixin.qq.com/wiki/doc/apiv3/apis/chapter3_1_8.shtml
+func (c *ClientV3) V3BillDownLoadBillStream(ctx context.Context, downloadUrl string) (body io.ReadCloser, err error) {
+	if downloadUrl == gopay.NULL {
+		return nil, errors.New("invalid download url")
+	}
+	split := strings.Split(downloadUrl, ".com")
+	if len(split) != 2 {
+		return nil, errors.New("invalid download url")
+	}
+	authorization, err := c.authorization(MethodGet, split[1], nil)
+	if err != nil {
+		return nil, err
+	}
+	var url = v3BaseUrlCh + split[1]
+	if c.DebugSwitch == gopay.DebugOn {
+		xlog.Debugf("Wechat_V3_Url: %s", url)
+		xlog.Debugf("Wechat_V3_Authorization: %s", authorization)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Add(HeaderAuthorization, authorization)
+	req.Header.Add(HeaderSerial, c.getWxSerialNo())
+	req.Header.Add("Accept", "*/*")
+	hc := c.hc
+	if hc == nil {
+		hc = billStreamClient
+	}
+	res, err := hc.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	if res.StatusCode != http.StatusOK {
+		bs, _ := ioutil.ReadAll(res.Body)
+		res.Body.Close()
+		return nil, newError(res, bs)
+	}
+	return res.Body, nil
+}
+
+// TradeBillIterator 交易账单逐行解析
+//	示例：
+//	for it.Next() {
+//		row := it.Row()
+//	}
+//	if err := it.Err(); err != nil {}
+//	summary := it.Summary()
+type TradeBillIterator struct {
+	br      *billReader
+	row     *TradeBillRow
+	summary *TradeBillSummary
+}
+
+// NewTradeBillIterator 初始化交易账单逐行解析
+//	r：下载的账单内容，如 V3BillDownLoadBillStream() 返回的 body
+//	bill：申请交易账单API返回的 wxRsp.Response，不为 nil 时读取完成后校验 hash_value，传 nil 不校验
+//	tarType：申请账单时的 tar_type，GZIP 时自动解压
+func NewTradeBillIterator(r io.Reader, bill *TradeBill, tarType string) (it *TradeBillIterator, err error) {
+	br, err := newBillReader(r, bill, tarType)
+	if err != nil {
+		return nil, err
+	}
+	return &TradeBillIterator{br: br}, nil
+}
+
+// Next 读取下一行明细，返回 false 时读取结束或出错，需检查 Err()
+func (it *TradeBillIterator) Next() bool {
+	for {
+		fields, ok := it.br.next("总交易单数")
+		if !ok {
+			return false
+		}
+		if it.br.isSummary {
+			if it.summary, it.br.err = wechatv2.ParseBillSummary(it.br.summaryHeader, fields); it.br.err != nil {
+				return false
+			}
+			continue
+		}
+		if it.row, it.br.err = wechatv2.ParseBillRow(it.br.header, fields); it.br.err != nil {
+			it.br.err = fmt.Errorf("line %d: %w", it.br.line, it.br.err)
+			return false
+		}
+		return true
+	}
+}
+
+// Row 当前明细行
+func (it *TradeBillIterator) Row() *TradeBillRow {
+	return it.row
+}
+
+// Summary 账单汇总，读取完成后可用
+func (it *TradeBillIterator) Summary() *TradeBillSummary {
+	return it.summary
+}
+
+// Err 读取、解析或校验 hash 的错误，hash 校验失败时返回 ErrBillHashMismatch
+func (it *TradeBillIterator) Err() error {
+	return it.br.err
+}
+
+// FundFlowBillIterator 资金账单逐行解析，用法同 TradeBillIterator
+type FundFlowBillIterator struct {
+	br      *billReader
+	row     *FundFlowBillRow
+	summary *FundFlowBillSummary
+}
+
+// NewFundFlowBillIterator 初始化资金账单逐行解析
+//	r：下载的账单内容，如 V3BillDownLoadBillStream() 返回的 body
+//	bill：申请资金账单API返回的 wxRsp.Response，不为 nil 时读取完成后校验 hash_value，传 nil 不校验
+//	tarType：申请账单时的 tar_type，GZIP 时自动解压
+func NewFundFlowBillIterator(r io.Reader, bill *TradeBill, tarType string) (it *FundFlowBillIterator, err error) {
+	br, err := newBillReader(r, bill, tarType)
+	if err != nil {
+		return nil, err
+	}
+	return &FundFlowBillIterator{br: br}, nil
+}
+
+// Next 读取下一行明细，返回 false 时读取结束或出错，需检查 Err()
+func (it *FundFlowBillIterator) Next() bool {
+	for {
+		fields, ok := it.br.next("资金流水总笔数")
+		if !ok {
+			return false
+		}
+		if it.br.isSummary {
+			if it.summary, it.br.err = wechatv2.ParseFundFlowBillSummary(it.br.summaryHeader, fields); it.br.err != nil {
+				return false
+			}
+			continue
+		}
+		if it.row, it.br.err = wechatv2.ParseFundFlowBillRow(it.br.header, fields); it.br.err != nil {
+			it.br.err = fmt.Errorf("line %d: %w", it.br.line, it.br.err)
+			return false
+		}
+		return true
+	}
+}
+
+// Row 当前明细行
+func (it *FundFlowBillIterator) Row() *FundFlowBillRow {
+	return it.row
+}
+
+// Summary 账单汇总，读取完成后可用
+func (it *FundFlowBillIterator) Summary() *FundFlowBillSummary {
+	return it.summary
+}
+
+// Err 读取、解析或校验 hash 的错误，hash 校验失败时返回 ErrBillHashMismatch
+func (it *FundFlowBillIterator) Err() error {
+	return it.br.err
+}
+
+// billReader 账单按行读取，明细字段以 ` 开头，读取完成后校验 hash_value（对解压后的账单内容计算）
+type billReader struct {
+	r             *bufio.Reader
+	gz            *gzip.Reader
+	hash          hash.Hash
+	hashValue     string
+	header        xbill.Header
+	summaryHeader xbill.Header
+	isSummary     bool
+	line          int
+	done          bool
+	err           error
+}
+
+func newBillReader(r io.Reader, bill *TradeBill, tarType string) (br *billReader, err error) {
+	br = new(billReader)
+	if strings.ToUpper(tarType) == BillTarTypeGZIP {
+		if br.gz, err = gzip.NewReader(r); err != nil {
+			return nil, fmt.Errorf("gzip.NewReader：%w", err)
+		}
+		r = br.gz
+	}
+	if bill != nil && bill.HashValue != util.NULL {
+		if bill.HashType != util.NULL && strings.ToUpper(bill.HashType) != BillHashTypeSHA1 {
+			return nil, fmt.Errorf("unsupported hash_type: %s", bill.HashType)
+		}
+		br.hash = sha1.New()
+		br.hashValue = bill.HashValue
+		r = io.TeeReader(r, br.hash)
+	}
+	br.r = bufio.NewReader(r)
+	return br, nil
+}
+
+// next 读取下一行，跳过空行，第一行为明细表头；首个字段为 summaryName 的行为汇总表头，其下一行 isSummary 为 true
+func (br *billReader) next(summaryName string) (fields []string, ok bool) {
+	if br.done || br.err != nil {
+		return nil, false
+	}
+	for {
+		line, err := br.r.ReadString('\n')
+		if err != nil && err != io.EOF {
+			br.err = err
+			return nil, false
+		}
+		if line = strings.TrimRight(line, "\r\n"); line != util.NULL {
+			br.line++
+			fields = xbill.SplitLine(line)
+			switch {
+			case br.header == nil:
+				br.header = xbill.NewHeader(fields)
+			case fields[0] == summaryName:
+				br.summaryHeader = xbill.NewHeader(fields)
+			default:
+				br.isSummary = br.summaryHeader != nil
+				return fields, true
+			}
+		}
+		if err == io.EOF {
+			br.done = true
+			br.err = br.finish()
+			return nil, false
+		}
+	}
+}
+
+func (br *billReader) finish() (err error) {
+	if br.gz != nil {
+		if err = br.gz.Close(); err != nil {
+			return err
+		}
+	}
+	if br.hash != nil && !strings.EqualFold(hex.EncodeToString(br.hash.Sum(nil)), br.hashValue) {
+		return ErrBillHashMismatch
+	}
+	return nil
+}
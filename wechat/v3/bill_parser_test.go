@@ -0,0 +1,141 @@
+package wechat
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"crypto/sha1"
+	"encoding/hex"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+const testTradeBill = "交易时间,公众账号ID,商户号,特约商户号,设备号,微信订单号,商户订单号,用户标识,交易类型,交易状态,付款银行,货币种类,应结订单金额,代金券金额,微信退款单号,商户退款单号,退款金额,充值券退款金额,退款类型,退款状态,商品名称,商户数据包,手续费,费率,订单金额,申请退款金额,费率备注\r\n" +
+	"`2021-03-01 10:00:00,`wx2421b1c4370ec43b,`10000100,`0,`,`4200000001,`order_1,`oUpF8uMuAJO_M2pxb1Q9zNjWeS6o,`JSAPI,`SUCCESS,`OTHERS,`CNY,`1.00,`0.00,`0,`0,`0.00,`0.00,`,`,`商品,a,b,`,`0.01000,`0.60%,`1.00,`0.00,`\r\n" +
+	"`2021-03-01 11:00:00,`wx2421b1c4370ec43b,`10000100,`0,`,`4200000001,`order_1,`oUpF8uMuAJO_M2pxb1Q9zNjWeS6o,`JSAPI,`REFUND,`OTHERS,`CNY,`0.00,`0.00,`50000001,`refund_1,`0.50,`0.00,`ORIGINAL,`SUCCESS,`商品,`,`-0.00300,`0.60%,`0.00,`0.50,`\r\n" +
+	"总交易单数,应结订单总金额,退款总金额,充值券退款总金额,手续费总金额,订单总金额,申请退款总金额\r\n" +
+	"`2,`1.00,`0.50,`0.00,`0.01,`1.00,`0.50\r\n"
+
+const testFundFlowBill = "记账时间,微信支付业务单号,资金流水单号,业务名称,业务类型,收支类型,收支金额（元）,账户结余（元）,资金变更提交申请人,备注,业务凭证号\r\n" +
+	"`2021-03-01 10:00:00,`4200000001,`fund_1,`交易,`交易,`收入,`1.00,`1.00,`system,`,`order_1\r\n" +
+	"资金流水总笔数,收入笔数,收入金额,支出笔数,支出金额\r\n" +
+	"`1,`1,`1.00,`0,`0.00\r\n"
+
+func TestTradeBillIterator(t *testing.T) {
+	h := sha1.Sum([]byte(testTradeBill))
+	bill := &TradeBill{HashType: BillHashTypeSHA1, HashValue: hex.EncodeToString(h[:])}
+	var gz bytes.Buffer
+	w := gzip.NewWriter(&gz)
+	w.Write([]byte(testTradeBill))
+	w.Close()
+
+	it, err := NewTradeBillIterator(&gz, bill, BillTarTypeGZIP)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var rows []*TradeBillRow
+	for it.Next() {
+		rows = append(rows, it.Row())
+	}
+	if err = it.Err(); err != nil {
+		t.Fatal(err)
+	}
+	if len(rows) != 2 {
+		t.Fatalf("expected 2 rows, got: %d", len(rows))
+	}
+	if rows[0].OutTradeNo != "order_1" || rows[0].TotalFee != 100 || rows[0].Fee != 1 || rows[0].Body != "商品,a,b" || rows[0].Refund != nil {
+		t.Fatalf("unexpected trade row: %+v", rows[0])
+	}
+	if rows[1].Refund == nil || rows[1].Refund.OutRefundNo != "refund_1" || rows[1].Refund.RefundFee != 50 {
+		t.Fatalf("unexpected refund row: %+v", rows[1])
+	}
+	if s := it.Summary(); s == nil || s.TotalCount != 2 || s.RefundFee != 50 || s.TotalFee != 100 {
+		t.Fatalf("unexpected summary: %+v", s)
+	}
+
+	bill.HashValue = strings.Repeat("0", 40)
+	if it, err = NewTradeBillIterator(strings.NewReader(testTradeBill), bill, ""); err != nil {
+		t.Fatal(err)
+	}
+	for it.Next() {
+	}
+	if it.Err() != ErrBillHashMismatch {
+		t.Fatalf("expected ErrBillHashMismatch, got: %v", it.Err())
+	}
+}
+
+func TestFundFlowBillIterator(t *testing.T) {
+	it, err := NewFundFlowBillIterator(strings.NewReader(testFundFlowBill), nil, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var rows []*FundFlowBillRow
+	for it.Next() {
+		rows = append(rows, it.Row())
+	}
+	if err = it.Err(); err != nil {
+		t.Fatal(err)
+	}
+	if len(rows) != 1 || rows[0].FundFlowId != "fund_1" || rows[0].Amount != 100 || rows[0].Balance != 100 || rows[0].IncomeType != "收入" {
+		t.Fatalf("unexpected rows: %+v", rows)
+	}
+	if s := it.Summary(); s == nil || s.TotalCount != 1 || s.IncomeAmount != 100 {
+		t.Fatalf("unexpected summary: %+v", s)
+	}
+
+	// 汇总笔数为空时按 0 处理，与 V2 解析一致
+	bill := strings.Replace(testFundFlowBill, "`1,`1,`1.00,`0,`0.00", "`1,`1,`1.00,`,`0.00", 1)
+	if it, err = NewFundFlowBillIterator(strings.NewReader(bill), nil, ""); err != nil {
+		t.Fatal(err)
+	}
+	for it.Next() {
+	}
+	if err = it.Err(); err != nil {
+		t.Fatal(err)
+	}
+	if s := it.Summary(); s == nil || s.ExpenseCount != 0 || s.IncomeCount != 1 {
+		t.Fatalf("unexpected summary: %+v", s)
+	}
+}
+
+type roundTripFunc func(req *http.Request) (*http.Response, error)
+
+func (f roundTripFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+func TestV3BillDownLoadBillStream(t *testing.T) {
+	c, err := NewClientV3(MchId, SerialNo, "0123456789abcdef0123456789abcdef", PrivateKeyContent)
+	if err != nil {
+		t.Fatal(err)
+	}
+	c.SetHttpClient(&http.Client{Transport: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		if err := req.Context().Err(); err != nil {
+			return nil, err
+		}
+		if req.URL.RequestURI() != "/v3/billdownload/file?token=xxx" || !strings.HasPrefix(req.Header.Get(HeaderAuthorization), Authorization) {
+			t.Errorf("unexpected request: %s, %v", req.URL, req.Header)
+		}
+		return &http.Response{StatusCode: http.StatusOK, Header: make(http.Header), Body: ioutil.NopCloser(strings.NewReader(testTradeBill))}, nil
+	})})
+
+	body, err := c.V3BillDownLoadBillStream(context.Background(), "https://api.mch.weixin.qq.com/v3/billdownload/file?token=xxx")
+	if err != nil {
+		t.Fatal(err)
+	}
+	bs, _ := ioutil.ReadAll(body)
+	body.Close()
+	if string(bs) != testTradeBill {
+		t.Fatalf("unexpected body: %s", bs)
+	}
+
+	// ctx 取消后请求失败
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err = c.V3BillDownLoadBillStream(ctx, "https://api.mch.weixin.qq.com/v3/billdownload/file?token=xxx"); !errors.Is(err, context.Canceled) {
+		t.Fatalf("expected context canceled, got: %v", err)
+	}
+}
@@ -25,7 +25,7 @@ func (c *ClientV3) V3BusinessPointsSync(bm gopay.BodyMap) (wxRsp *EmptyRsp, err
 	if res.StatusCode != http.StatusNoContent {
 		wxRsp.Code = res.StatusCode
 		wxRsp.Error = string(bs)
-		return wxRsp, nil
+		return wxRsp, newError(res, bs)
 	}
 	return wxRsp, c.verifySyncSign(si)
 }
@@ -52,7 +52,7 @@ func (c *ClientV3) V3BusinessAuthPointsQuery(appid, openid string) (*BusinessAut
 	if res.StatusCode != http.StatusOK {
 		wxRsp.Code = res.StatusCode
 		wxRsp.Error = string(bs)
-		return wxRsp, nil
+		return wxRsp, newError(res, bs)
 	}
 	return wxRsp, c.verifySyncSign(si)
 }
@@ -13,7 +13,6 @@ import (
 	"sync"
 	"time"
 
-	"github.com/yuanqinguo/gopay/pkg/aes"
 	"github.com/yuanqinguo/gopay/pkg/errgroup"
 	"github.com/yuanqinguo/gopay/pkg/util"
 	"github.com/yuanqinguo/gopay/pkg/xhttp"
@@ -67,7 +66,7 @@ func GetPlatformCerts(mchid, apiV3Key, serialNo, privateKey string) (certs *Plat
 	if res.StatusCode != http.StatusOK {
 		certs.Code = res.StatusCode
 		certs.Error = string(bs)
-		return certs, nil
+		return certs, newError(res, bs)
 	}
 	// Parse
 	certRsp := new(PlatformCert)
@@ -79,10 +78,14 @@ func GetPlatformCerts(mchid, apiV3Key, serialNo, privateKey string) (certs *Plat
 		if cert.EncryptCertificate != nil {
 			ec := cert.EncryptCertificate
 			eg.Go(func(ctx context.Context) error {
-				cipherBytes, _ := base64.StdEncoding.DecodeString(ec.Ciphertext)
-				pubKeyBytes, err := aes.GCMDecrypt(cipherBytes, []byte(ec.Nonce), []byte(ec.AssociatedData), []byte(apiV3Key))
+				pubKeyBytes, err := decryptResource(&Resource{
+					Algorithm:      ec.Algorithm,
+					Ciphertext:     ec.Ciphertext,
+					AssociatedData: ec.AssociatedData,
+					Nonce:          ec.Nonce,
+				}, []byte(apiV3Key))
 				if err != nil {
-					return fmt.Errorf("aes.GCMDecrypt, err:%+v", err)
+					return err
 				}
 				pci := &PlatformCertItem{
 					EffectiveTime: cert.EffectiveTime,
@@ -103,7 +106,7 @@ func GetPlatformCerts(mchid, apiV3Key, serialNo, privateKey string) (certs *Plat
 	return certs, nil
 }
 
-// 获取微信平台证书公钥（获取后自行保存使用，如需定期刷新功能，自行实现）
+// 获取微信平台证书公钥（获取后自行保存使用，如需定期刷新功能，可使用 client.AutoRefreshPlatformCerts()）
 //	注意事项
 //	如果自行实现验证平台签名逻辑的话，需要注意以下事项:
 //	  - 程序实现定期更新平台证书的逻辑，不要硬编码验证应答消息签名的平台证书
@@ -129,7 +132,7 @@ func (c *ClientV3) GetPlatformCerts() (certs *PlatformCertRsp, err error) {
 	if res.StatusCode != http.StatusOK {
 		certs.Code = res.StatusCode
 		certs.Error = string(bs)
-		return certs, nil
+		return certs, newError(res, bs)
 	}
 	certRsp := new(PlatformCert)
 	if err = json.Unmarshal(bs, certRsp); err != nil {
@@ -171,19 +174,20 @@ func (c *ClientV3) SetPlatformCert(wxPublicKeyContent []byte, wxSerialNo string)
 	if err != nil {
 		xlog.Errorf("SetPlatformCert(%s),err:%+v", wxPublicKeyContent, err)
 	}
+	c.rwMu.Lock()
 	if pubKey != nil {
 		c.wxPublicKey = pubKey
 	}
 	c.wxSerialNo = wxSerialNo
+	c.rwMu.Unlock()
 	return c
 }
 
 // 解密加密的证书
 func (c *ClientV3) DecryptCerts(ciphertext, nonce, additional string) (wxCerts string, err error) {
-	cipherBytes, _ := base64.StdEncoding.DecodeString(ciphertext)
-	decrypt, err := aes.GCMDecrypt(cipherBytes, []byte(nonce), []byte(additional), c.apiV3Key)
+	decrypt, err := decryptResource(aesResource(ciphertext, nonce, additional), c.apiV3Key)
 	if err != nil {
-		return "", fmt.Errorf("aes.GCMDecrypt, err:%+v", err)
+		return "", err
 	}
 	return string(decrypt), nil
 }
@@ -0,0 +1,280 @@
+package wechat
+
+import (
+	"crypto/rsa"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/yuanqinguo/gopay/pkg/xlog"
+	"github.com/yuanqinguo/gopay/pkg/xpem"
+)
+
+// 平台证书默认刷新间隔，微信要求间隔时间小于12小时
+const DefaultPlatformCertsRefreshInterval = 6 * time.Hour
+
+type platformCert struct {
+	item          *PlatformCertItem
+	publicKey     *rsa.PublicKey
+	effectiveTime time.Time
+	expireTime    time.Time
+}
+
+// SetPlatformCerts 设置多个微信支付平台证书
+//	certs：通过 client.GetPlatformCerts() 获取的证书列表
+//	证书按序列号保存，已过期的证书会被清除；使用已启用的证书中启用时间最晚的证书加密敏感信息，均未启用时使用启用时间最晚的证书
+//	同步验签、异步通知验签时，使用 Wechatpay-Serial 对应的平台证书
+func (c *ClientV3) SetPlatformCerts(certs []*PlatformCertItem) (err error) {
+	pcs := make(map[string]*platformCert, len(certs))
+	for _, v := range certs {
+		if v == nil {
+			continue
+		}
+		pc, err := parsePlatformCert(v)
+		if err != nil {
+			return err
+		}
+		pcs[v.SerialNo] = pc
+	}
+	c.rwMu.Lock()
+	defer c.rwMu.Unlock()
+	if c.platformCerts == nil {
+		c.platformCerts = make(map[string]*platformCert, len(pcs))
+	}
+	for k, v := range pcs {
+		c.platformCerts[k] = v
+	}
+	var (
+		now            = time.Now()
+		newest, active *platformCert
+	)
+	for k, v := range c.platformCerts {
+		if !v.expireTime.IsZero() && v.expireTime.Before(now) {
+			delete(c.platformCerts, k)
+			continue
+		}
+		if newest == nil || v.effectiveTime.After(newest.effectiveTime) {
+			newest = v
+		}
+		// 轮换时新证书可能尚未启用，优先使用已启用的证书
+		if !v.effectiveTime.After(now) && (active == nil || v.effectiveTime.After(active.effectiveTime)) {
+			active = v
+		}
+	}
+	if active != nil {
+		newest = active
+	}
+	if newest == nil {
+		return errors.New("no valid platform cert")
+	}
+	c.wxPublicKey = newest.publicKey
+	c.wxSerialNo = newest.item.SerialNo
+	return nil
+}
+
+// SetCertStore 设置平台证书存储
+//	设置后，client.RefreshPlatformCerts() 获取的平台证书会写入存储，client.LoadPlatformCerts() 从存储中读取平台证书
+func (c *ClientV3) SetCertStore(store CertStore) (client *ClientV3) {
+	c.rwMu.Lock()
+	c.certStore = store
+	c.rwMu.Unlock()
+	return c
+}
+
+// RefreshPlatformCerts 请求微信获取最新的平台证书，并更新到 client 中
+//	已设置 CertStore 时，同时写入存储
+func (c *ClientV3) RefreshPlatformCerts() (err error) {
+	certs, err := c.GetPlatformCerts()
+	if err != nil {
+		return err
+	}
+	if err = c.SetPlatformCerts(certs.Certs); err != nil {
+		return err
+	}
+	c.rwMu.RLock()
+	store := c.certStore
+	c.rwMu.RUnlock()
+	if store != nil {
+		for _, v := range certs.Certs {
+			if err = store.Put(c.Mchid, v); err != nil {
+				return fmt.Errorf("CertStore.Put(%s),err:%w", v.SerialNo, err)
+			}
+		}
+	}
+	return nil
+}
+
+// LoadPlatformCerts 从 CertStore 中读取平台证书，并更新到 client 中
+func (c *ClientV3) LoadPlatformCerts() (err error) {
+	c.rwMu.RLock()
+	store := c.certStore
+	c.rwMu.RUnlock()
+	if store == nil {
+		return errors.New("CertStore is nil, please call client.SetCertStore() first")
+	}
+	certs, err := store.List(c.Mchid)
+	if err != nil {
+		return fmt.Errorf("CertStore.List(%s),err:%w", c.Mchid, err)
+	}
+	return c.SetPlatformCerts(certs)
+}
+
+// AutoRefreshPlatformCerts 开启平台证书自动管理，并开启请求完自动验签功能
+//	调用时会同步获取一次平台证书，获取失败直接返回 err，之后每隔 interval 在后台刷新
+//	interval：刷新间隔，小于等于0或不小于12小时，使用 DefaultPlatformCertsRefreshInterval
+//	后台刷新失败时，继续使用已有证书，并输出错误日志；可通过 client.StopAutoRefreshPlatformCerts() 停止
+func (c *ClientV3) AutoRefreshPlatformCerts(interval time.Duration) (err error) {
+	if interval <= 0 || interval >= 12*time.Hour {
+		interval = DefaultPlatformCertsRefreshInterval
+	}
+	if err = c.RefreshPlatformCerts(); err != nil {
+		return err
+	}
+	c.startPlatformCertsTask(interval, c.RefreshPlatformCerts)
+	return nil
+}
+
+// AutoLoadPlatformCerts 从 CertStore 中定时读取平台证书，并开启请求完自动验签功能
+//	适用于多实例部署：由一个实例调用 client.AutoRefreshPlatformCerts() 刷新并写入存储，其他实例调用此方法读取
+//	调用时会同步读取一次平台证书，存储中没有可用证书时，请求微信获取一次
+//	interval：读取间隔，小于等于0或不小于12小时，使用 DefaultPlatformCertsRefreshInterval
+func (c *ClientV3) AutoLoadPlatformCerts(interval time.Duration) (err error) {
+	if interval <= 0 || interval >= 12*time.Hour {
+		interval = DefaultPlatformCertsRefreshInterval
+	}
+	if err = c.LoadPlatformCerts(); err != nil {
+		if err = c.RefreshPlatformCerts(); err != nil {
+			return err
+		}
+	}
+	c.startPlatformCertsTask(interval, c.LoadPlatformCerts)
+	return nil
+}
+
+func (c *ClientV3) startPlatformCertsTask(interval time.Duration, task func() error) {
+	stop := make(chan struct{})
+	c.rwMu.Lock()
+	if c.refreshStop != nil {
+		close(c.refreshStop)
+	}
+	c.refreshStop = stop
+	c.autoSign = true
+	c.rwMu.Unlock()
+
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-stop:
+				return
+			case <-ticker.C:
+				if err := task(); err != nil {
+					xlog.Errorf("platform certs task,err:%+v", err)
+				}
+			}
+		}
+	}()
+}
+
+// StopAutoRefreshPlatformCerts 停止后台刷新（或读取）平台证书，已加载的证书继续使用
+func (c *ClientV3) StopAutoRefreshPlatformCerts() {
+	c.rwMu.Lock()
+	if c.refreshStop != nil {
+		close(c.refreshStop)
+		c.refreshStop = nil
+	}
+	c.rwMu.Unlock()
+}
+
+// ListPlatformCerts 获取 client 当前保存的全部平台证书
+func (c *ClientV3) ListPlatformCerts() (certs []*PlatformCertItem) {
+	c.rwMu.RLock()
+	defer c.rwMu.RUnlock()
+	for _, v := range c.platformCerts {
+		certs = append(certs, v.item)
+	}
+	return certs
+}
+
+// VerifyNotifySign 使用通知 Wechatpay-Serial 对应的平台证书，对异步通知验签
+//	国密模式下，使用 Wechatpay-Serial 对应的微信支付 SM2 公钥验签
+func (c *ClientV3) VerifyNotifySign(notifyReq *V3NotifyReq) (err error) {
+	if notifyReq == nil || notifyReq.SignInfo == nil {
+		return errors.New("verify notify sign, but SignInfo is nil")
+	}
+	if c.isSM2() {
+		return c.verifySM2Sign(notifyReq.SignInfo)
+	}
+	publicKey, err := c.getPlatformPublicKey(notifyReq.SignInfo.HeaderSerial)
+	if err != nil {
+		return err
+	}
+	if publicKey == nil {
+		return errors.New("WxPublicKey is null")
+	}
+	return verifySignByPublicKey(notifyReq.SignInfo, publicKey)
+}
+
+// getPlatformPublicKey 获取序列号对应的平台证书公钥，未加载多个平台证书时，使用 SetPlatformCert() 设置的公钥
+//	序列号为微信支付公钥ID 时，使用 SetWxPublicKey() 设置的微信支付公钥
+//	未找到序列号对应的平台证书或微信支付公钥时，返回 *UnknownSerialError
+func (c *ClientV3) getPlatformPublicKey(serialNo string) (publicKey *rsa.PublicKey, err error) {
+	c.rwMu.RLock()
+	defer c.rwMu.RUnlock()
+	if serialNo == "" {
+		if c.wxPublicKey == nil {
+			return c.wxPubKey, nil
+		}
+		return c.wxPublicKey, nil
+	}
+	if isWxPubKeyId(serialNo) {
+		if c.wxPubKey == nil || c.wxPubKeyId != serialNo {
+			return nil, &UnknownSerialError{SerialNo: serialNo}
+		}
+		return c.wxPubKey, nil
+	}
+	if len(c.platformCerts) == 0 {
+		if c.wxPublicKey == nil || (c.wxSerialNo != "" && c.wxSerialNo != serialNo) {
+			return nil, &UnknownSerialError{SerialNo: serialNo}
+		}
+		return c.wxPublicKey, nil
+	}
+	pc, ok := c.platformCerts[serialNo]
+	if !ok {
+		return nil, &UnknownSerialError{SerialNo: serialNo}
+	}
+	return pc.publicKey, nil
+}
+
+func (c *ClientV3) getWxSerialNo() string {
+	if c.isSM2() {
+		c.rwMu.RLock()
+		defer c.rwMu.RUnlock()
+		return c.sm2WxSerialNo
+	}
+	_, serialNo := c.encryptPublicKey()
+	return serialNo
+}
+
+func parsePlatformCert(item *PlatformCertItem) (pc *platformCert, err error) {
+	pubKey, err := xpem.DecodePublicKey([]byte(item.PublicKey))
+	if err != nil {
+		return nil, err
+	}
+	if pubKey == nil {
+		return nil, fmt.Errorf("platform cert [%s] public key is nil", item.SerialNo)
+	}
+	pc = &platformCert{item: item, publicKey: pubKey}
+	if item.EffectiveTime != "" {
+		if pc.effectiveTime, err = time.Parse(time.RFC3339, item.EffectiveTime); err != nil {
+			return nil, fmt.Errorf("time.Parse(%s),err:%w", item.EffectiveTime, err)
+		}
+	}
+	if item.ExpireTime != "" {
+		if pc.expireTime, err = time.Parse(time.RFC3339, item.ExpireTime); err != nil {
+			return nil, fmt.Errorf("time.Parse(%s),err:%w", item.ExpireTime, err)
+		}
+	}
+	return pc, nil
+}
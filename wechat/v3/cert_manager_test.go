@@ -0,0 +1,157 @@
+package wechat
+
+import (
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/base64"
+	"encoding/pem"
+	"errors"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/yuanqinguo/gopay/pkg/util"
+)
+
+func newTestPlatformCert(t *testing.T, serialNo string, effective, expire time.Time) (*PlatformCertItem, *rsa.PrivateKey) {
+	priKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tpl := &x509.Certificate{
+		SerialNumber: big.NewInt(time.Now().UnixNano()),
+		Subject:      pkix.Name{CommonName: serialNo},
+		NotBefore:    effective,
+		NotAfter:     expire,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tpl, tpl, &priKey.PublicKey, priKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	item := &PlatformCertItem{
+		EffectiveTime: effective.Format(time.RFC3339),
+		ExpireTime:    expire.Format(time.RFC3339),
+		PublicKey:     string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})),
+		SerialNo:      serialNo,
+	}
+	return item, priKey
+}
+
+func newTestSignInfo(t *testing.T, priKey *rsa.PrivateKey, serialNo, body string) *SignInfo {
+	si := &SignInfo{
+		HeaderTimestamp: util.Int642String(time.Now().Unix()),
+		HeaderNonce:     "nonce",
+		HeaderSerial:    serialNo,
+		SignBody:        body,
+	}
+	h := sha256.New()
+	h.Write([]byte(si.HeaderTimestamp + "\n" + si.HeaderNonce + "\n" + si.SignBody + "\n"))
+	sign, err := rsa.SignPKCS1v15(rand.Reader, priKey, crypto.SHA256, h.Sum(nil))
+	if err != nil {
+		t.Fatal(err)
+	}
+	si.HeaderSignature = base64.StdEncoding.EncodeToString(sign)
+	return si
+}
+
+func TestSetPlatformCerts(t *testing.T) {
+	now := time.Now()
+	oldCert, oldKey := newTestPlatformCert(t, "OLD", now.Add(-48*time.Hour), now.Add(24*time.Hour))
+	newCert, newKey := newTestPlatformCert(t, "NEW", now.Add(-time.Hour), now.Add(48*time.Hour))
+	expiredCert, _ := newTestPlatformCert(t, "EXPIRED", now.Add(-72*time.Hour), now.Add(-time.Hour))
+
+	c, err := NewClientV3(MchId, SerialNo, APIv3Key, PrivateKeyContent)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = c.SetPlatformCerts([]*PlatformCertItem{oldCert, newCert, expiredCert}); err != nil {
+		t.Fatal(err)
+	}
+	if c.getWxSerialNo() != "NEW" {
+		t.Fatalf("expected newest cert NEW for encryption, got: %s", c.getWxSerialNo())
+	}
+	if len(c.ListPlatformCerts()) != 2 {
+		t.Fatalf("expected expired cert removed, got %d certs", len(c.ListPlatformCerts()))
+	}
+
+	// 轮换期间，新旧证书签名均可验签
+	if err = c.VerifyNotifySign(&V3NotifyReq{SignInfo: newTestSignInfo(t, oldKey, "OLD", "body")}); err != nil {
+		t.Fatal(err)
+	}
+	if err = c.VerifyNotifySign(&V3NotifyReq{SignInfo: newTestSignInfo(t, newKey, "NEW", "body")}); err != nil {
+		t.Fatal(err)
+	}
+	if err = c.VerifyNotifySign(&V3NotifyReq{SignInfo: newTestSignInfo(t, oldKey, "NEW", "body")}); err == nil {
+		t.Fatal("expected verify failed when serial does not match the signing cert")
+	}
+}
+
+func TestSetPlatformCertsNotYetEffective(t *testing.T) {
+	now := time.Now()
+	current, _ := newTestPlatformCert(t, "CURRENT", now.Add(-48*time.Hour), now.Add(24*time.Hour))
+	future, _ := newTestPlatformCert(t, "FUTURE", now.Add(time.Hour), now.Add(72*time.Hour))
+
+	c, err := NewClientV3(MchId, SerialNo, APIv3Key, PrivateKeyContent)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// 新证书尚未启用时，仍使用已启用的证书加密敏感信息
+	if err = c.SetPlatformCerts([]*PlatformCertItem{current, future}); err != nil {
+		t.Fatal(err)
+	}
+	if c.getWxSerialNo() != "CURRENT" {
+		t.Fatalf("expected active cert CURRENT, got: %s", c.getWxSerialNo())
+	}
+
+	// 均未启用时，使用启用时间最晚的证书
+	c, err = NewClientV3(MchId, SerialNo, APIv3Key, PrivateKeyContent)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = c.SetPlatformCerts([]*PlatformCertItem{future}); err != nil {
+		t.Fatal(err)
+	}
+	if c.getWxSerialNo() != "FUTURE" {
+		t.Fatalf("expected newest cert FUTURE, got: %s", c.getWxSerialNo())
+	}
+}
+
+func TestVerifyUnknownSerial(t *testing.T) {
+	now := time.Now()
+	cert, priKey := newTestPlatformCert(t, "CURRENT", now.Add(-time.Hour), now.Add(24*time.Hour))
+
+	c, err := NewClientV3(MchId, SerialNo, APIv3Key, PrivateKeyContent)
+	if err != nil {
+		t.Fatal(err)
+	}
+	c.SetPlatformCert([]byte(cert.PublicKey), cert.SerialNo).AutoVerifySign()
+	if err = c.verifySyncSign(newTestSignInfo(t, priKey, "CURRENT", "body")); err != nil {
+		t.Fatal(err)
+	}
+
+	var serialErr *UnknownSerialError
+	err = c.verifySyncSign(newTestSignInfo(t, priKey, "ROTATED", "body"))
+	if !errors.As(err, &serialErr) || serialErr.SerialNo != "ROTATED" {
+		t.Fatalf("expected *UnknownSerialError, got: %v", err)
+	}
+	if err = c.SetPlatformCerts([]*PlatformCertItem{cert}); err != nil {
+		t.Fatal(err)
+	}
+	err = c.VerifyNotifySign(&V3NotifyReq{SignInfo: newTestSignInfo(t, priKey, "ROTATED", "body")})
+	if !errors.As(err, &serialErr) {
+		t.Fatalf("expected *UnknownSerialError, got: %v", err)
+	}
+
+	notifyReq := &V3NotifyReq{SignInfo: newTestSignInfo(t, priKey, "CURRENT", "body")}
+	if err = notifyReq.VerifySignByCerts(map[string]string{cert.SerialNo: cert.PublicKey}); err != nil {
+		t.Fatal(err)
+	}
+	err = notifyReq.VerifySignByCerts(map[string]string{"OTHER": cert.PublicKey})
+	if !errors.As(err, &serialErr) || serialErr.SerialNo != "CURRENT" {
+		t.Fatalf("expected *UnknownSerialError, got: %v", err)
+	}
+}
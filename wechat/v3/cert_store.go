@@ -0,0 +1,223 @@
+package wechat
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+)
+
+// CertStore 微信平台证书存储，按 mchid + 证书序列号 保存
+//	多实例部署时，可由一个实例刷新平台证书并写入，其他实例从存储中读取，避免频繁请求 /v3/certificates
+type CertStore interface {
+	// Get 获取证书，不存在时返回 nil, nil
+	Get(mchid, serialNo string) (cert *PlatformCertItem, err error)
+	// Put 保存证书，序列号相同时覆盖
+	Put(mchid string, cert *PlatformCertItem) (err error)
+	// List 获取商户的全部证书
+	List(mchid string) (certs []*PlatformCertItem, err error)
+}
+
+// MemoryCertStore 内存证书存储，仅当前进程内共享
+type MemoryCertStore struct {
+	certs map[string]map[string]*PlatformCertItem
+	mu    sync.RWMutex
+}
+
+// NewMemoryCertStore 初始化内存证书存储
+func NewMemoryCertStore() (store *MemoryCertStore) {
+	return &MemoryCertStore{certs: make(map[string]map[string]*PlatformCertItem)}
+}
+
+func (m *MemoryCertStore) Get(mchid, serialNo string) (cert *PlatformCertItem, err error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.certs[mchid][serialNo], nil
+}
+
+func (m *MemoryCertStore) Put(mchid string, cert *PlatformCertItem) (err error) {
+	if cert == nil {
+		return nil
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.certs[mchid] == nil {
+		m.certs[mchid] = make(map[string]*PlatformCertItem)
+	}
+	m.certs[mchid][cert.SerialNo] = cert
+	return nil
+}
+
+func (m *MemoryCertStore) List(mchid string) (certs []*PlatformCertItem, err error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	for _, v := range m.certs[mchid] {
+		certs = append(certs, v)
+	}
+	sortPlatformCerts(certs)
+	return certs, nil
+}
+
+// FileCertStore 文件证书存储，每个商户的证书以 JSON 格式保存在 dir 目录下的一个文件中
+//	可用于同一台机器的多个进程之间共享证书，Put 时对同目录下的 .lock 文件加文件锁（flock），避免多进程同时写入丢失证书
+//	Windows 等不支持 flock 的系统，及 NFS 等不支持文件锁的共享目录，Put 仅在当前进程内互斥，请只由一个进程写入
+//	mchid 用于生成文件名，只允许数字；Put 时清除已过期的证书
+type FileCertStore struct {
+	dir string
+	mu  sync.Mutex
+}
+
+// NewFileCertStore 初始化文件证书存储
+//	dir：证书保存目录，不存在时自动创建
+func NewFileCertStore(dir string) (store *FileCertStore, err error) {
+	if err = os.MkdirAll(dir, 0700); err != nil {
+		return nil, fmt.Errorf("os.MkdirAll(%s),err:%w", dir, err)
+	}
+	return &FileCertStore{dir: dir}, nil
+}
+
+func (f *FileCertStore) Get(mchid, serialNo string) (cert *PlatformCertItem, err error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	certs, err := f.read(mchid)
+	if err != nil {
+		return nil, err
+	}
+	for _, v := range certs {
+		if v.SerialNo == serialNo {
+			return v, nil
+		}
+	}
+	return nil, nil
+}
+
+func (f *FileCertStore) Put(mchid string, cert *PlatformCertItem) (err error) {
+	if cert == nil {
+		return nil
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	unlock, err := f.lock(mchid)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+	certs, err := f.read(mchid)
+	if err != nil {
+		return err
+	}
+	var (
+		now  = time.Now()
+		kept = []*PlatformCertItem{cert}
+	)
+	for _, v := range certs {
+		if v.SerialNo != cert.SerialNo && !platformCertExpired(v, now) {
+			kept = append(kept, v)
+		}
+	}
+	return f.write(mchid, kept)
+}
+
+func (f *FileCertStore) List(mchid string) (certs []*PlatformCertItem, err error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.read(mchid)
+}
+
+// filename 商户证书文件名，mchid 只允许数字，避免拼接出 dir 以外的路径
+func (f *FileCertStore) filename(mchid string) (filename string, err error) {
+	if mchid == "" {
+		return "", fmt.Errorf("invalid mchid [%s]", mchid)
+	}
+	for _, v := range mchid {
+		if v < '0' || v > '9' {
+			return "", fmt.Errorf("invalid mchid [%s]", mchid)
+		}
+	}
+	return filepath.Join(f.dir, "wechatpay_platform_certs_"+mchid+".json"), nil
+}
+
+// lock 对商户证书文件的 .lock 文件加排他文件锁，跨进程互斥 Put 的读-改-写
+func (f *FileCertStore) lock(mchid string) (unlock func(), err error) {
+	filename, err := f.filename(mchid)
+	if err != nil {
+		return nil, err
+	}
+	lf, err := os.OpenFile(filename+".lock", os.O_CREATE|os.O_RDWR, 0600)
+	if err != nil {
+		return nil, fmt.Errorf("os.OpenFile(%s),err:%w", filename+".lock", err)
+	}
+	if err = lockFile(lf); err != nil {
+		lf.Close()
+		return nil, fmt.Errorf("lock %s,err:%w", lf.Name(), err)
+	}
+	return func() {
+		unlockFile(lf)
+		lf.Close()
+	}, nil
+}
+
+func (f *FileCertStore) read(mchid string) (certs []*PlatformCertItem, err error) {
+	filename, err := f.filename(mchid)
+	if err != nil {
+		return nil, err
+	}
+	bs, err := ioutil.ReadFile(filename)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("ioutil.ReadFile(%s),err:%w", filename, err)
+	}
+	if err = json.Unmarshal(bs, &certs); err != nil {
+		return nil, fmt.Errorf("json.Unmarshal(%s)：%w", string(bs), err)
+	}
+	return certs, nil
+}
+
+// write 先写入临时文件再重命名，避免其他进程读取到不完整的文件
+func (f *FileCertStore) write(mchid string, certs []*PlatformCertItem) (err error) {
+	filename, err := f.filename(mchid)
+	if err != nil {
+		return err
+	}
+	sortPlatformCerts(certs)
+	bs, err := json.Marshal(certs)
+	if err != nil {
+		return fmt.Errorf("json.Marshal：%w", err)
+	}
+	tmp, err := ioutil.TempFile(f.dir, ".wechatpay_platform_certs_")
+	if err != nil {
+		return fmt.Errorf("ioutil.TempFile(%s),err:%w", f.dir, err)
+	}
+	if _, err = tmp.Write(bs); err != nil {
+		tmp.Close()
+		os.Remove(tmp.Name())
+		return fmt.Errorf("write %s,err:%w", tmp.Name(), err)
+	}
+	if err = tmp.Close(); err != nil {
+		os.Remove(tmp.Name())
+		return fmt.Errorf("close %s,err:%w", tmp.Name(), err)
+	}
+	if err = os.Rename(tmp.Name(), filename); err != nil {
+		os.Remove(tmp.Name())
+		return fmt.Errorf("os.Rename(%s),err:%w", filename, err)
+	}
+	return nil
+}
+
+// platformCertExpired 证书是否已过期，expire_time 为空或格式错误时视为未过期
+func platformCertExpired(cert *PlatformCertItem, now time.Time) bool {
+	expireTime, err := time.Parse(time.RFC3339, cert.ExpireTime)
+	return err == nil && now.After(expireTime)
+}
+
+func sortPlatformCerts(certs []*PlatformCertItem) {
+	sort.Slice(certs, func(i, j int) bool {
+		return certs[i].SerialNo < certs[j].SerialNo
+	})
+}
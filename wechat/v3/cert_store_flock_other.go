@@ -0,0 +1,15 @@
+//go:build !linux && !darwin && !freebsd && !netbsd && !openbsd && !dragonfly
+// +build !linux,!darwin,!freebsd,!netbsd,!openbsd,!dragonfly
+
+package wechat
+
+import (
+	"os"
+)
+
+// lockFile 不支持 flock 的系统，仅依赖 FileCertStore 的进程内互斥
+func lockFile(f *os.File) (err error) {
+	return nil
+}
+
+func unlockFile(f *os.File) {}
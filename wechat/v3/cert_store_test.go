@@ -0,0 +1,143 @@
+package wechat
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestCertStore(t *testing.T) {
+	dir, err := ioutil.TempDir("", "gopay_cert_store")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	fileStore, err := NewFileCertStore(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	now := time.Now()
+	cert, _ := newTestPlatformCert(t, "SERIAL", now.Add(-time.Hour), now.Add(24*time.Hour))
+
+	// mchid 只允许数字，避免拼接出 dir 以外的路径
+	for _, mchid := range []string{"", "../1900000109", "1900000109/..", "19000001 09"} {
+		if err = fileStore.Put(mchid, cert); err == nil {
+			t.Fatalf("expected invalid mchid error: %q", mchid)
+		}
+		if _, err = fileStore.List(mchid); err == nil {
+			t.Fatalf("expected invalid mchid error: %q", mchid)
+		}
+	}
+
+	for name, store := range map[string]CertStore{"memory": NewMemoryCertStore(), "file": fileStore} {
+		if c, err := store.Get("1900000109", "SERIAL"); err != nil || c != nil {
+			t.Fatalf("%s: expected nil cert before put, got: %v, %v", name, c, err)
+		}
+		if err = store.Put("1900000109", cert); err != nil {
+			t.Fatalf("%s: %v", name, err)
+		}
+		if err = store.Put("1900000109", cert); err != nil {
+			t.Fatalf("%s: %v", name, err)
+		}
+		c, err := store.Get("1900000109", "SERIAL")
+		if err != nil || c == nil || c.PublicKey != cert.PublicKey {
+			t.Fatalf("%s: get cert failed: %v, %v", name, c, err)
+		}
+		certs, err := store.List("1900000109")
+		if err != nil || len(certs) != 1 {
+			t.Fatalf("%s: expected 1 cert, got: %d, %v", name, len(certs), err)
+		}
+		if certs, _ = store.List("other"); len(certs) != 0 {
+			t.Fatalf("%s: expected no cert for other mchid, got: %d", name, len(certs))
+		}
+
+		// 其他实例从存储中读取平台证书
+		client, err := NewClientV3("1900000109", SerialNo, APIv3Key, PrivateKeyContent)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err = client.SetCertStore(store).LoadPlatformCerts(); err != nil {
+			t.Fatalf("%s: %v", name, err)
+		}
+		if client.getWxSerialNo() != "SERIAL" {
+			t.Fatalf("%s: expected wxSerialNo SERIAL, got: %s", name, client.getWxSerialNo())
+		}
+	}
+}
+
+func TestFileCertStorePutPruneExpired(t *testing.T) {
+	dir, err := ioutil.TempDir("", "gopay_cert_store")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	store, err := NewFileCertStore(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	now := time.Now()
+	expired, _ := newTestPlatformCert(t, "EXPIRED", now.Add(-48*time.Hour), now.Add(-time.Hour))
+	old, _ := newTestPlatformCert(t, "OLD", now.Add(-time.Hour), now.Add(time.Hour))
+	cert, _ := newTestPlatformCert(t, "NEW", now.Add(-time.Hour), now.Add(24*time.Hour))
+	for _, v := range []*PlatformCertItem{expired, old} {
+		if err = store.Put("1900000109", v); err != nil {
+			t.Fatal(err)
+		}
+	}
+	// 写入新证书时清除已过期的证书，保留未过期的旧证书
+	if err = store.Put("1900000109", cert); err != nil {
+		t.Fatal(err)
+	}
+	certs, err := store.List("1900000109")
+	if err != nil || len(certs) != 2 || certs[0].SerialNo != "NEW" || certs[1].SerialNo != "OLD" {
+		t.Fatalf("unexpected certs: %+v, %v", certs, err)
+	}
+}
+
+func TestFileCertStoreConcurrentPut(t *testing.T) {
+	dir, err := ioutil.TempDir("", "gopay_cert_store")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	now := time.Now()
+	cert, _ := newTestPlatformCert(t, "SERIAL", now.Add(-time.Hour), now.Add(24*time.Hour))
+	// 每个 FileCertStore 的进程内锁相互独立，模拟多进程同时写入
+	var (
+		wg    sync.WaitGroup
+		start = make(chan struct{})
+		errs  = make(chan error, 8)
+	)
+	for i := 0; i < 8; i++ {
+		store, err := NewFileCertStore(dir)
+		if err != nil {
+			t.Fatal(err)
+		}
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			<-start
+			for j := 0; j < 10; j++ {
+				item := *cert
+				item.SerialNo = fmt.Sprintf("SERIAL%d_%d", i, j)
+				if err := store.Put("1900000109", &item); err != nil {
+					errs <- err
+					return
+				}
+			}
+		}(i)
+	}
+	close(start)
+	wg.Wait()
+	close(errs)
+	for err = range errs {
+		t.Fatal(err)
+	}
+	store, _ := NewFileCertStore(dir)
+	if certs, err := store.List("1900000109"); err != nil || len(certs) != 80 {
+		t.Fatalf("expected 80 certs, got: %d, %v", len(certs), err)
+	}
+}
@@ -2,9 +2,12 @@ package wechat
 
 import (
 	"crypto/rsa"
+	"encoding/json"
 	"net/http"
+	"sync"
 
 	"github.com/yuanqinguo/gopay"
+	"github.com/yuanqinguo/gopay/pkg/sm2"
 	"github.com/yuanqinguo/gopay/pkg/xhttp"
 	"github.com/yuanqinguo/gopay/pkg/xlog"
 	"github.com/yuanqinguo/gopay/pkg/xpem"
@@ -12,14 +15,24 @@ import (
 
 // ClientV3 微信支付 V3
 type ClientV3 struct {
-	Mchid       string
-	SerialNo    string
-	apiV3Key    []byte
-	wxSerialNo  string
-	autoSign    bool
-	privateKey  *rsa.PrivateKey
-	wxPublicKey *rsa.PublicKey
-	DebugSwitch gopay.DebugSwitch
+	Mchid         string
+	SerialNo      string
+	apiV3Key      []byte
+	wxSerialNo    string
+	autoSign      bool
+	privateKey    *rsa.PrivateKey
+	sm2PrivateKey *sm2.PrivateKey
+	wxPublicKey   *rsa.PublicKey
+	wxPubKey      *rsa.PublicKey
+	wxPubKeyId    string
+	platformCerts map[string]*platformCert
+	sm2WxPubKeys  map[string]*sm2.PublicKey
+	sm2WxSerialNo string
+	certStore     CertStore
+	refreshStop   chan struct{}
+	hc            *http.Client
+	DebugSwitch   gopay.DebugSwitch
+	rwMu          sync.RWMutex
 }
 
 // NewClientV3 初始化微信客户端 V3
@@ -44,14 +57,23 @@ func NewClientV3(mchid, serialNo, apiV3Key, privateKey string) (client *ClientV3
 
 // AutoVerifySign 开启请求完自动验签功能（默认不开启，推荐开启）
 func (c *ClientV3) AutoVerifySign() {
-	if c.wxPublicKey != nil && c.wxSerialNo != "" {
+	c.rwMu.Lock()
+	if (c.wxPublicKey != nil && c.wxSerialNo != "") || c.wxPubKey != nil || len(c.sm2WxPubKeys) > 0 {
 		c.autoSign = true
 	}
+	c.rwMu.Unlock()
+}
+
+// SetHttpClient 设置自定义的 http.Client，client 的所有请求均通过此 http.Client 发出
+//	可用于代理、连接复用、链路追踪、单元测试替身等场景，不设置则每次请求使用默认配置
+func (c *ClientV3) SetHttpClient(httpClient *http.Client) (client *ClientV3) {
+	c.hc = httpClient
+	return c
 }
 
 func (c *ClientV3) doProdPostWithHeader(headerMap map[string]string, bm gopay.BodyMap, path, authorization string) (res *http.Response, si *SignInfo, bs []byte, err error) {
 	var url = v3BaseUrlCh + path
-	httpClient := xhttp.NewClient()
+	httpClient := xhttp.NewClient().SetHttpClient(c.hc)
 	if c.DebugSwitch == gopay.DebugOn {
 		xlog.Debugf("Wechat_V3_RequestBody: %s", bm.JsonBody())
 		xlog.Debugf("Wechat_V3_Authorization: %s", authorization)
@@ -60,7 +82,7 @@ func (c *ClientV3) doProdPostWithHeader(headerMap map[string]string, bm gopay.Bo
 		httpClient.Header.Add(k, v)
 	}
 	httpClient.Header.Add(HeaderAuthorization, authorization)
-	httpClient.Header.Add(HeaderSerial, c.wxSerialNo)
+	httpClient.Header.Add(HeaderSerial, c.getWxSerialNo())
 	httpClient.Header.Add("Accept", "*/*")
 	res, bs, errs := httpClient.Type(xhttp.TypeJSON).Post(url).SendBodyMap(bm).EndBytes()
 	if len(errs) > 0 {
@@ -83,13 +105,13 @@ func (c *ClientV3) doProdPostWithHeader(headerMap map[string]string, bm gopay.Bo
 
 func (c *ClientV3) doProdPost(bm gopay.BodyMap, path, authorization string) (res *http.Response, si *SignInfo, bs []byte, err error) {
 	var url = v3BaseUrlCh + path
-	httpClient := xhttp.NewClient()
+	httpClient := xhttp.NewClient().SetHttpClient(c.hc)
 	if c.DebugSwitch == gopay.DebugOn {
 		xlog.Debugf("Wechat_V3_RequestBody: %s", bm.JsonBody())
 		xlog.Debugf("Wechat_V3_Authorization: %s", authorization)
 	}
 	httpClient.Header.Add(HeaderAuthorization, authorization)
-	httpClient.Header.Add(HeaderSerial, c.wxSerialNo)
+	httpClient.Header.Add(HeaderSerial, c.getWxSerialNo())
 	httpClient.Header.Add("Accept", "*/*")
 	res, bs, errs := httpClient.Type(xhttp.TypeJSON).Post(url).SendBodyMap(bm).EndBytes()
 	if len(errs) > 0 {
@@ -112,13 +134,13 @@ func (c *ClientV3) doProdPost(bm gopay.BodyMap, path, authorization string) (res
 
 func (c *ClientV3) doProdGet(uri, authorization string) (res *http.Response, si *SignInfo, bs []byte, err error) {
 	var url = v3BaseUrlCh + uri
-	httpClient := xhttp.NewClient()
+	httpClient := xhttp.NewClient().SetHttpClient(c.hc)
 	if c.DebugSwitch == gopay.DebugOn {
 		xlog.Debugf("Wechat_V3_Url: %s", url)
 		xlog.Debugf("Wechat_V3_Authorization: %s", authorization)
 	}
 	httpClient.Header.Add(HeaderAuthorization, authorization)
-	httpClient.Header.Add(HeaderSerial, c.wxSerialNo)
+	httpClient.Header.Add(HeaderSerial, c.getWxSerialNo())
 	httpClient.Header.Add("Accept", "*/*")
 	res, bs, errs := httpClient.Type(xhttp.TypeJSON).Get(url).EndBytes()
 	if len(errs) > 0 {
@@ -141,13 +163,13 @@ func (c *ClientV3) doProdGet(uri, authorization string) (res *http.Response, si
 
 func (c *ClientV3) doProdPut(bm gopay.BodyMap, path, authorization string) (res *http.Response, si *SignInfo, bs []byte, err error) {
 	var url = v3BaseUrlCh + path
-	httpClient := xhttp.NewClient()
+	httpClient := xhttp.NewClient().SetHttpClient(c.hc)
 	if c.DebugSwitch == gopay.DebugOn {
 		xlog.Debugf("Wechat_V3_RequestBody: %s", bm.JsonBody())
 		xlog.Debugf("Wechat_V3_Authorization: %s", authorization)
 	}
 	httpClient.Header.Add(HeaderAuthorization, authorization)
-	httpClient.Header.Add(HeaderSerial, c.wxSerialNo)
+	httpClient.Header.Add(HeaderSerial, c.getWxSerialNo())
 	httpClient.Header.Add("Accept", "*/*")
 	res, bs, errs := httpClient.Type(xhttp.TypeJSON).Put(url).SendBodyMap(bm).EndBytes()
 	if len(errs) > 0 {
@@ -170,13 +192,13 @@ func (c *ClientV3) doProdPut(bm gopay.BodyMap, path, authorization string) (res
 
 func (c *ClientV3) doProdDelete(bm gopay.BodyMap, path, authorization string) (res *http.Response, si *SignInfo, bs []byte, err error) {
 	var url = v3BaseUrlCh + path
-	httpClient := xhttp.NewClient()
+	httpClient := xhttp.NewClient().SetHttpClient(c.hc)
 	if c.DebugSwitch == gopay.DebugOn {
 		xlog.Debugf("Wechat_V3_RequestBody: %s", bm.JsonBody())
 		xlog.Debugf("Wechat_V3_Authorization: %s", authorization)
 	}
 	httpClient.Header.Add(HeaderAuthorization, authorization)
-	httpClient.Header.Add(HeaderSerial, c.wxSerialNo)
+	httpClient.Header.Add(HeaderSerial, c.getWxSerialNo())
 	httpClient.Header.Add("Accept", "*/*")
 	res, bs, errs := httpClient.Type(xhttp.TypeJSON).Delete(url).SendBodyMap(bm).EndBytes()
 	if len(errs) > 0 {
@@ -199,13 +221,13 @@ func (c *ClientV3) doProdDelete(bm gopay.BodyMap, path, authorization string) (r
 
 func (c *ClientV3) doProdPostFile(bm gopay.BodyMap, path, authorization string) (res *http.Response, si *SignInfo, bs []byte, err error) {
 	var url = v3BaseUrlCh + path
-	httpClient := xhttp.NewClient()
+	httpClient := xhttp.NewClient().SetHttpClient(c.hc)
 	if c.DebugSwitch == gopay.DebugOn {
 		xlog.Debugf("Wechat_V3_RequestBody: %s", bm.GetString("meta"))
 		xlog.Debugf("Wechat_V3_Authorization: %s", authorization)
 	}
 	httpClient.Header.Add(HeaderAuthorization, authorization)
-	httpClient.Header.Add(HeaderSerial, c.wxSerialNo)
+	httpClient.Header.Add(HeaderSerial, c.getWxSerialNo())
 	httpClient.Header.Add("Accept", "*/*")
 	res, bs, errs := httpClient.Type(xhttp.TypeMultipartFormData).Post(url).SendMultipartBodyMap(bm).EndBytes()
 	if len(errs) > 0 {
@@ -228,13 +250,13 @@ func (c *ClientV3) doProdPostFile(bm gopay.BodyMap, path, authorization string)
 
 func (c *ClientV3) doProdPatch(bm gopay.BodyMap, path, authorization string) (res *http.Response, si *SignInfo, bs []byte, err error) {
 	var url = v3BaseUrlCh + path
-	httpClient := xhttp.NewClient()
+	httpClient := xhttp.NewClient().SetHttpClient(c.hc)
 	if c.DebugSwitch == gopay.DebugOn {
 		xlog.Debugf("Wechat_V3_RequestBody: %s", bm.JsonBody())
 		xlog.Debugf("Wechat_V3_Authorization: %s", authorization)
 	}
 	httpClient.Header.Add(HeaderAuthorization, authorization)
-	httpClient.Header.Add(HeaderSerial, c.wxSerialNo)
+	httpClient.Header.Add(HeaderSerial, c.getWxSerialNo())
 	httpClient.Header.Add("Accept", "*/*")
 	res, bs, errs := httpClient.Type(xhttp.TypeJSON).Patch(url).SendBodyMap(bm).EndBytes()
 	if len(errs) > 0 {
@@ -254,3 +276,20 @@ func (c *ClientV3) doProdPatch(bm gopay.BodyMap, path, authorization string) (re
 	}
 	return res, si, bs, nil
 }
+
+// newError 微信v3 接口返回非成功状态码时的错误
+//	文档：https://pay.weixin.qq.com/wiki/doc/apiv3/wechatpay/wechatpay2_0.shtml
+func newError(res *http.Response, bs []byte) error {
+	errRsp := new(struct {
+		Code    string `json:"code"`
+		Message string `json:"message"`
+	})
+	_ = json.Unmarshal(bs, errRsp)
+	e := gopay.NewError(gopay.ProviderWechatV3, res.StatusCode, errRsp.Code, errRsp.Message)
+	e.RequestId = res.Header.Get(HeaderRequestId)
+	e.Body = string(bs)
+	if errRsp.Code == "SYSTEM_ERROR" || errRsp.Code == "FREQUENCY_LIMITED" {
+		e.Retryable = true
+	}
+	return e
+}
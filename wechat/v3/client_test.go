@@ -4,6 +4,7 @@ import (
 	"crypto/sha256"
 	"encoding/hex"
 	"io/ioutil"
+	"net/http"
 	"os"
 	"testing"
 	"time"
@@ -467,3 +468,16 @@ func TestClientV3_V3ComplaintUploadImage(t *testing.T) {
 	}
 	xlog.Errorf("wxRsp: %s", wxRsp.Error)
 }
+
+func TestNewError(t *testing.T) {
+	res := &http.Response{StatusCode: http.StatusInternalServerError, Header: make(http.Header)}
+	res.Header.Set(HeaderRequestId, "08F78BB5AF0610D302839A5E1D3E8A")
+	err := newError(res, []byte(`{"code":"SYSTEM_ERROR","message":"系统错误"}`))
+	gopayErr, ok := gopay.AsError(err)
+	if !ok {
+		t.Fatalf("expected *gopay.Error, got: %v", err)
+	}
+	if gopayErr.Code != "SYSTEM_ERROR" || gopayErr.RequestId != "08F78BB5AF0610D302839A5E1D3E8A" || !gopayErr.Retryable {
+		t.Fatalf("unexpected error: %+v", gopayErr)
+	}
+}
@@ -32,7 +32,7 @@ func (c *ClientV3) V3ComplaintNotifyUrlCreate(url string) (wxRsp *ComplaintNotif
 	if res.StatusCode != http.StatusOK {
 		wxRsp.Code = res.StatusCode
 		wxRsp.Error = string(bs)
-		return wxRsp, nil
+		return wxRsp, newError(res, bs)
 	}
 	return wxRsp, c.verifySyncSign(si)
 }
@@ -58,7 +58,7 @@ func (c *ClientV3) V3ComplaintNotifyUrlQuery() (wxRsp *ComplaintNotifyUrlRsp, er
 	if res.StatusCode != http.StatusOK {
 		wxRsp.Code = res.StatusCode
 		wxRsp.Error = string(bs)
-		return wxRsp, nil
+		return wxRsp, newError(res, bs)
 	}
 	return wxRsp, c.verifySyncSign(si)
 }
@@ -86,7 +86,7 @@ func (c *ClientV3) V3ComplaintNotifyUrlUpdate(url string) (wxRsp *ComplaintNotif
 	if res.StatusCode != http.StatusOK {
 		wxRsp.Code = res.StatusCode
 		wxRsp.Error = string(bs)
-		return wxRsp, nil
+		return wxRsp, newError(res, bs)
 	}
 	return wxRsp, c.verifySyncSign(si)
 }
@@ -108,7 +108,7 @@ func (c *ClientV3) V3ComplaintNotifyUrlDelete() (wxRsp *EmptyRsp, err error) {
 	if res.StatusCode != http.StatusNoContent {
 		wxRsp.Code = res.StatusCode
 		wxRsp.Error = string(bs)
-		return wxRsp, nil
+		return wxRsp, newError(res, bs)
 	}
 	return wxRsp, c.verifySyncSign(si)
 }
@@ -142,7 +142,7 @@ func (c *ClientV3) V3ComplaintUploadImage(fileName, fileSha256 string, img *util
 	if res.StatusCode != http.StatusOK {
 		wxRsp.Code = res.StatusCode
 		wxRsp.Error = string(bs)
-		return wxRsp, nil
+		return wxRsp, newError(res, bs)
 	}
 	return wxRsp, c.verifySyncSign(si)
 }
@@ -169,7 +169,7 @@ func (c *ClientV3) V3ComplaintList(bm gopay.BodyMap) (wxRsp *ComplaintListRsp, e
 	if res.StatusCode != http.StatusOK {
 		wxRsp.Code = res.StatusCode
 		wxRsp.Error = string(bs)
-		return wxRsp, nil
+		return wxRsp, newError(res, bs)
 	}
 	return wxRsp, c.verifySyncSign(si)
 }
@@ -196,7 +196,7 @@ func (c *ClientV3) V3ComplaintNegotiationHistory(complaintId string, bm gopay.Bo
 	if res.StatusCode != http.StatusOK {
 		wxRsp.Code = res.StatusCode
 		wxRsp.Error = string(bs)
-		return wxRsp, nil
+		return wxRsp, newError(res, bs)
 	}
 	return wxRsp, c.verifySyncSign(si)
 }
@@ -223,7 +223,7 @@ func (c *ClientV3) V3ComplaintDetail(complaintId string) (wxRsp *ComplaintDetail
 	if res.StatusCode != http.StatusOK {
 		wxRsp.Code = res.StatusCode
 		wxRsp.Error = string(bs)
-		return wxRsp, nil
+		return wxRsp, newError(res, bs)
 	}
 	return wxRsp, c.verifySyncSign(si)
 }
@@ -246,7 +246,7 @@ func (c *ClientV3) V3ComplaintResponse(complaintId, bm gopay.BodyMap) (wxRsp *Em
 	if res.StatusCode != http.StatusNoContent {
 		wxRsp.Code = res.StatusCode
 		wxRsp.Error = string(bs)
-		return wxRsp, nil
+		return wxRsp, newError(res, bs)
 	}
 	return wxRsp, c.verifySyncSign(si)
 }
@@ -269,7 +269,7 @@ func (c *ClientV3) V3ComplaintComplete(complaintId, bm gopay.BodyMap) (wxRsp *Em
 	if res.StatusCode != http.StatusNoContent {
 		wxRsp.Code = res.StatusCode
 		wxRsp.Error = string(bs)
-		return wxRsp, nil
+		return wxRsp, newError(res, bs)
 	}
 	return wxRsp, c.verifySyncSign(si)
 }
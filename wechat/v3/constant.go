@@ -15,8 +15,10 @@ const (
 	HeaderNonce     = "Wechatpay-Nonce"
 	HeaderSignature = "Wechatpay-Signature"
 	HeaderSerial    = "Wechatpay-Serial"
+	HeaderRequestId = "Request-ID"
 
-	Authorization = "WECHATPAY2-SHA256-RSA2048"
+	Authorization    = "WECHATPAY2-SHA256-RSA2048"
+	AuthorizationSM2 = "WECHATPAY2-SM2-WITH-SM3"
 
 	v3BaseUrlCh = "https://api.mch.weixin.qq.com" // 中国国内
 
@@ -248,4 +250,13 @@ const (
 	TradeStateRevoked  = "REVOKED"    // 已撤销（付款码支付）
 	TradeStatePaying   = "USERPAYING" // 用户支付中（付款码支付）
 	TradeStatePayError = "PAYERROR"   // 支付失败(其他原因，如银行返回失败)
+
+	// v3 异步通知类型 event_type
+	EventTypeTransactionSuccess = "TRANSACTION.SUCCESS"    // 支付成功（普通支付、服务商支付、合单支付）
+	EventTypeRefundSuccess      = "REFUND.SUCCESS"         // 退款成功
+	EventTypeRefundAbnormal     = "REFUND.ABNORMAL"        // 退款异常
+	EventTypeRefundClosed       = "REFUND.CLOSED"          // 退款关闭
+	EventTypeCouponUse          = "COUPON.USE"             // 代金券核销
+	EventTypeComplaintCreate    = "COMPLAINT.CREATE"       // 产生新投诉
+	EventTypeComplaintState     = "COMPLAINT.STATE_CHANGE" // 投诉状态变化
 )
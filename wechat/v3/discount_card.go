@@ -28,7 +28,7 @@ func (c *ClientV3) V3DiscountCardApply(bm gopay.BodyMap) (wxRsp *DiscountCardApp
 	if res.StatusCode != http.StatusOK {
 		wxRsp.Code = res.StatusCode
 		wxRsp.Error = string(bs)
-		return wxRsp, nil
+		return wxRsp, newError(res, bs)
 	}
 	return wxRsp, c.verifySyncSign(si)
 }
@@ -54,7 +54,7 @@ func (c *ClientV3) V3DiscountCardAddUser(bm gopay.BodyMap) (wxRsp *EmptyRsp, err
 	if res.StatusCode != http.StatusNoContent {
 		wxRsp.Code = res.StatusCode
 		wxRsp.Error = string(bs)
-		return wxRsp, nil
+		return wxRsp, newError(res, bs)
 	}
 	return wxRsp, c.verifySyncSign(si)
 }
@@ -80,7 +80,7 @@ func (c *ClientV3) V3DiscountCardQuery(outCardCode string) (wxRsp *DiscountCardQ
 	if res.StatusCode != http.StatusOK {
 		wxRsp.Code = res.StatusCode
 		wxRsp.Error = string(bs)
-		return wxRsp, nil
+		return wxRsp, newError(res, bs)
 	}
 	return wxRsp, c.verifySyncSign(si)
 }
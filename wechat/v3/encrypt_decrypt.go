@@ -5,22 +5,22 @@ import (
 	"crypto/rsa"
 	"crypto/sha1"
 	"encoding/base64"
-	"encoding/json"
 	"errors"
 	"fmt"
 
 	"github.com/yuanqinguo/gopay"
-	"github.com/yuanqinguo/gopay/pkg/aes"
 	"github.com/yuanqinguo/gopay/pkg/util"
 	"github.com/yuanqinguo/gopay/pkg/xpem"
 )
 
 // 敏感信息加密
+//	设置了微信支付公钥（SetWxPublicKey()）时使用微信支付公钥加密，否则使用平台证书
 func (c *ClientV3) V3EncryptText(text string) (cipherText string, err error) {
-	if c.wxPublicKey == nil || c.wxSerialNo == "" {
+	wxPublicKey, wxSerialNo := c.encryptPublicKey()
+	if wxPublicKey == nil || wxSerialNo == "" {
 		return util.NULL, errors.New("WxPublicKey or WxSerialNo is null")
 	}
-	cipherByte, err := rsa.EncryptOAEP(sha1.New(), rand.Reader, c.wxPublicKey, []byte(text), nil)
+	cipherByte, err := rsa.EncryptOAEP(sha1.New(), rand.Reader, wxPublicKey, []byte(text), nil)
 	if err != nil {
 		return "", fmt.Errorf("rsa.EncryptOAEP：%w", err)
 	}
@@ -67,99 +67,71 @@ func V3DecryptText(cipherText string, privateKeyContent []byte) (text string, er
 }
 
 // 解密 普通支付 回调中的加密信息
+//	使用 AEAD_AES_256_GCM 解密，国密（AEAD_SM4_GCM）通知请使用 V3NotifyReq 的解密方法
 func V3DecryptNotifyCipherText(ciphertext, nonce, additional, apiV3Key string) (result *V3DecryptResult, err error) {
-	cipherBytes, _ := base64.StdEncoding.DecodeString(ciphertext)
-	decrypt, err := aes.GCMDecrypt(cipherBytes, []byte(nonce), []byte(additional), []byte(apiV3Key))
-	if err != nil {
-		return nil, fmt.Errorf("aes.GCMDecrypt, err:%+v", err)
-	}
 	result = &V3DecryptResult{}
-	if err = json.Unmarshal(decrypt, result); err != nil {
-		return nil, fmt.Errorf("json.Unmarshal(%s), err:%+v", string(decrypt), err)
+	if err = decryptNotifyResource(aesResource(ciphertext, nonce, additional), []byte(apiV3Key), result); err != nil {
+		return nil, err
 	}
 	return result, nil
 }
 
 // 解密 服务商支付 回调中的加密信息
+//	使用 AEAD_AES_256_GCM 解密，国密（AEAD_SM4_GCM）通知请使用 V3NotifyReq 的解密方法
 func V3DecryptPartnerNotifyCipherText(ciphertext, nonce, additional, apiV3Key string) (result *V3DecryptPartnerResult, err error) {
-	cipherBytes, _ := base64.StdEncoding.DecodeString(ciphertext)
-	decrypt, err := aes.GCMDecrypt(cipherBytes, []byte(nonce), []byte(additional), []byte(apiV3Key))
-	if err != nil {
-		return nil, fmt.Errorf("aes.GCMDecrypt, err:%+v", err)
-	}
 	result = &V3DecryptPartnerResult{}
-	if err = json.Unmarshal(decrypt, result); err != nil {
-		return nil, fmt.Errorf("json.Unmarshal(%s), err:%+v", string(decrypt), err)
+	if err = decryptNotifyResource(aesResource(ciphertext, nonce, additional), []byte(apiV3Key), result); err != nil {
+		return nil, err
 	}
 	return result, nil
 }
 
 // 解密 普通退款 回调中的加密信息
+//	使用 AEAD_AES_256_GCM 解密，国密（AEAD_SM4_GCM）通知请使用 V3NotifyReq 的解密方法
 func V3DecryptRefundNotifyCipherText(ciphertext, nonce, additional, apiV3Key string) (result *V3DecryptRefundResult, err error) {
-	cipherBytes, _ := base64.StdEncoding.DecodeString(ciphertext)
-	decrypt, err := aes.GCMDecrypt(cipherBytes, []byte(nonce), []byte(additional), []byte(apiV3Key))
-	if err != nil {
-		return nil, fmt.Errorf("aes.GCMDecrypt, err:%+v", err)
-	}
 	result = &V3DecryptRefundResult{}
-	if err = json.Unmarshal(decrypt, result); err != nil {
-		return nil, fmt.Errorf("json.Unmarshal(%s), err:%+v", string(decrypt), err)
+	if err = decryptNotifyResource(aesResource(ciphertext, nonce, additional), []byte(apiV3Key), result); err != nil {
+		return nil, err
 	}
 	return result, nil
 }
 
 // 解密 服务商退款 回调中的加密信息
+//	使用 AEAD_AES_256_GCM 解密，国密（AEAD_SM4_GCM）通知请使用 V3NotifyReq 的解密方法
 func V3DecryptPartnerRefundNotifyCipherText(ciphertext, nonce, additional, apiV3Key string) (result *V3DecryptPartnerRefundResult, err error) {
-	cipherBytes, _ := base64.StdEncoding.DecodeString(ciphertext)
-	decrypt, err := aes.GCMDecrypt(cipherBytes, []byte(nonce), []byte(additional), []byte(apiV3Key))
-	if err != nil {
-		return nil, fmt.Errorf("aes.GCMDecrypt, err:%+v", err)
-	}
 	result = &V3DecryptPartnerRefundResult{}
-	if err = json.Unmarshal(decrypt, result); err != nil {
-		return nil, fmt.Errorf("json.Unmarshal(%s), err:%+v", string(decrypt), err)
+	if err = decryptNotifyResource(aesResource(ciphertext, nonce, additional), []byte(apiV3Key), result); err != nil {
+		return nil, err
 	}
 	return result, nil
 }
 
 // 解密 合单支付 回调中的加密信息
+//	使用 AEAD_AES_256_GCM 解密，国密（AEAD_SM4_GCM）通知请使用 V3NotifyReq 的解密方法
 func V3DecryptCombineNotifyCipherText(ciphertext, nonce, additional, apiV3Key string) (result *V3DecryptCombineResult, err error) {
-	cipherBytes, _ := base64.StdEncoding.DecodeString(ciphertext)
-	decrypt, err := aes.GCMDecrypt(cipherBytes, []byte(nonce), []byte(additional), []byte(apiV3Key))
-	if err != nil {
-		return nil, fmt.Errorf("aes.GCMDecrypt, err:%+v", err)
-	}
 	result = &V3DecryptCombineResult{}
-	if err = json.Unmarshal(decrypt, result); err != nil {
-		return nil, fmt.Errorf("json.Unmarshal(%s), err:%+v", string(decrypt), err)
+	if err = decryptNotifyResource(aesResource(ciphertext, nonce, additional), []byte(apiV3Key), result); err != nil {
+		return nil, err
 	}
 	return result, nil
 }
 
 // 解密分账动账回调中的加密信息
+//	使用 AEAD_AES_256_GCM 解密，国密（AEAD_SM4_GCM）通知请使用 V3NotifyReq 的解密方法
 func V3DecryptProfitShareNotifyCipherText(ciphertext, nonce, additional, apiV3Key string) (result *V3DecryptProfitShareResult, err error) {
-	cipherBytes, _ := base64.StdEncoding.DecodeString(ciphertext)
-	decrypt, err := aes.GCMDecrypt(cipherBytes, []byte(nonce), []byte(additional), []byte(apiV3Key))
-	if err != nil {
-		return nil, fmt.Errorf("aes.GCMDecrypt, err:%+v", err)
-	}
 	result = &V3DecryptProfitShareResult{}
-	if err = json.Unmarshal(decrypt, result); err != nil {
-		return nil, fmt.Errorf("json.Unmarshal(%s), err:%+v", string(decrypt), err)
+	if err = decryptNotifyResource(aesResource(ciphertext, nonce, additional), []byte(apiV3Key), result); err != nil {
+		return nil, err
 	}
 	return result, nil
 }
 
 // 解密 支付分 回调中的加密信息
+//	使用 AEAD_AES_256_GCM 解密，国密（AEAD_SM4_GCM）通知请使用 V3NotifyReq 的解密方法
 func V3DecryptScoreNotifyCipherText(ciphertext, nonce, additional, apiV3Key string) (result *V3DecryptScoreResult, err error) {
-	cipherBytes, _ := base64.StdEncoding.DecodeString(ciphertext)
-	decrypt, err := aes.GCMDecrypt(cipherBytes, []byte(nonce), []byte(additional), []byte(apiV3Key))
-	if err != nil {
-		return nil, fmt.Errorf("aes.GCMDecrypt, err:%+v", err)
-	}
 	result = &V3DecryptScoreResult{}
-	if err = json.Unmarshal(decrypt, result); err != nil {
-		return nil, fmt.Errorf("json.Unmarshal(%s), err:%+v", string(decrypt), err)
+	if err = decryptNotifyResource(aesResource(ciphertext, nonce, additional), []byte(apiV3Key), result); err != nil {
+		return nil, err
 	}
 	return result, nil
 }
@@ -30,7 +30,7 @@ func (c *ClientV3) V3FavorBatchCreate(bm gopay.BodyMap) (wxRsp *FavorBatchCreate
 	if res.StatusCode != http.StatusOK {
 		wxRsp.Code = res.StatusCode
 		wxRsp.Error = string(bs)
-		return wxRsp, nil
+		return wxRsp, newError(res, bs)
 	}
 	return wxRsp, c.verifySyncSign(si)
 }
@@ -57,7 +57,7 @@ func (c *ClientV3) V3FavorBatchGrant(openid string, bm gopay.BodyMap) (wxRsp *Fa
 	if res.StatusCode != http.StatusOK {
 		wxRsp.Code = res.StatusCode
 		wxRsp.Error = string(bs)
-		return wxRsp, nil
+		return wxRsp, newError(res, bs)
 	}
 	return wxRsp, c.verifySyncSign(si)
 }
@@ -86,7 +86,7 @@ func (c *ClientV3) V3FavorBatchStart(stockId, stockCreatorMchid string) (wxRsp *
 	if res.StatusCode != http.StatusOK {
 		wxRsp.Code = res.StatusCode
 		wxRsp.Error = string(bs)
-		return wxRsp, nil
+		return wxRsp, newError(res, bs)
 	}
 	return wxRsp, c.verifySyncSign(si)
 }
@@ -113,7 +113,7 @@ func (c *ClientV3) V3FavorBatchList(bm gopay.BodyMap) (wxRsp *FavorBatchListRsp,
 	if res.StatusCode != http.StatusOK {
 		wxRsp.Code = res.StatusCode
 		wxRsp.Error = string(bs)
-		return wxRsp, nil
+		return wxRsp, newError(res, bs)
 	}
 	return wxRsp, c.verifySyncSign(si)
 }
@@ -140,7 +140,7 @@ func (c *ClientV3) V3FavorBatchDetail(stockId, stockCreatorMchid string) (wxRsp
 	if res.StatusCode != http.StatusOK {
 		wxRsp.Code = res.StatusCode
 		wxRsp.Error = string(bs)
-		return wxRsp, nil
+		return wxRsp, newError(res, bs)
 	}
 	return wxRsp, c.verifySyncSign(si)
 }
@@ -167,7 +167,7 @@ func (c *ClientV3) V3FavorDetail(appid, couponId, openid string) (wxRsp *FavorDe
 	if res.StatusCode != http.StatusOK {
 		wxRsp.Code = res.StatusCode
 		wxRsp.Error = string(bs)
-		return wxRsp, nil
+		return wxRsp, newError(res, bs)
 	}
 	return wxRsp, c.verifySyncSign(si)
 }
@@ -197,7 +197,7 @@ func (c *ClientV3) V3FavorMerchant(stockId, stockCreatorMchid string, limit, off
 	if res.StatusCode != http.StatusOK {
 		wxRsp.Code = res.StatusCode
 		wxRsp.Error = string(bs)
-		return wxRsp, nil
+		return wxRsp, newError(res, bs)
 	}
 	return wxRsp, c.verifySyncSign(si)
 }
@@ -227,7 +227,7 @@ func (c *ClientV3) V3FavorItems(stockId, stockCreatorMchid string, limit, offset
 	if res.StatusCode != http.StatusOK {
 		wxRsp.Code = res.StatusCode
 		wxRsp.Error = string(bs)
-		return wxRsp, nil
+		return wxRsp, newError(res, bs)
 	}
 	return wxRsp, c.verifySyncSign(si)
 }
@@ -254,7 +254,7 @@ func (c *ClientV3) V3FavorUserCoupons(openid string, bm gopay.BodyMap) (wxRsp *F
 	if res.StatusCode != http.StatusOK {
 		wxRsp.Code = res.StatusCode
 		wxRsp.Error = string(bs)
-		return wxRsp, nil
+		return wxRsp, newError(res, bs)
 	}
 	return wxRsp, c.verifySyncSign(si)
 }
@@ -281,7 +281,7 @@ func (c *ClientV3) V3FavorUseFlowDownload(stockId string) (wxRsp *FavorUseFlowDo
 	if res.StatusCode != http.StatusOK {
 		wxRsp.Code = res.StatusCode
 		wxRsp.Error = string(bs)
-		return wxRsp, nil
+		return wxRsp, newError(res, bs)
 	}
 	return wxRsp, c.verifySyncSign(si)
 }
@@ -308,7 +308,7 @@ func (c *ClientV3) V3FavorRefundFlowDownload(stockId string) (wxRsp *FavorRefund
 	if res.StatusCode != http.StatusOK {
 		wxRsp.Code = res.StatusCode
 		wxRsp.Error = string(bs)
-		return wxRsp, nil
+		return wxRsp, newError(res, bs)
 	}
 	return wxRsp, c.verifySyncSign(si)
 }
@@ -334,7 +334,7 @@ func (c *ClientV3) V3FavorCallbackUrlSet(bm gopay.BodyMap) (wxRsp *FavorCallback
 	if res.StatusCode != http.StatusOK {
 		wxRsp.Code = res.StatusCode
 		wxRsp.Error = string(bs)
-		return wxRsp, nil
+		return wxRsp, newError(res, bs)
 	}
 	return wxRsp, c.verifySyncSign(si)
 }
@@ -363,7 +363,7 @@ func (c *ClientV3) V3FavorBatchPause(stockId, stockCreatorMchid string) (wxRsp *
 	if res.StatusCode != http.StatusOK {
 		wxRsp.Code = res.StatusCode
 		wxRsp.Error = string(bs)
-		return wxRsp, nil
+		return wxRsp, newError(res, bs)
 	}
 	return wxRsp, c.verifySyncSign(si)
 }
@@ -392,7 +392,7 @@ func (c *ClientV3) V3FavorBatchRestart(stockId, stockCreatorMchid string) (wxRsp
 	if res.StatusCode != http.StatusOK {
 		wxRsp.Code = res.StatusCode
 		wxRsp.Error = string(bs)
-		return wxRsp, nil
+		return wxRsp, newError(res, bs)
 	}
 	return wxRsp, c.verifySyncSign(si)
 }
@@ -38,7 +38,7 @@ func (c *ClientV3) V3FavorMediaUploadImage(fileName, fileSha256 string, img *uti
 	if res.StatusCode != http.StatusOK {
 		wxRsp.Code = res.StatusCode
 		wxRsp.Error = string(bs)
-		return wxRsp, nil
+		return wxRsp, newError(res, bs)
 	}
 	return wxRsp, c.verifySyncSign(si)
 }
@@ -29,7 +29,7 @@ func (c *ClientV3) V3PartnershipsBuild(idempotencyKey string, bm gopay.BodyMap)
 	if res.StatusCode != http.StatusOK {
 		wxRsp.Code = res.StatusCode
 		wxRsp.Error = string(bs)
-		return wxRsp, nil
+		return wxRsp, newError(res, bs)
 	}
 	return wxRsp, c.verifySyncSign(si)
 }
@@ -55,7 +55,7 @@ func (c *ClientV3) V3PartnershipsTerminate(idempotencyKey string, bm gopay.BodyM
 	if res.StatusCode != http.StatusOK {
 		wxRsp.Code = res.StatusCode
 		wxRsp.Error = string(bs)
-		return wxRsp, nil
+		return wxRsp, newError(res, bs)
 	}
 	return wxRsp, c.verifySyncSign(si)
 }
@@ -82,7 +82,7 @@ func (c *ClientV3) V3PartnershipsList(bm gopay.BodyMap) (wxRsp *PartnershipsList
 	if res.StatusCode != http.StatusOK {
 		wxRsp.Code = res.StatusCode
 		wxRsp.Error = string(bs)
-		return wxRsp, nil
+		return wxRsp, newError(res, bs)
 	}
 	return wxRsp, c.verifySyncSign(si)
 }
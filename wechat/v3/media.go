@@ -38,7 +38,7 @@ func (c *ClientV3) V3MediaUploadImage(fileName, fileSha256 string, img *util.Fil
 	if res.StatusCode != http.StatusOK {
 		wxRsp.Code = res.StatusCode
 		wxRsp.Error = string(bs)
-		return wxRsp, nil
+		return wxRsp, newError(res, bs)
 	}
 	return wxRsp, c.verifySyncSign(si)
 }
@@ -72,7 +72,7 @@ func (c *ClientV3) V3MediaUploadVideo(fileName, fileSha256 string, img *util.Fil
 	if res.StatusCode != http.StatusOK {
 		wxRsp.Code = res.StatusCode
 		wxRsp.Error = string(bs)
-		return wxRsp, nil
+		return wxRsp, newError(res, bs)
 	}
 	return wxRsp, c.verifySyncSign(si)
 }
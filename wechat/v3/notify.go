@@ -7,6 +7,8 @@ import (
 	"io"
 	"io/ioutil"
 	"net/http"
+	"strconv"
+	"time"
 
 	"github.com/yuanqinguo/gopay"
 	"github.com/yuanqinguo/gopay/pkg/xlog"
@@ -122,6 +124,11 @@ type V3DecryptProfitShareResult struct {
 	SuccessTime   string    `json:"success_time"` // 成功时间
 }
 
+type V3DecryptComplaintResult struct {
+	ComplaintId string `json:"complaint_id"` // 投诉单号
+	ActionType  string `json:"action_type"`  // 动作类型
+}
+
 type Receiver struct {
 	Type        string `json:"type"`        // 分账接收方类型
 	Account     string `json:"account"`     // 分账接收方账号
@@ -174,95 +181,120 @@ func (v *V3NotifyReq) VerifySign(wxPkContent string) (err error) {
 	return errors.New("verify notify sign, bug SignInfo is nil")
 }
 
+// 异步通知验签，使用通知 Wechatpay-Serial 对应的平台证书验签
+//	wxPkContents 是平台证书序列号 -> 平台公钥证书内容，通过client.GetPlatformCerts()接口向微信获取
+//	未找到对应的平台证书时，返回 *UnknownSerialError
+func (v *V3NotifyReq) VerifySignByCerts(wxPkContents map[string]string) (err error) {
+	if v.SignInfo != nil {
+		return V3VerifySignByCerts(v.SignInfo.HeaderTimestamp, v.SignInfo.HeaderNonce, v.SignInfo.SignBody, v.SignInfo.HeaderSignature, v.SignInfo.HeaderSerial, wxPkContents)
+	}
+	return errors.New("verify notify sign, bug SignInfo is nil")
+}
+
+// 校验异步通知的 Wechatpay-Timestamp，拒绝过期或时间异常的通知，防止重放
+//	maxAge：通知允许的最大时间差，如 5 * time.Minute
+func (v *V3NotifyReq) CheckTimestamp(maxAge time.Duration) (err error) {
+	if v.SignInfo == nil {
+		return errors.New("check notify timestamp, bug SignInfo is nil")
+	}
+	ts, err := strconv.ParseInt(v.SignInfo.HeaderTimestamp, 10, 64)
+	if err != nil {
+		return fmt.Errorf("invalid %s [%s]", HeaderTimestamp, v.SignInfo.HeaderTimestamp)
+	}
+	if d := time.Since(time.Unix(ts, 0)); d > maxAge || d < -maxAge {
+		return fmt.Errorf("notify %s [%s] expired, max age: %s", HeaderTimestamp, v.SignInfo.HeaderTimestamp, maxAge)
+	}
+	return nil
+}
+
+// 获取异步通知去重使用的 key（通知ID），配合 gopay.NotifyDeduper 使用
+//	返回格式：wechat_v3:notify:{id}，通知ID为空时返回空字符串，不做去重
+func (v *V3NotifyReq) DedupeKey() (key string) {
+	return gopay.NotifyDedupeKey(gopay.ProviderWechatV3, "notify", v.Id)
+}
+
+// 解密回调中的加密信息到结构体，按 resource.algorithm 使用 AEAD_AES_256_GCM 或 AEAD_SM4_GCM（国密）解密
+//	ptr：结构体指针，如 &V3DecryptResult{}
+func (v *V3NotifyReq) DecryptCipherTextToStruct(apiV3Key string, ptr interface{}) (err error) {
+	if v.Resource == nil {
+		return errors.New("notify data Resource is nil")
+	}
+	return decryptNotifyResource(v.Resource, []byte(apiV3Key), ptr)
+}
+
 // 解密 普通支付 回调中的加密信息
 func (v *V3NotifyReq) DecryptCipherText(apiV3Key string) (result *V3DecryptResult, err error) {
-	if v.Resource != nil {
-		result, err = V3DecryptNotifyCipherText(v.Resource.Ciphertext, v.Resource.Nonce, v.Resource.AssociatedData, apiV3Key)
-		if err != nil {
-			bytes, _ := json.Marshal(v)
-			return nil, fmt.Errorf("V3NotifyReq(%s) decrypt cipher text error(%+v)", string(bytes), err)
-		}
-		return result, nil
+	result = &V3DecryptResult{}
+	if err = v.decryptCipherText(apiV3Key, result); err != nil {
+		return nil, err
 	}
-	return nil, errors.New("notify data Resource is nil")
+	return result, nil
 }
 
 // 解密 服务商支付 回调中的加密信息
 func (v *V3NotifyReq) DecryptPartnerCipherText(apiV3Key string) (result *V3DecryptPartnerResult, err error) {
-	if v.Resource != nil {
-		result, err = V3DecryptPartnerNotifyCipherText(v.Resource.Ciphertext, v.Resource.Nonce, v.Resource.AssociatedData, apiV3Key)
-		if err != nil {
-			bytes, _ := json.Marshal(v)
-			return nil, fmt.Errorf("V3NotifyReq(%s) decrypt cipher text error(%+v)", string(bytes), err)
-		}
-		return result, nil
+	result = &V3DecryptPartnerResult{}
+	if err = v.decryptCipherText(apiV3Key, result); err != nil {
+		return nil, err
 	}
-	return nil, errors.New("notify data Resource is nil")
+	return result, nil
 }
 
 // 解密 普通退款 回调中的加密信息
 func (v *V3NotifyReq) DecryptRefundCipherText(apiV3Key string) (result *V3DecryptRefundResult, err error) {
-	if v.Resource != nil {
-		result, err = V3DecryptRefundNotifyCipherText(v.Resource.Ciphertext, v.Resource.Nonce, v.Resource.AssociatedData, apiV3Key)
-		if err != nil {
-			bytes, _ := json.Marshal(v)
-			return nil, fmt.Errorf("V3NotifyReq(%s) decrypt cipher text error(%+v)", string(bytes), err)
-		}
-		return result, nil
+	result = &V3DecryptRefundResult{}
+	if err = v.decryptCipherText(apiV3Key, result); err != nil {
+		return nil, err
 	}
-	return nil, errors.New("notify data Resource is nil")
+	return result, nil
 }
 
 // 解密 服务商退款 回调中的加密信息
 func (v *V3NotifyReq) DecryptPartnerRefundCipherText(apiV3Key string) (result *V3DecryptPartnerRefundResult, err error) {
-	if v.Resource != nil {
-		result, err = V3DecryptPartnerRefundNotifyCipherText(v.Resource.Ciphertext, v.Resource.Nonce, v.Resource.AssociatedData, apiV3Key)
-		if err != nil {
-			bytes, _ := json.Marshal(v)
-			return nil, fmt.Errorf("V3NotifyReq(%s) decrypt cipher text error(%+v)", string(bytes), err)
-		}
-		return result, nil
+	result = &V3DecryptPartnerRefundResult{}
+	if err = v.decryptCipherText(apiV3Key, result); err != nil {
+		return nil, err
 	}
-	return nil, errors.New("notify data Resource is nil")
+	return result, nil
 }
 
 // 解密 合单支付 回调中的加密信息
 func (v *V3NotifyReq) DecryptCombineCipherText(apiV3Key string) (result *V3DecryptCombineResult, err error) {
-	if v.Resource != nil {
-		result, err = V3DecryptCombineNotifyCipherText(v.Resource.Ciphertext, v.Resource.Nonce, v.Resource.AssociatedData, apiV3Key)
-		if err != nil {
-			bytes, _ := json.Marshal(v)
-			return nil, fmt.Errorf("V3NotifyReq(%s) decrypt cipher text error(%+v)", string(bytes), err)
-		}
-		return result, nil
+	result = &V3DecryptCombineResult{}
+	if err = v.decryptCipherText(apiV3Key, result); err != nil {
+		return nil, err
 	}
-	return nil, errors.New("notify data Resource is nil")
+	return result, nil
 }
 
 // 解密 支付分 回调中的加密信息
 func (v *V3NotifyReq) DecryptScoreCipherText(apiV3Key string) (result *V3DecryptScoreResult, err error) {
-	if v.Resource != nil {
-		result, err = V3DecryptScoreNotifyCipherText(v.Resource.Ciphertext, v.Resource.Nonce, v.Resource.AssociatedData, apiV3Key)
-		if err != nil {
-			bytes, _ := json.Marshal(v)
-			return nil, fmt.Errorf("V3NotifyReq(%s) decrypt cipher text error(%+v)", string(bytes), err)
-		}
-		return result, nil
+	result = &V3DecryptScoreResult{}
+	if err = v.decryptCipherText(apiV3Key, result); err != nil {
+		return nil, err
 	}
-	return nil, errors.New("notify data Resource is nil")
+	return result, nil
 }
 
 // 解密分账动账回调中的加密信息
 func (v *V3NotifyReq) DecryptProfitShareCipherText(apiV3Key string) (result *V3DecryptProfitShareResult, err error) {
-	if v.Resource != nil {
-		result, err = V3DecryptProfitShareNotifyCipherText(v.Resource.Ciphertext, v.Resource.Nonce, v.Resource.AssociatedData, apiV3Key)
-		if err != nil {
-			bytes, _ := json.Marshal(v)
-			return nil, fmt.Errorf("V3NotifyReq(%s) decrypt cipher text error(%+v)", string(bytes), err)
-		}
-		return result, nil
+	result = &V3DecryptProfitShareResult{}
+	if err = v.decryptCipherText(apiV3Key, result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// decryptCipherText 按 resource.algorithm 解密回调中的加密信息到结构体
+func (v *V3NotifyReq) decryptCipherText(apiV3Key string, result interface{}) (err error) {
+	if v.Resource == nil {
+		return errors.New("notify data Resource is nil")
+	}
+	if err = decryptNotifyResource(v.Resource, []byte(apiV3Key), result); err != nil {
+		bytes, _ := json.Marshal(v)
+		return fmt.Errorf("V3NotifyReq(%s) decrypt cipher text error(%+v)", string(bytes), err)
 	}
-	return nil, errors.New("notify data Resource is nil")
+	return nil
 }
 
 // Deprecated
@@ -0,0 +1,258 @@
+package wechat
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/yuanqinguo/gopay"
+	"github.com/yuanqinguo/gopay/pkg/xlog"
+)
+
+// V3NotifyHandler 微信支付V3 异步通知 http.Handler
+//	依次完成：解析通知、使用 Wechatpay-Serial 对应的平台证书验签、解密 resource、按 event_type 调用对应回调、返回应答
+//	回调方法返回 nil 时，返回 200 和 {"code":"SUCCESS"}；解析失败、验签失败、回调返回 err、或未设置对应回调时，返回 4XX/5XX 和 {"code":"FAIL"}，微信会重新通知
+//	文档：https://pay.weixin.qq.com/wiki/doc/apiv3/wechatpay/wechatpay4_1.shtml
+type V3NotifyHandler struct {
+	client           *ClientV3
+	onPayment        func(ctx context.Context, notifyReq *V3NotifyReq, result *V3DecryptResult) error
+	onPartnerPayment func(ctx context.Context, notifyReq *V3NotifyReq, result *V3DecryptPartnerResult) error
+	onRefund         func(ctx context.Context, notifyReq *V3NotifyReq, result *V3DecryptRefundResult) error
+	onPartnerRefund  func(ctx context.Context, notifyReq *V3NotifyReq, result *V3DecryptPartnerRefundResult) error
+	onCombine        func(ctx context.Context, notifyReq *V3NotifyReq, result *V3DecryptCombineResult) error
+	onScore          func(ctx context.Context, notifyReq *V3NotifyReq, result *V3DecryptScoreResult) error
+	onProfitShare    func(ctx context.Context, notifyReq *V3NotifyReq, result *V3DecryptProfitShareResult) error
+	onComplaint      func(ctx context.Context, notifyReq *V3NotifyReq, result *V3DecryptComplaintResult) error
+	onCoupon         func(ctx context.Context, notifyReq *V3NotifyReq, result *UserCoupon) error
+	onOther          func(ctx context.Context, notifyReq *V3NotifyReq, plaintext []byte) error
+	onError          func(req *http.Request, err error)
+	deduper          *gopay.NotifyDeduper
+	maxAge           time.Duration
+}
+
+// NewV3NotifyHandler 初始化微信支付V3 异步通知 http.Handler
+//	client：用于验签（平台证书）和解密（APIv3Key），请预先设置平台证书，如 client.AutoRefreshPlatformCerts()
+func NewV3NotifyHandler(client *ClientV3) (handler *V3NotifyHandler) {
+	return &V3NotifyHandler{client: client}
+}
+
+// OnPayment 设置 普通支付 通知回调（TRANSACTION.SUCCESS）
+func (h *V3NotifyHandler) OnPayment(fn func(ctx context.Context, notifyReq *V3NotifyReq, result *V3DecryptResult) error) (handler *V3NotifyHandler) {
+	h.onPayment = fn
+	return h
+}
+
+// OnPartnerPayment 设置 服务商支付 通知回调（TRANSACTION.SUCCESS，且包含 sp_mchid）
+func (h *V3NotifyHandler) OnPartnerPayment(fn func(ctx context.Context, notifyReq *V3NotifyReq, result *V3DecryptPartnerResult) error) (handler *V3NotifyHandler) {
+	h.onPartnerPayment = fn
+	return h
+}
+
+// OnRefund 设置 普通退款 通知回调（REFUND.SUCCESS、REFUND.ABNORMAL、REFUND.CLOSED）
+func (h *V3NotifyHandler) OnRefund(fn func(ctx context.Context, notifyReq *V3NotifyReq, result *V3DecryptRefundResult) error) (handler *V3NotifyHandler) {
+	h.onRefund = fn
+	return h
+}
+
+// OnPartnerRefund 设置 服务商退款 通知回调（REFUND.*，且包含 sp_mchid）
+func (h *V3NotifyHandler) OnPartnerRefund(fn func(ctx context.Context, notifyReq *V3NotifyReq, result *V3DecryptPartnerRefundResult) error) (handler *V3NotifyHandler) {
+	h.onPartnerRefund = fn
+	return h
+}
+
+// OnCombine 设置 合单支付 通知回调（TRANSACTION.SUCCESS，且包含 combine_out_trade_no）
+func (h *V3NotifyHandler) OnCombine(fn func(ctx context.Context, notifyReq *V3NotifyReq, result *V3DecryptCombineResult) error) (handler *V3NotifyHandler) {
+	h.onCombine = fn
+	return h
+}
+
+// OnScore 设置 支付分 通知回调（PAYSCORE.*）
+func (h *V3NotifyHandler) OnScore(fn func(ctx context.Context, notifyReq *V3NotifyReq, result *V3DecryptScoreResult) error) (handler *V3NotifyHandler) {
+	h.onScore = fn
+	return h
+}
+
+// OnProfitShare 设置 分账动账 通知回调（PROFITSHARING.*）
+func (h *V3NotifyHandler) OnProfitShare(fn func(ctx context.Context, notifyReq *V3NotifyReq, result *V3DecryptProfitShareResult) error) (handler *V3NotifyHandler) {
+	h.onProfitShare = fn
+	return h
+}
+
+// OnComplaint 设置 消费者投诉 通知回调（COMPLAINT.*）
+func (h *V3NotifyHandler) OnComplaint(fn func(ctx context.Context, notifyReq *V3NotifyReq, result *V3DecryptComplaintResult) error) (handler *V3NotifyHandler) {
+	h.onComplaint = fn
+	return h
+}
+
+// OnCoupon 设置 代金券核销 通知回调（COUPON.*）
+func (h *V3NotifyHandler) OnCoupon(fn func(ctx context.Context, notifyReq *V3NotifyReq, result *UserCoupon) error) (handler *V3NotifyHandler) {
+	h.onCoupon = fn
+	return h
+}
+
+// OnOther 设置其他类型通知的回调，plaintext 为解密后的 resource 明文
+func (h *V3NotifyHandler) OnOther(fn func(ctx context.Context, notifyReq *V3NotifyReq, plaintext []byte) error) (handler *V3NotifyHandler) {
+	h.onOther = fn
+	return h
+}
+
+// SetDeduper 设置异步通知去重，按通知ID去重，重复的通知不再调用回调方法
+//	已处理完成的重复通知直接应答 SUCCESS；首次通知仍在处理中时应答 FAIL（500），由微信稍后重新通知
+//	deduper：gopay.NewNotifyDeduper(nil, 0) 使用内存 LRU 存储，多实例部署时请自行实现 gopay.NotifyStore
+func (h *V3NotifyHandler) SetDeduper(deduper *gopay.NotifyDeduper) (handler *V3NotifyHandler) {
+	h.deduper = deduper
+	return h
+}
+
+// SetMaxAge 设置通知 Wechatpay-Timestamp 允许的最大时间差，超出时拒绝通知，防止重放，不设置则不校验
+func (h *V3NotifyHandler) SetMaxAge(maxAge time.Duration) (handler *V3NotifyHandler) {
+	h.maxAge = maxAge
+	return h
+}
+
+// OnError 设置处理失败时的回调，可用于记录日志，不设置时使用 xlog 输出错误日志
+func (h *V3NotifyHandler) OnError(fn func(req *http.Request, err error)) (handler *V3NotifyHandler) {
+	h.onError = fn
+	return h
+}
+
+func (h *V3NotifyHandler) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	status, err := h.handle(req)
+	rsp := &V3NotifyRsp{Code: gopay.SUCCESS, Message: "成功"}
+	if err != nil {
+		if h.onError != nil {
+			h.onError(req, err)
+		} else {
+			xlog.Errorf("wechat v3 notify handle error: %+v", err)
+		}
+		rsp = &V3NotifyRsp{Code: gopay.FAIL, Message: err.Error()}
+	}
+	bs, _ := json.Marshal(rsp)
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	w.Write(bs)
+}
+
+func (h *V3NotifyHandler) handle(req *http.Request) (status int, err error) {
+	notifyReq, err := V3ParseNotify(req)
+	if err != nil {
+		return http.StatusBadRequest, err
+	}
+	if err = h.client.VerifyNotifySign(notifyReq); err != nil {
+		return http.StatusUnauthorized, err
+	}
+	if h.maxAge > 0 {
+		if err = notifyReq.CheckTimestamp(h.maxAge); err != nil {
+			return http.StatusBadRequest, err
+		}
+	}
+	plaintext, err := h.decrypt(notifyReq)
+	if err != nil {
+		return http.StatusBadRequest, err
+	}
+	if h.deduper == nil {
+		err = h.dispatch(req.Context(), notifyReq, plaintext)
+	} else {
+		err = h.deduper.Do(notifyReq.DedupeKey(), func() error {
+			return h.dispatch(req.Context(), notifyReq, plaintext)
+		})
+		// 处理中的重复通知（gopay.ErrNotifyProcessing）应答 FAIL
+		if errors.Is(err, gopay.ErrNotifyDuplicate) {
+			err = nil
+		}
+	}
+	if err != nil {
+		return http.StatusInternalServerError, err
+	}
+	return http.StatusOK, nil
+}
+
+func (h *V3NotifyHandler) dispatch(ctx context.Context, notifyReq *V3NotifyReq, plaintext []byte) (err error) {
+	var (
+		eventType = notifyReq.EventType
+		fields    map[string]json.RawMessage
+	)
+	if err = json.Unmarshal(plaintext, &fields); err != nil {
+		return fmt.Errorf("json.Unmarshal(%s), err:%+v", string(plaintext), err)
+	}
+	_, isPartner := fields["sp_mchid"]
+	_, isCombine := fields["combine_out_trade_no"]
+
+	switch {
+	case eventType == EventTypeTransactionSuccess && isCombine && h.onCombine != nil:
+		result := new(V3DecryptCombineResult)
+		if err = unmarshalPlaintext(plaintext, result); err != nil {
+			return err
+		}
+		return h.onCombine(ctx, notifyReq, result)
+	case eventType == EventTypeTransactionSuccess && isPartner && h.onPartnerPayment != nil:
+		result := new(V3DecryptPartnerResult)
+		if err = unmarshalPlaintext(plaintext, result); err != nil {
+			return err
+		}
+		return h.onPartnerPayment(ctx, notifyReq, result)
+	case eventType == EventTypeTransactionSuccess && !isCombine && !isPartner && h.onPayment != nil:
+		result := new(V3DecryptResult)
+		if err = unmarshalPlaintext(plaintext, result); err != nil {
+			return err
+		}
+		return h.onPayment(ctx, notifyReq, result)
+	case strings.HasPrefix(eventType, "REFUND.") && isPartner && h.onPartnerRefund != nil:
+		result := new(V3DecryptPartnerRefundResult)
+		if err = unmarshalPlaintext(plaintext, result); err != nil {
+			return err
+		}
+		return h.onPartnerRefund(ctx, notifyReq, result)
+	case strings.HasPrefix(eventType, "REFUND.") && !isPartner && h.onRefund != nil:
+		result := new(V3DecryptRefundResult)
+		if err = unmarshalPlaintext(plaintext, result); err != nil {
+			return err
+		}
+		return h.onRefund(ctx, notifyReq, result)
+	case strings.HasPrefix(eventType, "PAYSCORE.") && h.onScore != nil:
+		result := new(V3DecryptScoreResult)
+		if err = unmarshalPlaintext(plaintext, result); err != nil {
+			return err
+		}
+		return h.onScore(ctx, notifyReq, result)
+	case strings.HasPrefix(eventType, "PROFITSHARING.") && h.onProfitShare != nil:
+		result := new(V3DecryptProfitShareResult)
+		if err = unmarshalPlaintext(plaintext, result); err != nil {
+			return err
+		}
+		return h.onProfitShare(ctx, notifyReq, result)
+	case strings.HasPrefix(eventType, "COMPLAINT.") && h.onComplaint != nil:
+		result := new(V3DecryptComplaintResult)
+		if err = unmarshalPlaintext(plaintext, result); err != nil {
+			return err
+		}
+		return h.onComplaint(ctx, notifyReq, result)
+	case strings.HasPrefix(eventType, "COUPON.") && h.onCoupon != nil:
+		result := new(UserCoupon)
+		if err = unmarshalPlaintext(plaintext, result); err != nil {
+			return err
+		}
+		return h.onCoupon(ctx, notifyReq, result)
+	case h.onOther != nil:
+		return h.onOther(ctx, notifyReq, plaintext)
+	}
+	return fmt.Errorf("event_type [%s] has no callback", eventType)
+}
+
+func (h *V3NotifyHandler) decrypt(notifyReq *V3NotifyReq) (plaintext []byte, err error) {
+	if notifyReq.Resource == nil {
+		return nil, errors.New("notify data Resource is nil")
+	}
+	return decryptResource(notifyReq.Resource, h.client.apiV3Key)
+}
+
+func unmarshalPlaintext(plaintext []byte, result interface{}) (err error) {
+	if err = json.Unmarshal(plaintext, result); err != nil {
+		return fmt.Errorf("json.Unmarshal(%s), err:%+v", string(plaintext), err)
+	}
+	return nil
+}
@@ -0,0 +1,150 @@
+package wechat
+
+import (
+	"context"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/yuanqinguo/gopay"
+	"github.com/yuanqinguo/gopay/pkg/aes"
+	"github.com/yuanqinguo/gopay/pkg/util"
+)
+
+func newTestV3NotifyRequest(t *testing.T, priKey *rsa.PrivateKey, serialNo, apiV3Key, eventType, plaintext string) *http.Request {
+	nonce, cipherBytes, err := aes.GCMEncrypt([]byte(plaintext), []byte("transaction"), []byte(apiV3Key))
+	if err != nil {
+		t.Fatal(err)
+	}
+	bs, _ := json.Marshal(&V3NotifyReq{
+		Id:           "EV-2018022511223320873",
+		EventType:    eventType,
+		ResourceType: "encrypt-resource",
+		Resource: &Resource{
+			Algorithm:      "AEAD_AES_256_GCM",
+			Ciphertext:     base64.StdEncoding.EncodeToString(cipherBytes),
+			AssociatedData: "transaction",
+			Nonce:          string(nonce),
+		},
+	})
+	si := newTestSignInfo(t, priKey, serialNo, string(bs))
+	req := httptest.NewRequest(http.MethodPost, "/notify", strings.NewReader(string(bs)))
+	req.Header.Set(HeaderTimestamp, si.HeaderTimestamp)
+	req.Header.Set(HeaderNonce, si.HeaderNonce)
+	req.Header.Set(HeaderSignature, si.HeaderSignature)
+	req.Header.Set(HeaderSerial, si.HeaderSerial)
+	return req
+}
+
+func TestV3NotifyHandler(t *testing.T) {
+	apiV3Key := "0123456789abcdef0123456789abcdef"
+	now := time.Now()
+	cert, priKey := newTestPlatformCert(t, "SERIAL", now.Add(-time.Hour), now.Add(24*time.Hour))
+	c, err := NewClientV3(MchId, SerialNo, apiV3Key, PrivateKeyContent)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = c.SetPlatformCerts([]*PlatformCertItem{cert}); err != nil {
+		t.Fatal(err)
+	}
+
+	var (
+		payment *V3DecryptResult
+		combine *V3DecryptCombineResult
+		refund  *V3DecryptRefundResult
+	)
+	handler := NewV3NotifyHandler(c).
+		OnPayment(func(ctx context.Context, notifyReq *V3NotifyReq, result *V3DecryptResult) error {
+			payment = result
+			return nil
+		}).
+		OnCombine(func(ctx context.Context, notifyReq *V3NotifyReq, result *V3DecryptCombineResult) error {
+			combine = result
+			return nil
+		}).
+		OnRefund(func(ctx context.Context, notifyReq *V3NotifyReq, result *V3DecryptRefundResult) error {
+			refund = result
+			return nil
+		}).
+		OnError(func(req *http.Request, err error) {})
+
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, newTestV3NotifyRequest(t, priKey, "SERIAL", apiV3Key, EventTypeTransactionSuccess, `{"mchid":"1230000109","out_trade_no":"1217752501201407033233368018","trade_state":"SUCCESS"}`))
+	if w.Code != http.StatusOK || !strings.Contains(w.Body.String(), `"SUCCESS"`) {
+		t.Fatalf("unexpected response: %d %s", w.Code, w.Body.String())
+	}
+	if payment == nil || payment.OutTradeNo != "1217752501201407033233368018" {
+		t.Fatalf("unexpected payment result: %+v", payment)
+	}
+
+	w = httptest.NewRecorder()
+	handler.ServeHTTP(w, newTestV3NotifyRequest(t, priKey, "SERIAL", apiV3Key, EventTypeTransactionSuccess, `{"combine_mchid":"1230000109","combine_out_trade_no":"P20150806125346"}`))
+	if w.Code != http.StatusOK || combine == nil || combine.CombineOutTradeNo != "P20150806125346" {
+		t.Fatalf("unexpected combine result: %d %+v", w.Code, combine)
+	}
+
+	w = httptest.NewRecorder()
+	handler.ServeHTTP(w, newTestV3NotifyRequest(t, priKey, "SERIAL", apiV3Key, EventTypeRefundSuccess, `{"mchid":"1230000109","out_refund_no":"1217752501201407033233368018","refund_status":"SUCCESS"}`))
+	if w.Code != http.StatusOK || refund == nil || refund.RefundStatus != "SUCCESS" {
+		t.Fatalf("unexpected refund result: %d %+v", w.Code, refund)
+	}
+
+	// 未知平台证书序列号，验签失败
+	w = httptest.NewRecorder()
+	handler.ServeHTTP(w, newTestV3NotifyRequest(t, priKey, "UNKNOWN", apiV3Key, EventTypeTransactionSuccess, `{}`))
+	if w.Code != http.StatusUnauthorized || !strings.Contains(w.Body.String(), `"FAIL"`) {
+		t.Fatalf("unexpected response: %d %s", w.Code, w.Body.String())
+	}
+
+	// 未设置回调的通知类型
+	w = httptest.NewRecorder()
+	handler.ServeHTTP(w, newTestV3NotifyRequest(t, priKey, "SERIAL", apiV3Key, EventTypeComplaintCreate, `{"complaint_id":"200201820200101080076610000","action_type":"CREATE_COMPLAINT"}`))
+	if w.Code != http.StatusInternalServerError {
+		t.Fatalf("unexpected response: %d %s", w.Code, w.Body.String())
+	}
+}
+
+func TestV3NotifyHandlerDedupe(t *testing.T) {
+	apiV3Key := "0123456789abcdef0123456789abcdef"
+	now := time.Now()
+	cert, priKey := newTestPlatformCert(t, "SERIAL", now.Add(-time.Hour), now.Add(24*time.Hour))
+	c, err := NewClientV3(MchId, SerialNo, apiV3Key, PrivateKeyContent)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = c.SetPlatformCerts([]*PlatformCertItem{cert}); err != nil {
+		t.Fatal(err)
+	}
+	var count int
+	handler := NewV3NotifyHandler(c).
+		SetDeduper(gopay.NewNotifyDeduper(nil, 0)).
+		SetMaxAge(5 * time.Minute).
+		OnPayment(func(ctx context.Context, notifyReq *V3NotifyReq, result *V3DecryptResult) error {
+			count++
+			return nil
+		})
+	for i := 0; i < 2; i++ {
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, newTestV3NotifyRequest(t, priKey, "SERIAL", apiV3Key, EventTypeTransactionSuccess, `{"out_trade_no":"1217752501201407033233368018"}`))
+		if w.Code != http.StatusOK {
+			t.Fatalf("unexpected response: %d %s", w.Code, w.Body.String())
+		}
+	}
+	if count != 1 {
+		t.Fatalf("expected callback called once, got: %d", count)
+	}
+
+	notifyReq := &V3NotifyReq{SignInfo: &SignInfo{HeaderTimestamp: util.Int642String(now.Add(-10 * time.Minute).Unix())}}
+	if err = notifyReq.CheckTimestamp(5 * time.Minute); err == nil {
+		t.Fatal("expected stale notify rejected")
+	}
+	notifyReq.SignInfo.HeaderTimestamp = util.Int642String(now.Unix())
+	if err = notifyReq.CheckTimestamp(5 * time.Minute); err != nil {
+		t.Fatal(err)
+	}
+}
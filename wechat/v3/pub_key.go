@@ -0,0 +1,60 @@
+package wechat
+
+import (
+	"crypto/rsa"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/yuanqinguo/gopay/pkg/xpem"
+)
+
+// 微信支付公钥 ID 前缀
+const WxPubKeyIdPrefix = "PUB_KEY_ID_"
+
+// SetWxPublicKey 设置微信支付公钥 和 公钥ID（微信支付公钥模式）
+//	wxPublicKeyContent：微信支付公钥 pub_key.pem 读取后的内容，商户平台「API安全」下载
+//	wxPubKeyId：微信支付公钥ID，以 PUB_KEY_ID_ 开头
+//	设置后，敏感信息加密使用微信支付公钥，请求 Header 的 Wechatpay-Serial 为公钥ID；
+//	同步验签、异步通知验签时，Wechatpay-Serial 为公钥ID 的使用微信支付公钥，其余使用平台证书
+//	平台证书切换到微信支付公钥期间，可同时设置平台证书（SetPlatformCert()、SetPlatformCerts() 等），两者均可验签
+func (c *ClientV3) SetWxPublicKey(wxPublicKeyContent []byte, wxPubKeyId string) (err error) {
+	if !strings.HasPrefix(wxPubKeyId, WxPubKeyIdPrefix) {
+		return fmt.Errorf("invalid wechatpay public key id [%s]", wxPubKeyId)
+	}
+	pubKey, err := xpem.DecodePublicKey(wxPublicKeyContent)
+	if err != nil {
+		return err
+	}
+	if pubKey == nil {
+		return errors.New("wechatpay public key is nil")
+	}
+	c.rwMu.Lock()
+	c.wxPubKey = pubKey
+	c.wxPubKeyId = wxPubKeyId
+	c.rwMu.Unlock()
+	return nil
+}
+
+// WxPubKeyId 获取 client 当前设置的微信支付公钥ID，未设置时返回空字符串
+func (c *ClientV3) WxPubKeyId() (wxPubKeyId string) {
+	c.rwMu.RLock()
+	defer c.rwMu.RUnlock()
+	return c.wxPubKeyId
+}
+
+// encryptPublicKey 获取敏感信息加密使用的公钥及对应的 Wechatpay-Serial
+//	设置了微信支付公钥时优先使用微信支付公钥，否则使用平台证书
+func (c *ClientV3) encryptPublicKey() (publicKey *rsa.PublicKey, serialNo string) {
+	c.rwMu.RLock()
+	defer c.rwMu.RUnlock()
+	if c.wxPubKey != nil {
+		return c.wxPubKey, c.wxPubKeyId
+	}
+	return c.wxPublicKey, c.wxSerialNo
+}
+
+// isWxPubKeyId Wechatpay-Serial 是否为微信支付公钥ID
+func isWxPubKeyId(serialNo string) bool {
+	return strings.HasPrefix(serialNo, WxPubKeyIdPrefix)
+}
@@ -0,0 +1,106 @@
+package wechat
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha1"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"errors"
+	"testing"
+	"time"
+)
+
+func newTestWxPublicKey(t *testing.T) ([]byte, *rsa.PrivateKey) {
+	priKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	der, err := x509.MarshalPKIXPublicKey(&priKey.PublicKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der}), priKey
+}
+
+func TestSetWxPublicKey(t *testing.T) {
+	pubKeyContent, priKey := newTestWxPublicKey(t)
+	pubKeyId := "PUB_KEY_ID_0114232134912410000000000000"
+
+	c, err := NewClientV3(MchId, SerialNo, APIv3Key, PrivateKeyContent)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = c.SetWxPublicKey(pubKeyContent, "5157F09EFDC096DE15EBE81A47057A72"); err == nil {
+		t.Fatal("expected error for invalid public key id")
+	}
+	if err = c.SetWxPublicKey(pubKeyContent, pubKeyId); err != nil {
+		t.Fatal(err)
+	}
+	c.AutoVerifySign()
+	if c.getWxSerialNo() != pubKeyId {
+		t.Fatalf("expected Wechatpay-Serial %s, got: %s", pubKeyId, c.getWxSerialNo())
+	}
+
+	// 敏感信息使用微信支付公钥加密
+	cipherText, err := c.V3EncryptText("张三")
+	if err != nil {
+		t.Fatal(err)
+	}
+	cipherByte, _ := base64.StdEncoding.DecodeString(cipherText)
+	text, err := rsa.DecryptOAEP(sha1.New(), rand.Reader, priKey, cipherByte, nil)
+	if err != nil || string(text) != "张三" {
+		t.Fatalf("decrypt failed: %s, %v", text, err)
+	}
+
+	// 同步、异步验签
+	if err = c.verifySyncSign(newTestSignInfo(t, priKey, pubKeyId, "body")); err != nil {
+		t.Fatal(err)
+	}
+	if err = c.VerifyNotifySign(&V3NotifyReq{SignInfo: newTestSignInfo(t, priKey, pubKeyId, "body")}); err != nil {
+		t.Fatal(err)
+	}
+	var serialErr *UnknownSerialError
+	err = c.verifySyncSign(newTestSignInfo(t, priKey, "PUB_KEY_ID_OTHER", "body"))
+	if !errors.As(err, &serialErr) || serialErr.SerialNo != "PUB_KEY_ID_OTHER" {
+		t.Fatalf("expected *UnknownSerialError, got: %v", err)
+	}
+	err = c.verifySyncSign(newTestSignInfo(t, priKey, "5157F09EFDC096DE15EBE81A47057A72", "body"))
+	if !errors.As(err, &serialErr) {
+		t.Fatalf("expected *UnknownSerialError, got: %v", err)
+	}
+}
+
+func TestWxPublicKeyMixedMode(t *testing.T) {
+	now := time.Now()
+	cert, certKey := newTestPlatformCert(t, "PLATFORM", now.Add(-time.Hour), now.Add(24*time.Hour))
+	pubKeyContent, pubKey := newTestWxPublicKey(t)
+	pubKeyId := "PUB_KEY_ID_0114232134912410000000000000"
+
+	c, err := NewClientV3(MchId, SerialNo, APIv3Key, PrivateKeyContent)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = c.SetPlatformCerts([]*PlatformCertItem{cert}); err != nil {
+		t.Fatal(err)
+	}
+	if err = c.SetWxPublicKey(pubKeyContent, pubKeyId); err != nil {
+		t.Fatal(err)
+	}
+	c.AutoVerifySign()
+	if c.getWxSerialNo() != pubKeyId {
+		t.Fatalf("expected Wechatpay-Serial %s, got: %s", pubKeyId, c.getWxSerialNo())
+	}
+
+	// 切换期间，平台证书、微信支付公钥签名均可验签
+	if err = c.verifySyncSign(newTestSignInfo(t, certKey, "PLATFORM", "body")); err != nil {
+		t.Fatal(err)
+	}
+	if err = c.VerifyNotifySign(&V3NotifyReq{SignInfo: newTestSignInfo(t, pubKey, pubKeyId, "body")}); err != nil {
+		t.Fatal(err)
+	}
+	if err = c.VerifyNotifySign(&V3NotifyReq{SignInfo: newTestSignInfo(t, certKey, pubKeyId, "body")}); err == nil {
+		t.Fatal("expected verify failed when serial does not match the signing key")
+	}
+}
@@ -34,6 +34,28 @@ func V3VerifySign(timestamp, nonce, signBody, sign, wxPubKeyContent string) (err
 	return nil
 }
 
+// V3VerifySignByCerts 微信V3 版本验签（同步/异步），使用 Wechatpay-Serial 对应的平台证书验签
+//	serialNo：应答或通知 Header 中的 Wechatpay-Serial
+//	wxPubKeyContents：平台证书序列号 -> 平台证书公钥内容，通过client.GetPlatformCerts() 获取
+//	未找到 serialNo 对应的平台证书时，返回 *UnknownSerialError，可刷新平台证书后重试
+func V3VerifySignByCerts(timestamp, nonce, signBody, sign, serialNo string, wxPubKeyContents map[string]string) (err error) {
+	wxPubKeyContent, ok := wxPubKeyContents[serialNo]
+	if !ok {
+		return &UnknownSerialError{SerialNo: serialNo}
+	}
+	return V3VerifySign(timestamp, nonce, signBody, sign, wxPubKeyContent)
+}
+
+// UnknownSerialError 验签时，未找到 Wechatpay-Serial 对应的平台证书
+//	通常发生在微信平台证书轮换期间，可调用 client.RefreshPlatformCerts() 刷新平台证书后重试
+type UnknownSerialError struct {
+	SerialNo string
+}
+
+func (e *UnknownSerialError) Error() string {
+	return fmt.Sprintf("platform cert serial_no [%s] not found", e.SerialNo)
+}
+
 // PaySignOfJSAPI 获取 JSAPI paySign
 //	文档：https://pay.weixin.qq.com/wiki/doc/apiv3/apis/chapter3_1_4.shtml
 func (c *ClientV3) PaySignOfJSAPI(appid, prepayid string) (jsapi *JSAPIPayParams, err error) {
@@ -115,11 +137,21 @@ func (c *ClientV3) authorization(method, path string, bm gopay.BodyMap) (string,
 	if c.DebugSwitch == gopay.DebugOn {
 		xlog.Debugf("Wechat_V3_SignString:\n%s", _str)
 	}
-	sign, err := c.rsaSign(_str)
+	var (
+		schema = Authorization
+		sign   string
+		err    error
+	)
+	if c.isSM2() {
+		schema = AuthorizationSM2
+		sign, err = c.sm2Sign(_str)
+	} else {
+		sign, err = c.rsaSign(_str)
+	}
 	if err != nil {
 		return "", err
 	}
-	return Authorization + ` mchid="` + c.Mchid + `",nonce_str="` + nonceStr + `",timestamp="` + ts + `",serial_no="` + c.SerialNo + `",signature="` + sign + `"`, nil
+	return schema + ` mchid="` + c.Mchid + `",nonce_str="` + nonceStr + `",timestamp="` + ts + `",serial_no="` + c.SerialNo + `",signature="` + sign + `"`, nil
 }
 
 func (c *ClientV3) rsaSign(str string) (string, error) {
@@ -136,20 +168,36 @@ func (c *ClientV3) rsaSign(str string) (string, error) {
 }
 
 // 自动同步请求验签
+//	已通过 SetPlatformCerts() 或 AutoRefreshPlatformCerts() 加载多个平台证书时，使用应答 Wechatpay-Serial 对应的证书验签
+//	应答 Wechatpay-Serial 为微信支付公钥ID 时，使用微信支付公钥验签；国密模式下使用 SM2 公钥验签
 func (c *ClientV3) verifySyncSign(si *SignInfo) (err error) {
-	if c.autoSign && c.wxPublicKey != nil {
+	c.rwMu.RLock()
+	autoSign, hasPublicKey := c.autoSign, c.wxPublicKey != nil || c.wxPubKey != nil || len(c.sm2WxPubKeys) > 0
+	c.rwMu.RUnlock()
+	if autoSign && hasPublicKey {
 		if si != nil {
-			str := si.HeaderTimestamp + "\n" + si.HeaderNonce + "\n" + si.SignBody + "\n"
-			signBytes, _ := base64.StdEncoding.DecodeString(si.HeaderSignature)
-
-			h := sha256.New()
-			h.Write([]byte(str))
-			if err = rsa.VerifyPKCS1v15(c.wxPublicKey, crypto.SHA256, h.Sum(nil), signBytes); err != nil {
-				return fmt.Errorf("verify sign failed: %+v", err)
+			if c.isSM2() {
+				return c.verifySM2Sign(si)
+			}
+			publicKey, err := c.getPlatformPublicKey(si.HeaderSerial)
+			if err != nil {
+				return err
 			}
-			return nil
+			return verifySignByPublicKey(si, publicKey)
 		}
 		return errors.New("auto verify sign, bug SignInfo is nil")
 	}
 	return nil
 }
+
+func verifySignByPublicKey(si *SignInfo, publicKey *rsa.PublicKey) (err error) {
+	str := si.HeaderTimestamp + "\n" + si.HeaderNonce + "\n" + si.SignBody + "\n"
+	signBytes, _ := base64.StdEncoding.DecodeString(si.HeaderSignature)
+
+	h := sha256.New()
+	h.Write([]byte(str))
+	if err = rsa.VerifyPKCS1v15(publicKey, crypto.SHA256, h.Sum(nil), signBytes); err != nil {
+		return fmt.Errorf("verify sign failed: %+v", err)
+	}
+	return nil
+}
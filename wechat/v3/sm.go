@@ -0,0 +1,158 @@
+package wechat
+
+import (
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"fmt"
+
+	"github.com/emmansun/gmsm/sm4"
+	"github.com/yuanqinguo/gopay"
+	"github.com/yuanqinguo/gopay/pkg/aes"
+	"github.com/yuanqinguo/gopay/pkg/sm2"
+)
+
+// 回调 resource 加密算法
+const (
+	AlgorithmAES256GCM = "AEAD_AES_256_GCM"
+	AlgorithmSM4GCM    = "AEAD_SM4_GCM"
+)
+
+// NewClientV3SM2 初始化微信客户端 V3（国密算法，WECHATPAY2-SM2-WITH-SM3）
+//	mchid：商户ID 或者服务商模式的 sp_mchid
+//	serialNo：商户 SM2 证书的证书序列号
+//	apiV3Key：APIv3Key，回调 resource 为 AEAD_SM4_GCM 时作为 SM4 密钥，长度需为 16 字节
+//	privateKey：商户 SM2 私钥读取后的字符串内容，支持 PKCS#8、SEC1 格式
+//	请求签名使用 SM2-with-SM3；同步验签、异步通知验签使用 client.SetSM2PlatformCert() 设置的微信支付 SM2 公钥
+func NewClientV3SM2(mchid, serialNo, apiV3Key, privateKey string) (client *ClientV3, err error) {
+	priKey, err := sm2.DecodePrivateKey([]byte(privateKey))
+	if err != nil {
+		return nil, err
+	}
+	client = &ClientV3{
+		Mchid:         mchid,
+		SerialNo:      serialNo,
+		apiV3Key:      []byte(apiV3Key),
+		sm2PrivateKey: priKey,
+		DebugSwitch:   gopay.DebugOff,
+	}
+	return client, nil
+}
+
+// SetSM2PlatformCert 设置微信支付 SM2 平台证书（或公钥）和 序列号，国密模式下使用
+//	wxPublicKeyContent：SM2 证书或 PUBLIC KEY 内容
+//	可多次调用设置多个，同步验签、异步通知验签时使用 Wechatpay-Serial 对应的公钥，请求 Header 的 Wechatpay-Serial 为最后设置的序列号
+func (c *ClientV3) SetSM2PlatformCert(wxPublicKeyContent []byte, wxSerialNo string) (err error) {
+	pubKey, err := sm2.DecodePublicKey(wxPublicKeyContent)
+	if err != nil {
+		return err
+	}
+	c.rwMu.Lock()
+	if c.sm2WxPubKeys == nil {
+		c.sm2WxPubKeys = make(map[string]*sm2.PublicKey)
+	}
+	c.sm2WxPubKeys[wxSerialNo] = pubKey
+	c.sm2WxSerialNo = wxSerialNo
+	c.rwMu.Unlock()
+	return nil
+}
+
+// V3VerifySignSM2 微信V3 国密版本验签（同步/异步），签名方式 SM2-with-SM3
+//	wxPubKeyContent：微信支付 SM2 证书或公钥内容
+func V3VerifySignSM2(timestamp, nonce, signBody, sign, wxPubKeyContent string) (err error) {
+	publicKey, err := sm2.DecodePublicKey([]byte(wxPubKeyContent))
+	if err != nil {
+		return err
+	}
+	return verifySignBySM2PublicKey(&SignInfo{
+		HeaderTimestamp: timestamp,
+		HeaderNonce:     nonce,
+		HeaderSignature: sign,
+		SignBody:        signBody,
+	}, publicKey)
+}
+
+func (c *ClientV3) isSM2() bool {
+	return c.sm2PrivateKey != nil
+}
+
+func (c *ClientV3) sm2Sign(str string) (string, error) {
+	result, err := sm2.Sign(rand.Reader, c.sm2PrivateKey, nil, []byte(str))
+	if err != nil {
+		return "", fmt.Errorf("sm2.Sign(),err:%+v", err)
+	}
+	return base64.StdEncoding.EncodeToString(result), nil
+}
+
+// verifySM2Sign 使用 Wechatpay-Serial 对应的微信支付 SM2 公钥验签
+func (c *ClientV3) verifySM2Sign(si *SignInfo) (err error) {
+	c.rwMu.RLock()
+	publicKey, ok := c.sm2WxPubKeys[si.HeaderSerial]
+	if !ok && si.HeaderSerial == "" {
+		publicKey, ok = c.sm2WxPubKeys[c.sm2WxSerialNo]
+	}
+	c.rwMu.RUnlock()
+	if !ok {
+		return &UnknownSerialError{SerialNo: si.HeaderSerial}
+	}
+	return verifySignBySM2PublicKey(si, publicKey)
+}
+
+func verifySignBySM2PublicKey(si *SignInfo, publicKey *sm2.PublicKey) (err error) {
+	str := si.HeaderTimestamp + "\n" + si.HeaderNonce + "\n" + si.SignBody + "\n"
+	signBytes, _ := base64.StdEncoding.DecodeString(si.HeaderSignature)
+	if !sm2.Verify(publicKey, nil, []byte(str), signBytes) {
+		return errors.New("verify sign failed: sm2 verification error")
+	}
+	return nil
+}
+
+// decryptResource 按 resource.algorithm 解密回调中的加密信息，仅支持 AEAD_AES_256_GCM、AEAD_SM4_GCM
+//	algorithm 为空时兼容旧版本，按 AEAD_AES_256_GCM 解密
+func decryptResource(resource *Resource, apiV3Key []byte) (plaintext []byte, err error) {
+	cipherBytes, _ := base64.StdEncoding.DecodeString(resource.Ciphertext)
+	switch resource.Algorithm {
+	case AlgorithmAES256GCM, gopay.NULL:
+		if plaintext, err = aes.GCMDecrypt(cipherBytes, []byte(resource.Nonce), []byte(resource.AssociatedData), apiV3Key); err != nil {
+			return nil, fmt.Errorf("aes.GCMDecrypt, err:%+v", err)
+		}
+	case AlgorithmSM4GCM:
+		if plaintext, err = sm4GCMDecrypt(cipherBytes, []byte(resource.Nonce), []byte(resource.AssociatedData), apiV3Key); err != nil {
+			return nil, fmt.Errorf("sm4GCMDecrypt, err:%+v", err)
+		}
+	default:
+		return nil, fmt.Errorf("unsupported resource algorithm [%s]", resource.Algorithm)
+	}
+	return plaintext, nil
+}
+
+// sm4GCMDecrypt AEAD_SM4_GCM 解密，使用 github.com/emmansun/gmsm/sm4，cipherText 为密文及 16 字节认证标签
+func sm4GCMDecrypt(cipherText, nonce, additional, key []byte) (plaintext []byte, err error) {
+	block, err := sm4.NewCipher(key)
+	if err != nil {
+		return nil, fmt.Errorf("sm4.NewCipher：%w", err)
+	}
+	gcm, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, fmt.Errorf("cipher.NewGCM：%w", err)
+	}
+	if len(nonce) != gcm.NonceSize() {
+		return nil, fmt.Errorf("invalid nonce length: %d", len(nonce))
+	}
+	return gcm.Open(nil, nonce, cipherText, additional)
+}
+
+// decryptNotifyResource 解密回调中的加密信息并解析到结构体
+func decryptNotifyResource(resource *Resource, apiV3Key []byte, result interface{}) (err error) {
+	plaintext, err := decryptResource(resource, apiV3Key)
+	if err != nil {
+		return err
+	}
+	return unmarshalPlaintext(plaintext, result)
+}
+
+// aesResource 未指定算法的加密信息，按 AEAD_AES_256_GCM 解密
+func aesResource(ciphertext, nonce, additional string) *Resource {
+	return &Resource{Algorithm: AlgorithmAES256GCM, Ciphertext: ciphertext, Nonce: nonce, AssociatedData: additional}
+}
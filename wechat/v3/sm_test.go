@@ -0,0 +1,166 @@
+package wechat
+
+import (
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/pem"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/emmansun/gmsm/sm4"
+	"github.com/yuanqinguo/gopay"
+	"github.com/yuanqinguo/gopay/pkg/aes"
+	"github.com/yuanqinguo/gopay/pkg/sm2"
+	"github.com/yuanqinguo/gopay/pkg/util"
+)
+
+func newTestSM2Key(t *testing.T) (*sm2.PrivateKey, string, string) {
+	priKey, err := sm2.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	priDer, err := sm2.MarshalPKCS8PrivateKey(priKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	pubDer, err := sm2.MarshalPKIXPublicKey(&priKey.PublicKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return priKey, string(pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: priDer})), string(pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: pubDer}))
+}
+
+func newTestSM2SignInfo(t *testing.T, priKey *sm2.PrivateKey, serialNo, body string) *SignInfo {
+	si := &SignInfo{
+		HeaderTimestamp: "1554208460",
+		HeaderNonce:     util.GetRandomString(32),
+		HeaderSerial:    serialNo,
+		SignBody:        body,
+	}
+	sign, err := sm2.Sign(rand.Reader, priKey, nil, []byte(si.HeaderTimestamp+"\n"+si.HeaderNonce+"\n"+si.SignBody+"\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	si.HeaderSignature = base64.StdEncoding.EncodeToString(sign)
+	return si
+}
+
+func TestClientV3SM2(t *testing.T) {
+	mchKey, mchKeyContent, mchPubContent := newTestSM2Key(t)
+	wxKey, _, wxPubContent := newTestSM2Key(t)
+
+	c, err := NewClientV3SM2(MchId, SerialNo, "0123456789abcdef", mchKeyContent)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = c.SetSM2PlatformCert([]byte(wxPubContent), "SM2_WX_SERIAL"); err != nil {
+		t.Fatal(err)
+	}
+	c.AutoVerifySign()
+	if c.getWxSerialNo() != "SM2_WX_SERIAL" {
+		t.Fatalf("unexpected Wechatpay-Serial: %s", c.getWxSerialNo())
+	}
+
+	// 请求签名
+	authorization, err := c.authorization(MethodPost, "/v3/pay/transactions/jsapi", make(gopay.BodyMap).Set("appid", "wxd678efh567hg6787"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasPrefix(authorization, AuthorizationSM2+" ") {
+		t.Fatalf("unexpected authorization: %s", authorization)
+	}
+	var nonce, ts, sign string
+	for _, kv := range strings.Split(strings.TrimPrefix(authorization, AuthorizationSM2+" "), ",") {
+		kvs := strings.SplitN(kv, "=", 2)
+		switch v := strings.Trim(kvs[1], `"`); kvs[0] {
+		case "nonce_str":
+			nonce = v
+		case "timestamp":
+			ts = v
+		case "signature":
+			sign = v
+		}
+	}
+	signStr := "POST\n/v3/pay/transactions/jsapi\n" + ts + "\n" + nonce + "\n" + `{"appid":"wxd678efh567hg6787"}` + "\n"
+	signBytes, _ := base64.StdEncoding.DecodeString(sign)
+	mchPub, _ := sm2.DecodePublicKey([]byte(mchPubContent))
+	if !sm2.Verify(mchPub, nil, []byte(signStr), signBytes) || mchKey.X.Cmp(mchPub.X) != 0 {
+		t.Fatal("verify authorization signature failed")
+	}
+
+	// 同步、异步验签
+	if err = c.verifySyncSign(newTestSM2SignInfo(t, wxKey, "SM2_WX_SERIAL", "body")); err != nil {
+		t.Fatal(err)
+	}
+	if err = c.VerifyNotifySign(&V3NotifyReq{SignInfo: newTestSM2SignInfo(t, wxKey, "SM2_WX_SERIAL", "body")}); err != nil {
+		t.Fatal(err)
+	}
+	if err = c.verifySyncSign(newTestSM2SignInfo(t, mchKey, "SM2_WX_SERIAL", "body")); err == nil {
+		t.Fatal("expected verify failed with other key")
+	}
+	var serialErr *UnknownSerialError
+	if err = c.verifySyncSign(newTestSM2SignInfo(t, wxKey, "OTHER", "body")); !errors.As(err, &serialErr) {
+		t.Fatalf("expected *UnknownSerialError, got: %v", err)
+	}
+	si := newTestSM2SignInfo(t, wxKey, "SM2_WX_SERIAL", "body")
+	if err = V3VerifySignSM2(si.HeaderTimestamp, si.HeaderNonce, si.SignBody, si.HeaderSignature, wxPubContent); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestDecryptCipherTextSM4(t *testing.T) {
+	apiV3Key := "0123456789abcdef"
+	plaintext := `{"mchid":"1230000109","out_trade_no":"1217752501201407033233368018","trade_state":"SUCCESS"}`
+	block, err := sm4.NewCipher([]byte(apiV3Key))
+	if err != nil {
+		t.Fatal(err)
+	}
+	gcm, _ := cipher.NewGCM(block)
+	cipherBytes := gcm.Seal(nil, []byte("fdasflkja484"), []byte(plaintext), []byte("transaction"))
+	notifyReq := &V3NotifyReq{Resource: &Resource{
+		Algorithm:      AlgorithmSM4GCM,
+		Ciphertext:     base64.StdEncoding.EncodeToString(cipherBytes),
+		AssociatedData: "transaction",
+		Nonce:          "fdasflkja484",
+	}}
+	result := new(V3DecryptResult)
+	if err = notifyReq.DecryptCipherTextToStruct(apiV3Key, result); err != nil {
+		t.Fatal(err)
+	}
+	if result.OutTradeNo != "1217752501201407033233368018" || result.TradeState != "SUCCESS" {
+		t.Fatalf("unexpected result: %+v", result)
+	}
+	if result, err = notifyReq.DecryptCipherText(apiV3Key); err != nil || result.TradeState != "SUCCESS" {
+		t.Fatalf("DecryptCipherText: %+v, %v", result, err)
+	}
+	notifyReq.Resource.Algorithm = AlgorithmAES256GCM
+	if err = notifyReq.DecryptCipherTextToStruct(apiV3Key, result); err == nil {
+		t.Fatal("expected decrypt failed with AEAD_AES_256_GCM")
+	}
+	// 未知算法不再按 AEAD_AES_256_GCM 解密
+	notifyReq.Resource.Algorithm = "AEAD_UNKNOWN"
+	if _, err = notifyReq.DecryptCipherText(apiV3Key); err == nil || !strings.Contains(err.Error(), "unsupported resource algorithm") {
+		t.Fatalf("expected unsupported algorithm error, got: %v", err)
+	}
+}
+
+func TestDecryptResourceEmptyAlgorithm(t *testing.T) {
+	apiV3Key := "0123456789abcdef0123456789abcdef"
+	plaintext := `{"mchid":"1230000109","out_trade_no":"1217752501201407033233368018","trade_state":"SUCCESS"}`
+	nonce, cipherBytes, err := aes.GCMEncrypt([]byte(plaintext), []byte("transaction"), []byte(apiV3Key))
+	if err != nil {
+		t.Fatal(err)
+	}
+	// 未设置 algorithm 的 Resource 按 AEAD_AES_256_GCM 解密
+	notifyReq := &V3NotifyReq{Resource: &Resource{
+		Ciphertext:     base64.StdEncoding.EncodeToString(cipherBytes),
+		AssociatedData: "transaction",
+		Nonce:          string(nonce),
+	}}
+	result, err := notifyReq.DecryptCipherText(apiV3Key)
+	if err != nil || result.OutTradeNo != "1217752501201407033233368018" {
+		t.Fatalf("DecryptCipherText: %+v, %v", result, err)
+	}
+}
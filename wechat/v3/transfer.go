@@ -31,7 +31,7 @@ func (c *ClientV3) V3Transfer(bm gopay.BodyMap) (*TransferRsp, error) {
 	if res.StatusCode != http.StatusOK {
 		wxRsp.Code = res.StatusCode
 		wxRsp.Error = string(bs)
-		return wxRsp, nil
+		return wxRsp, newError(res, bs)
 	}
 	return wxRsp, c.verifySyncSign(si)
 }
@@ -61,7 +61,7 @@ func (c *ClientV3) V3TransferQuery(batchId string, bm gopay.BodyMap) (*TransferQ
 	if res.StatusCode != http.StatusOK {
 		wxRsp.Code = res.StatusCode
 		wxRsp.Error = string(bs)
-		return wxRsp, nil
+		return wxRsp, newError(res, bs)
 	}
 	return wxRsp, c.verifySyncSign(si)
 }
@@ -89,7 +89,7 @@ func (c *ClientV3) V3TransferDetailQuery(batchId, detailId string) (*TransferDet
 	if res.StatusCode != http.StatusOK {
 		wxRsp.Code = res.StatusCode
 		wxRsp.Error = string(bs)
-		return wxRsp, nil
+		return wxRsp, newError(res, bs)
 	}
 	return wxRsp, c.verifySyncSign(si)
 }
@@ -119,7 +119,7 @@ func (c *ClientV3) V3TransferMerchantQuery(outBatchNo string, bm gopay.BodyMap)
 	if res.StatusCode != http.StatusOK {
 		wxRsp.Code = res.StatusCode
 		wxRsp.Error = string(bs)
-		return wxRsp, nil
+		return wxRsp, newError(res, bs)
 	}
 	return wxRsp, c.verifySyncSign(si)
 }
@@ -147,7 +147,7 @@ func (c *ClientV3) V3TransferMerchantDetailQuery(outBatchNo, outDetailNo string)
 	if res.StatusCode != http.StatusOK {
 		wxRsp.Code = res.StatusCode
 		wxRsp.Error = string(bs)
-		return wxRsp, nil
+		return wxRsp, newError(res, bs)
 	}
 	return wxRsp, c.verifySyncSign(si)
 }
@@ -177,7 +177,7 @@ func (c *ClientV3) V3TransferReceipt(outBatchNo string) (*TransferReceiptRsp, er
 	if res.StatusCode != http.StatusOK {
 		wxRsp.Code = res.StatusCode
 		wxRsp.Error = string(bs)
-		return wxRsp, nil
+		return wxRsp, newError(res, bs)
 	}
 	return wxRsp, c.verifySyncSign(si)
 }
@@ -205,7 +205,7 @@ func (c *ClientV3) V3TransferReceiptQuery(outBatchNo string) (*TransferReceiptQu
 	if res.StatusCode != http.StatusOK {
 		wxRsp.Code = res.StatusCode
 		wxRsp.Error = string(bs)
-		return wxRsp, nil
+		return wxRsp, newError(res, bs)
 	}
 	return wxRsp, c.verifySyncSign(si)
 }
@@ -232,7 +232,7 @@ func (c *ClientV3) V3TransferDetailReceipt(bm gopay.BodyMap) (*TransferDetailRec
 	if res.StatusCode != http.StatusOK {
 		wxRsp.Code = res.StatusCode
 		wxRsp.Error = string(bs)
-		return wxRsp, nil
+		return wxRsp, newError(res, bs)
 	}
 	return wxRsp, c.verifySyncSign(si)
 }
@@ -260,7 +260,7 @@ func (c *ClientV3) V3TransferDetailReceiptQuery(bm gopay.BodyMap) (*TransferDeta
 	if res.StatusCode != http.StatusOK {
 		wxRsp.Code = res.StatusCode
 		wxRsp.Error = string(bs)
-		return wxRsp, nil
+		return wxRsp, newError(res, bs)
 	}
 	return wxRsp, c.verifySyncSign(si)
 }
@@ -28,7 +28,7 @@ func (c *ClientV3) V3CommerceFundWithDraw(bm gopay.BodyMap) (*CommerceFundWithdr
 	if res.StatusCode != http.StatusOK {
 		wxRsp.Code = res.StatusCode
 		wxRsp.Error = string(bs)
-		return wxRsp, nil
+		return wxRsp, newError(res, bs)
 	}
 	return wxRsp, c.verifySyncSign(si)
 }
@@ -56,7 +56,7 @@ func (c *ClientV3) V3CommerceFundWithdrawQuery(subMchId, withdrawId string) (*Co
 	if res.StatusCode != http.StatusOK {
 		wxRsp.Code = res.StatusCode
 		wxRsp.Error = string(bs)
-		return wxRsp, nil
+		return wxRsp, newError(res, bs)
 	}
 	return wxRsp, c.verifySyncSign(si)
 }
@@ -84,7 +84,7 @@ func (c *ClientV3) V3CommerceFundWithdrawOutRequestNoQuery(subMchId, outRequestN
 	if res.StatusCode != http.StatusOK {
 		wxRsp.Code = res.StatusCode
 		wxRsp.Error = string(bs)
-		return wxRsp, nil
+		return wxRsp, newError(res, bs)
 	}
 	return wxRsp, c.verifySyncSign(si)
 }